@@ -7,7 +7,6 @@ import (
 	"os"
 
 	"github.com/iuif/minecraft-mod-dictionary/internal/analyzer"
-	"github.com/iuif/minecraft-mod-dictionary/internal/database"
 )
 
 func runAnalyze(args []string) error {
@@ -33,7 +32,7 @@ func runAnalyze(args []string) error {
 	fs := flag.NewFlagSet("analyze", flag.ExitOnError)
 
 	var (
-		dbPath   = fs.String("db", "moddict.db", "Database file path")
+		dbPath   = fs.String("db", "moddict.db", "Database file path or postgres:// URL")
 		modID    = fs.String("mod", "", "Target mod ID (empty for all mods)")
 		format   = fs.String("format", "summary", "Output format: summary, json, csv")
 		outPath  = fs.String("out", "", "Output file path (empty for stdout)")
@@ -67,9 +66,9 @@ Examples:
 		return err
 	}
 
-	repo, err := database.NewRepository(*dbPath)
+	repo, err := openReadOnlyRepository(*dbPath)
 	if err != nil {
-		return fmt.Errorf("failed to open database: %w", err)
+		return err
 	}
 	defer repo.Close()
 
@@ -14,17 +14,29 @@ import (
 )
 
 // termFile represents the structure of a YAML term file.
+// Files either carry the full scope ("mod:botania"), "scope: mod" with a separate mod_id,
+// or a bare mod ID ("scope: embers"). The file-level priority applies to terms without one.
 type termFile struct {
-	Scope string `yaml:"scope"`
-	Terms []struct {
-		Source   string   `yaml:"source"`
-		Target   string   `yaml:"target"`
-		Tags     []string `yaml:"tags,omitempty"`
-		Priority int      `yaml:"priority,omitempty"`
-		Context  string   `yaml:"context,omitempty"`
-	} `yaml:"terms"`
+	Scope    string      `yaml:"scope"`
+	ModID    string      `yaml:"mod_id,omitempty"`
+	Priority interface{} `yaml:"priority,omitempty"` // a number; descriptive values like "high" are ignored
+	Terms    []termEntry `yaml:"terms"`
 }
 
+// termEntry is a term in a YAML term file.
+type termEntry struct {
+	Source   string   `yaml:"source"`
+	Target   string   `yaml:"target"`
+	Tags     []string `yaml:"tags,omitempty"`
+	Priority int      `yaml:"priority,omitempty"`
+	Context  string   `yaml:"context,omitempty"`
+	Notes    string   `yaml:"notes,omitempty"`
+	Origin   string   `yaml:"origin,omitempty"`
+}
+
+// defaultTermPriority is used for terms without a term or file priority.
+const defaultTermPriority = 100
+
 // patternFile represents the structure of a YAML pattern file.
 type patternFile struct {
 	Patterns []struct {
@@ -41,11 +53,12 @@ func runBuild(args []string) error {
 	fs := flag.NewFlagSet("build", flag.ExitOnError)
 
 	var (
-		dbPath   = fs.String("db", "moddict.db", "Database file path")
-		dataDir  = fs.String("data", "data", "Data directory containing YAML files")
-		termDir  = fs.String("terms", "", "Terms directory (default: data/terms)")
-		patDir   = fs.String("patterns", "", "Patterns directory (default: data/patterns)")
-		clean    = fs.Bool("clean", false, "Clean existing data before import")
+		dbPath     = fs.String("db", "moddict.db", "Database file path or postgres:// URL")
+		dataDir    = fs.String("data", "data", "Data directory containing YAML files")
+		termDir    = fs.String("terms", "", "Terms directory (default: data/terms)")
+		patDir     = fs.String("patterns", "", "Patterns directory (default: data/patterns)")
+		clean      = fs.Bool("clean", false, "Clean existing data before import")
+		noSnapshot = fs.Bool("no-snapshot", false, "Do not snapshot the database before -clean removes it")
 	)
 
 	fs.Usage = func() {
@@ -78,6 +91,14 @@ Examples:
 
 	// Clean database if requested
 	if *clean {
+		if database.IsPostgresDSN(*dbPath) {
+			return fmt.Errorf("-clean only removes SQLite files; drop the PostgreSQL schema manually")
+		}
+		if !*noSnapshot {
+			if err := snapshotBeforeChange(*dbPath, "build-clean"); err != nil {
+				return err
+			}
+		}
 		os.Remove(*dbPath)
 		fmt.Println("Cleaned existing database")
 	}
@@ -136,47 +157,160 @@ func importTerms(ctx context.Context, repo *database.Repository, dir string) (in
 			return fmt.Errorf("failed to read %s: %w", path, err)
 		}
 
-		var file termFile
-		if err := yaml.Unmarshal(content, &file); err != nil {
+		terms, err := parseTermFile(content)
+		if err != nil {
 			return fmt.Errorf("failed to parse %s: %w", path, err)
 		}
 
-		terms := make([]*models.Term, 0, len(file.Terms))
-		for _, t := range file.Terms {
+		if len(terms) > 0 {
+			if err := repo.BulkSaveTerms(ctx, terms); err != nil {
+				return fmt.Errorf("failed to save terms from %s: %w", path, err)
+			}
+			totalCount += len(terms)
+			fmt.Printf("  Loaded %d terms from %s\n", len(terms), filepath.Base(path))
+		} else {
+			fmt.Printf("  Skipped %s: no terms in a supported format\n", filepath.Base(path))
+		}
+
+		return nil
+	})
+
+	return totalCount, err
+}
+
+// parseTermFile reads the terms of a YAML term file. Besides the "terms" list, a file
+// may map scopes directly to source: target pairs:
+//
+//	mod:botania:
+//	  Mana: マナ
+//
+// Entries without a source or target are skipped.
+func parseTermFile(content []byte) ([]*models.Term, error) {
+	var file termFile
+	if err := yaml.Unmarshal(content, &file); err != nil {
+		return nil, err
+	}
+
+	filePriority := defaultTermPriority
+	if p, ok := file.Priority.(int); ok && p > 0 {
+		filePriority = p
+	}
+
+	entries := make(map[string][]termEntry)
+	var scopes []string
+	if len(file.Terms) > 0 {
+		scope := termFileScope(file.Scope, file.ModID)
+		scopes = append(scopes, scope)
+		entries[scope] = file.Terms
+	}
+
+	flat, order, err := flatTermEntries(content)
+	if err != nil {
+		return nil, err
+	}
+	for _, scope := range order {
+		if _, ok := entries[scope]; !ok {
+			scopes = append(scopes, scope)
+		}
+		entries[scope] = append(entries[scope], flat[scope]...)
+	}
+
+	var terms []*models.Term
+	for _, scope := range scopes {
+		for _, t := range entries[scope] {
+			if t.Source == "" || t.Target == "" {
+				continue
+			}
+
 			priority := t.Priority
 			if priority == 0 {
-				priority = 100
+				priority = filePriority
 			}
 
 			term := &models.Term{
-				Scope:      file.Scope,
+				Scope:      scope,
 				SourceText: t.Source,
 				TargetText: t.Target,
 				SourceLang: "en_us",
 				TargetLang: "ja_jp",
 				Tags:       t.Tags,
 				Priority:   priority,
+				Source:     t.Origin,
 			}
 
 			if t.Context != "" {
 				term.Context = &t.Context
 			}
+			if t.Notes != "" {
+				term.Notes = &t.Notes
+			}
 
 			terms = append(terms, term)
 		}
+	}
 
-		if len(terms) > 0 {
-			if err := repo.BulkSaveTerms(ctx, terms); err != nil {
-				return fmt.Errorf("failed to save terms from %s: %w", path, err)
-			}
-			totalCount += len(terms)
-			fmt.Printf("  Loaded %d terms from %s\n", len(terms), filepath.Base(path))
+	return terms, nil
+}
+
+// termFileScope returns the full scope of a term file's "scope" and "mod_id" fields.
+func termFileScope(scope, modID string) string {
+	switch {
+	case scope == "" || scope == models.ScopeMod:
+		if modID == "" {
+			return scope
 		}
+		return models.BuildScope(models.ScopeMod, modID)
+	case isTermScope(scope):
+		return scope
+	default:
+		// A bare mod ID
+		return models.BuildScope(models.ScopeMod, scope)
+	}
+}
 
-		return nil
-	})
+// flatTermEntries returns the source: target pairs of top-level scope keys, by scope,
+// and the scopes in file order.
+func flatTermEntries(content []byte) (map[string][]termEntry, []string, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(content, &doc); err != nil {
+		return nil, nil, err
+	}
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return nil, nil, nil
+	}
 
-	return totalCount, err
+	entries := make(map[string][]termEntry)
+	var order []string
+	root := doc.Content[0]
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		key, value := root.Content[i], root.Content[i+1]
+		if value.Kind != yaml.MappingNode || !isTermScope(key.Value) {
+			continue
+		}
+		if _, ok := entries[key.Value]; !ok {
+			order = append(order, key.Value)
+		}
+		for j := 0; j+1 < len(value.Content); j += 2 {
+			source, target := value.Content[j], value.Content[j+1]
+			if target.Kind != yaml.ScalarNode {
+				continue
+			}
+			entries[key.Value] = append(entries[key.Value], termEntry{Source: source.Value, Target: target.Value})
+		}
+	}
+	return entries, order, nil
+}
+
+// isTermScope reports whether s is a full term scope: "global", "category:<name>" or "mod:<id>".
+func isTermScope(s string) bool {
+	scopeType, value := models.ParseScope(s)
+	switch scopeType {
+	case models.ScopeGlobal:
+		return true
+	case models.ScopeCategory, models.ScopeMod:
+		return value != ""
+	}
+	return false
 }
 
 func importPatterns(ctx context.Context, repo *database.Repository, dir string) (int, error) {
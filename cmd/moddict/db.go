@@ -0,0 +1,281 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/iuif/minecraft-mod-dictionary/internal/database"
+)
+
+func runDB(args []string) error {
+	if len(args) == 0 {
+		printDBUsage()
+		return fmt.Errorf("subcommand is required")
+	}
+
+	switch args[0] {
+	case "migrate":
+		return runDBMigrate(args[1:])
+	case "status":
+		return runDBStatus(args[1:])
+	case "check":
+		return runDBCheck(args[1:])
+	case "backup":
+		return runDBBackup(args[1:])
+	case "restore":
+		return runDBRestore(args[1:])
+	case "snapshots":
+		return runDBSnapshots(args[1:])
+	case "-h", "--help", "-help", "help":
+		printDBUsage()
+		return nil
+	default:
+		return fmt.Errorf("unknown subcommand: %s", args[0])
+	}
+}
+
+func printDBUsage() {
+	fmt.Print(`Usage: moddict db <subcommand> [options]
+
+Manage the database schema and backups. The schema is versioned by numbered
+migrations recorded in the schema_migrations table.
+
+Subcommands:
+  migrate    Apply (or revert) schema migrations
+  status     Show the schema version and the state of each migration
+  check      Report rows that violate the unique indexes and foreign keys
+  backup     Copy the database or create a timestamped snapshot
+  restore    Restore the database from a backup or snapshot
+  snapshots  List the snapshots of the database
+
+Use "moddict db <subcommand> --help" for more information.
+`)
+}
+
+func runDBMigrate(args []string) error {
+	fs := flag.NewFlagSet("db migrate", flag.ExitOnError)
+
+	var (
+		dbPath     = fs.String("db", "moddict.db", "Database file path or postgres:// URL")
+		to         = fs.Int("to", database.LatestSchemaVersion(), "Target schema version (lower than the current version reverts migrations)")
+		noSnapshot = fs.Bool("no-snapshot", false, "Do not snapshot the database before migrating")
+	)
+
+	fs.Usage = func() {
+		fmt.Print(`Usage: moddict db migrate [options]
+
+Bring the database schema to the latest (or the given) version. Databases
+created before schema versioning are detected and upgraded in place,
+including linking legacy translations to translation sources. A snapshot
+of the database is saved before any migration runs.
+
+Options:
+`)
+		fs.PrintDefaults()
+		fmt.Print(`
+Examples:
+  moddict db migrate
+  moddict db migrate -to 3
+`)
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	return migrateDatabase(*dbPath, *to, !*noSnapshot)
+}
+
+// migrateDatabase runs the migrations needed to reach the target version and prints them.
+// With snapshot set, an existing database is snapshotted before the first step.
+func migrateDatabase(dbPath string, target int, snapshot bool) error {
+	_, statErr := os.Stat(dbPath)
+	existed := statErr == nil
+
+	repo, err := database.NewRepository(dbPath)
+	if err != nil {
+		return fmt.Errorf("failed to open database: %w", err)
+	}
+	defer repo.Close()
+
+	ctx := context.Background()
+
+	from, err := repo.SchemaVersion(ctx)
+	if err != nil {
+		return err
+	}
+
+	if snapshot && existed && from != target {
+		if err := snapshotBeforeChange(dbPath, "migrate"); err != nil {
+			return err
+		}
+	}
+
+	var run []database.Migration
+
+	// Report the rows the constraints migration will merge or delete before it runs
+	if from < database.ConstraintsSchemaVersion && target >= database.ConstraintsSchemaVersion {
+		steps, err := repo.MigrateTo(ctx, database.ConstraintsSchemaVersion-1)
+		run = append(run, steps...)
+		printMigrationSteps(steps, target)
+		if err != nil {
+			return err
+		}
+
+		report, err := repo.CheckConstraints(ctx)
+		if err != nil {
+			return err
+		}
+		if report.Total() > 0 {
+			fmt.Println("Resolving constraint violations before adding constraints:")
+			printConstraintReport(report)
+		}
+	}
+
+	steps, err := repo.MigrateTo(ctx, target)
+	run = append(run, steps...)
+	printMigrationSteps(steps, target)
+	if err != nil {
+		return err
+	}
+
+	if len(run) == 0 {
+		fmt.Printf("Schema is up to date (version %d)\n", from)
+		return nil
+	}
+	fmt.Printf("Schema migrated from version %d to %d\n", from, target)
+	return nil
+}
+
+func printMigrationSteps(steps []database.Migration, target int) {
+	for _, m := range steps {
+		if m.Version > target {
+			fmt.Printf("  Reverted %03d %s\n", m.Version, m.Name)
+		} else {
+			fmt.Printf("  Applied  %03d %s\n", m.Version, m.Name)
+		}
+	}
+}
+
+func printConstraintReport(report *database.ConstraintReport) {
+	fmt.Printf("  Missing mods (will be created):             %d\n", report.MissingMods)
+	fmt.Printf("  Duplicate sources (will be merged):         %d\n", report.DuplicateSources)
+	fmt.Printf("  Duplicate version links (will be removed):  %d\n", report.DuplicateSourceVersions)
+	fmt.Printf("  Duplicate translations (best one is kept):  %d\n", report.DuplicateTranslations)
+	fmt.Printf("  Links without source/version (removed):     %d\n", report.OrphanSourceVersions)
+	fmt.Printf("  Translations without source (removed):      %d\n", report.OrphanTranslations)
+}
+
+func runDBCheck(args []string) error {
+	fs := flag.NewFlagSet("db check", flag.ExitOnError)
+
+	dbPath := fs.String("db", "moddict.db", "Database file path or postgres:// URL")
+
+	fs.Usage = func() {
+		fmt.Print(`Usage: moddict db check [options]
+
+Report rows that violate the unique indexes and foreign keys of the source
+schema (duplicate sources, version links and translations, and rows whose
+mod, source or version is missing). "moddict db migrate" resolves them before
+adding the constraints; databases that have the constraints report none.
+
+Options:
+`)
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	repo, err := database.NewRepository(*dbPath)
+	if err != nil {
+		return fmt.Errorf("failed to open database: %w", err)
+	}
+	defer repo.Close()
+
+	report, err := repo.CheckConstraints(context.Background())
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Constraint check: %s\n", *dbPath)
+	printConstraintReport(report)
+	if report.Total() == 0 {
+		fmt.Println("No violations found")
+	} else {
+		fmt.Printf("%d violations; run \"moddict db migrate\" to resolve them\n", report.Total())
+	}
+	return nil
+}
+
+func runDBStatus(args []string) error {
+	fs := flag.NewFlagSet("db status", flag.ExitOnError)
+
+	dbPath := fs.String("db", "moddict.db", "Database file path or postgres:// URL")
+
+	fs.Usage = func() {
+		fmt.Print(`Usage: moddict db status [options]
+
+Show the current schema version and which migrations have been applied.
+
+Options:
+`)
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	repo, err := database.NewRepository(*dbPath)
+	if err != nil {
+		return fmt.Errorf("failed to open database: %w", err)
+	}
+	defer repo.Close()
+
+	ctx := context.Background()
+
+	states, err := repo.MigrationStatus(ctx)
+	if err != nil {
+		return err
+	}
+	current, err := repo.SchemaVersion(ctx)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Database: %s\n", *dbPath)
+	fmt.Printf("Schema version: %d (latest %d)\n\n", current, database.LatestSchemaVersion())
+	fmt.Printf("%-8s %-36s %-8s %s\n", "Version", "Name", "State", "Applied at")
+	for _, s := range states {
+		state, appliedAt := "pending", ""
+		if s.Applied {
+			state = "applied"
+			appliedAt = s.AppliedAt.Local().Format("2006-01-02 15:04:05")
+		}
+		fmt.Printf("%-8d %-36s %-8s %s\n", s.Version, s.Name, state, appliedAt)
+	}
+
+	if current < database.LatestSchemaVersion() {
+		fmt.Printf("\nRun \"moddict db migrate\" to apply pending migrations.\n")
+	}
+	return nil
+}
+
+// openReadOnlyRepository opens the database for reading. Read-only commands cannot migrate,
+// so a database that is not at the latest schema version is rejected with a hint to run
+// "moddict db migrate" instead of failing later on a missing column.
+func openReadOnlyRepository(dbPath string) (*database.Repository, error) {
+	repo, err := database.NewReadOnlyRepository(dbPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+	if err := repo.CheckSchemaVersion(context.Background()); err != nil {
+		repo.Close()
+		return nil, err
+	}
+	return repo, nil
+}
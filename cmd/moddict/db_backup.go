@@ -0,0 +1,217 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/iuif/minecraft-mod-dictionary/internal/database"
+)
+
+// defaultSnapshotKeep is how many snapshots are kept per database file.
+const defaultSnapshotKeep = 10
+
+func runDBBackup(args []string) error {
+	fs := flag.NewFlagSet("db backup", flag.ExitOnError)
+
+	var (
+		dbPath = fs.String("db", "moddict.db", "Database file path")
+		out    = fs.String("out", "", "Backup file path (default: timestamped snapshot in snapshots/ next to the database)")
+		keep   = fs.Int("keep", defaultSnapshotKeep, "Number of snapshots to keep (0 = keep all, ignored with -out)")
+	)
+
+	fs.Usage = func() {
+		fmt.Print(`Usage: moddict db backup [options]
+
+Copy the database with the SQLite online backup API. The copy is consistent
+even while an import or translation batch is writing. Without -out a
+timestamped snapshot is created and snapshots beyond -keep are deleted.
+
+Options:
+`)
+		fs.PrintDefaults()
+		fmt.Print(`
+Examples:
+  moddict db backup
+  moddict db backup -out backups/before-batch.db
+  moddict db backup -keep 30
+`)
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *out == "" {
+		path, err := createSnapshot(*dbPath, "", *keep)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Snapshot saved: %s\n", path)
+		return nil
+	}
+
+	repo, err := database.NewReadOnlyRepository(*dbPath)
+	if err != nil {
+		return fmt.Errorf("failed to open database: %w", err)
+	}
+	defer repo.Close()
+
+	if err := repo.Backup(context.Background(), *out); err != nil {
+		return err
+	}
+	fmt.Printf("Backup saved: %s\n", *out)
+	return nil
+}
+
+func runDBRestore(args []string) error {
+	fs := flag.NewFlagSet("db restore", flag.ExitOnError)
+
+	var (
+		dbPath     = fs.String("db", "moddict.db", "Database file path")
+		from       = fs.String("from", "", "Backup file to restore, or \"latest\" for the newest snapshot (required)")
+		noSnapshot = fs.Bool("no-snapshot", false, "Do not snapshot the current database before restoring")
+	)
+
+	fs.Usage = func() {
+		fmt.Print(`Usage: moddict db restore -from <file|latest> [options]
+
+Replace the database contents with a backup or snapshot. The current
+database is snapshotted first, so a restore can itself be undone.
+
+Options:
+`)
+		fs.PrintDefaults()
+		fmt.Print(`
+Examples:
+  moddict db restore -from latest
+  moddict db restore -from snapshots/moddict-20251228-153000.000-repair.db
+`)
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *from == "" {
+		fs.Usage()
+		return fmt.Errorf("-from is required")
+	}
+
+	src := *from
+	if src == "latest" {
+		snapshots, err := database.ListSnapshots(*dbPath)
+		if err != nil {
+			return err
+		}
+		if len(snapshots) == 0 {
+			return fmt.Errorf("no snapshots found in %s", database.SnapshotDir(*dbPath))
+		}
+		src = snapshots[len(snapshots)-1].Path
+	}
+
+	if !*noSnapshot {
+		// Pruning must not delete the snapshot being restored
+		if err := snapshotBeforeChange(*dbPath, "pre-restore", src); err != nil {
+			return err
+		}
+	}
+
+	repo, err := database.NewRepository(*dbPath)
+	if err != nil {
+		return fmt.Errorf("failed to open database: %w", err)
+	}
+	defer repo.Close()
+
+	if err := repo.Restore(context.Background(), src); err != nil {
+		return err
+	}
+	fmt.Printf("Restored %s from %s\n", *dbPath, src)
+	return nil
+}
+
+func runDBSnapshots(args []string) error {
+	fs := flag.NewFlagSet("db snapshots", flag.ExitOnError)
+	dbPath := fs.String("db", "moddict.db", "Database file path")
+
+	fs.Usage = func() {
+		fmt.Print(`Usage: moddict db snapshots [options]
+
+List the snapshots of the database, oldest first.
+
+Options:
+`)
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	snapshots, err := database.ListSnapshots(*dbPath)
+	if err != nil {
+		return err
+	}
+	if len(snapshots) == 0 {
+		fmt.Printf("No snapshots in %s\n", database.SnapshotDir(*dbPath))
+		return nil
+	}
+
+	fmt.Printf("Snapshots in %s:\n", database.SnapshotDir(*dbPath))
+	for _, s := range snapshots {
+		label := s.Label
+		if label == "" {
+			label = "manual"
+		}
+		fmt.Printf("  %s  %-14s %8.1f MB  %s\n", s.CreatedAt.Format("2006-01-02 15:04:05"), label, float64(s.Size)/(1024*1024), s.Path)
+	}
+	return nil
+}
+
+// snapshotBeforeChange saves a snapshot before a destructive command. Missing database
+// files (nothing to lose) and PostgreSQL databases are skipped. Snapshots in protect
+// are kept when old snapshots are pruned.
+func snapshotBeforeChange(dbPath, label string, protect ...string) error {
+	if database.IsPostgresDSN(dbPath) {
+		fmt.Println("Skipping snapshot: back up PostgreSQL databases with pg_dump")
+		return nil
+	}
+	if _, err := os.Stat(dbPath); err != nil {
+		return nil
+	}
+
+	path, err := createSnapshot(dbPath, label, defaultSnapshotKeep, protect...)
+	if err != nil {
+		return fmt.Errorf("failed to snapshot database before %s (use -no-snapshot to skip): %w", label, err)
+	}
+	fmt.Printf("Snapshot saved: %s\n", path)
+	return nil
+}
+
+// createSnapshot backs up dbPath into its snapshot directory and prunes old snapshots,
+// except those in protect.
+func createSnapshot(dbPath, label string, keep int, protect ...string) (string, error) {
+	repo, err := database.NewReadOnlyRepository(dbPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to open database: %w", err)
+	}
+	defer repo.Close()
+
+	if err := os.MkdirAll(database.SnapshotDir(dbPath), 0755); err != nil {
+		return "", fmt.Errorf("failed to create snapshot directory: %w", err)
+	}
+	path := database.SnapshotPath(dbPath, label, time.Now())
+	if err := repo.Backup(context.Background(), path); err != nil {
+		return "", err
+	}
+
+	pruned, err := database.PruneSnapshots(dbPath, keep, protect...)
+	if err != nil {
+		return "", err
+	}
+	for _, s := range pruned {
+		fmt.Printf("Deleted old snapshot: %s\n", s.Path)
+	}
+	return path, nil
+}
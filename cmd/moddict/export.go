@@ -12,6 +12,7 @@ import (
 
 	"github.com/iuif/minecraft-mod-dictionary/internal/database"
 	"github.com/iuif/minecraft-mod-dictionary/internal/export"
+	"github.com/iuif/minecraft-mod-dictionary/internal/parser"
 	"github.com/iuif/minecraft-mod-dictionary/pkg/interfaces"
 	"github.com/iuif/minecraft-mod-dictionary/pkg/models"
 )
@@ -20,15 +21,25 @@ func runExport(args []string) error {
 	fs := flag.NewFlagSet("export", flag.ExitOnError)
 
 	var (
-		dbPath     = fs.String("db", "moddict.db", "Database file path")
+		dbPath     = fs.String("db", "moddict.db", "Database file path or postgres:// URL")
 		outputDir  = fs.String("out", "workspace/exports", "Output directory")
 		modID      = fs.String("mod", "", "Mod ID to export")
 		targetLang = fs.String("lang", "ja_jp", "Target language code")
-		format     = fs.String("format", "json", "Output format (json, merged, csv, resourcepack)")
-		original   = fs.String("original", "", "Original lang file for merged export")
+		format     = fs.String("format", "json", "Output format (json, merged, upstream, csv, xliff, po, pot, resourcepack, ftbquests)")
+		original   = fs.String("original", "", "Original lang file (en_us.json) for merged/upstream export")
+		sourceFile = fs.String("source", "", "Source lang file (en_us.json) whose key order is used for keys appended by merged export (default: -original)")
+		existing   = fs.String("existing", "", "Existing upstream lang file to compare against (upstream export)")
+		untrans    = fs.String("untranslated", "omit", "Untranslated keys in upstream export: omit or source")
+		questsDir  = fs.String("quests", "", "FTB Quests directory (config/ftbquests/quests) for ftbquests export")
 		status     = fs.String("status", "", "Filter by status (pending, translated, verified)")
 		all        = fs.Bool("all", false, "Export all mods to a single combined CSV file")
 		perMod     = fs.Bool("per-mod", false, "Export each mod to separate CSV files (use with -all)")
+		mcVersion  = fs.String("mc", "1.20.1", "Target Minecraft version or range for resourcepack (e.g. 1.20.1, 1.20.1-1.21.1)")
+		zipPack    = fs.Bool("zip", false, "Write the resource pack as a .zip file")
+		icon       = fs.String("icon", "", "pack.png for the resource pack (a default icon is generated if empty)")
+		overlays   = fs.String("overlay", "", "Comma-separated MC versions (1.20.2+) to add version-specific overlays for")
+		modpack    = fs.String("pack", "", "Modpack mods folder, instance folder, modrinth.index.json or .mrpack; export only its mods and versions (resourcepack)")
+		desc       = fs.String("description", "Mod翻訳リソースパック - Generated by moddict", "Resource pack description")
 	)
 
 	fs.Usage = func() {
@@ -44,10 +55,17 @@ Options:
 Examples:
   moddict export -mod create -out output/
   moddict export -mod botania -format merged -original en_us.json
+  moddict export -mod botania -format merged -original ja_jp.json -source en_us.json  # Update an existing ja_jp.json
+  moddict export -mod botania -format upstream -original en_us.json -existing ja_jp.json  # For PRs to the mod repo
   moddict export -mod create -status translated
-  moddict export -all -out translations/       # Export all mods to combined CSV
+  moddict export -all -out translations/       # Export all mods to combined CSV (with mod_id)
   moddict export -all -per-mod -out data/translations/  # Export each mod to separate CSV
   moddict export -all -format resourcepack -out my_pack/  # Export as Minecraft resource pack
+  moddict export -all -format resourcepack -mc 1.20.1-1.21.1 -overlay 1.21.1 -zip -out my_pack.zip
+  moddict export -all -format resourcepack -pack instance/mods -zip -out modpack_ja.zip  # Only the mods in a modpack
+  moddict export -mod create -format xliff -out xliff/  # XLIFF 2.0 for OmegaT/Trados
+  moddict export -mod create -format po -out po/      # gettext PO for Weblate (-format pot for a template)
+  moddict export -mod mypack -format ftbquests -quests config/ftbquests/quests -out localized/  # FTB Quests with lang keys
 `)
 	}
 
@@ -58,7 +76,14 @@ Examples:
 	// Handle -all flag
 	if *all {
 		if *format == "resourcepack" {
-			return runExportResourcePack(*dbPath, *outputDir, *targetLang, *status)
+			return runExportResourcePack(*dbPath, *outputDir, *targetLang, *status, resourcePackOptions{
+				mcVersions:  *mcVersion,
+				description: *desc,
+				iconPath:    *icon,
+				overlays:    splitList(*overlays),
+				zip:         *zipPack,
+				pack:        *modpack,
+			})
 		}
 		return runExportAllCSV(*dbPath, *outputDir, *targetLang, *status, *perMod)
 	}
@@ -69,9 +94,9 @@ Examples:
 	}
 
 	// Open database
-	repo, err := database.NewRepository(*dbPath)
+	repo, err := openReadOnlyRepository(*dbPath)
 	if err != nil {
-		return fmt.Errorf("failed to open database: %w", err)
+		return err
 	}
 	defer repo.Close()
 
@@ -83,6 +108,11 @@ Examples:
 		return fmt.Errorf("mod not found: %s", *modID)
 	}
 
+	// A POT template lists the source keys of the default version, translated or not
+	if *format == "pot" {
+		return exportPOT(ctx, repo, mod, *outputDir)
+	}
+
 	// Get translations using new source-based query
 	filter := interfaces.TranslationFilter{
 		TargetLang: *targetLang,
@@ -95,6 +125,20 @@ Examples:
 	if err != nil {
 		return fmt.Errorf("failed to get translations: %w", err)
 	}
+	// Book entries are only exported as book files (-format resourcepack)
+	translations = export.LangTranslations(translations)
+
+	// FTB Quests are rewritten to lang keys even if nothing is translated yet
+	if *format == "ftbquests" {
+		if *questsDir == "" {
+			return fmt.Errorf("-quests flag is required for ftbquests export")
+		}
+		if err := runExportFTBQuests(translations, *modID, *targetLang, *questsDir, *outputDir); err != nil {
+			return fmt.Errorf("failed to export: %w", err)
+		}
+		printExportSummary(mod, translations, *outputDir)
+		return nil
+	}
 
 	if len(translations) == 0 {
 		fmt.Println("No translations found matching criteria")
@@ -126,11 +170,33 @@ Examples:
 			return fmt.Errorf("failed to read original file: %w", err)
 		}
 
+		sourceContent := originalContent
+		if *sourceFile != "" {
+			sourceContent, err = os.ReadFile(*sourceFile)
+			if err != nil {
+				return fmt.Errorf("failed to read source file: %w", err)
+			}
+		}
+		sourceKeys, err := parser.JSONLangKeys(sourceContent)
+		if err != nil {
+			return fmt.Errorf("failed to parse source file: %w", err)
+		}
+
 		fmt.Printf("Exporting merged translations to %s...\n", outputPath)
-		if err := exporter.ExportMerged(originalContent, translations, outputPath); err != nil {
+		if err := exporter.ExportMerged(originalContent, translations, sourceKeys, outputPath); err != nil {
 			return fmt.Errorf("failed to export: %w", err)
 		}
 
+	case "upstream":
+		if *original == "" {
+			return fmt.Errorf("-original flag is required for upstream export")
+		}
+
+		if err := runExportUpstream(translations, *modID, *targetLang, *original, *existing, *untrans, *outputDir); err != nil {
+			return fmt.Errorf("failed to export: %w", err)
+		}
+		outputPath = filepath.Join(*outputDir, *targetLang+".json")
+
 	case "csv":
 		csvPath := filepath.Join(*outputDir, fmt.Sprintf("%s_%s.csv", *modID, *targetLang))
 		fmt.Printf("Exporting %d translations to %s...\n", len(translations), csvPath)
@@ -139,11 +205,87 @@ Examples:
 		}
 		outputPath = csvPath
 
+	case "xliff":
+		terms, err := applicableTerms(ctx, repo, mod, *targetLang)
+		if err != nil {
+			return err
+		}
+
+		xliffPath := filepath.Join(*outputDir, fmt.Sprintf("%s_%s.xlf", *modID, *targetLang))
+		fmt.Printf("Exporting %d translations to %s (%d glossary terms)...\n", len(translations), xliffPath, len(terms))
+		if err := exporter.ExportXLIFF(mod.ID, translations[0].SourceLang, *targetLang, translations, terms, xliffPath); err != nil {
+			return fmt.Errorf("failed to export XLIFF: %w", err)
+		}
+		outputPath = xliffPath
+
+	case "po":
+		opts := export.POOptions{
+			ModID:      mod.ID,
+			SourceLang: translations[0].SourceLang,
+			TargetLang: *targetLang,
+		}
+		poPath := filepath.Join(*outputDir, fmt.Sprintf("%s_%s.po", *modID, *targetLang))
+		fmt.Printf("Exporting %d entries to %s...\n", len(translations), poPath)
+		if err := exporter.ExportPO(translations, opts, poPath); err != nil {
+			return fmt.Errorf("failed to export PO: %w", err)
+		}
+		outputPath = poPath
+
 	default:
 		return fmt.Errorf("unknown format: %s", *format)
 	}
 
-	// Print summary
+	printExportSummary(mod, translations, outputPath)
+	return nil
+}
+
+// exportPOT exports the sources of a mod's default version as a gettext POT template.
+func exportPOT(ctx context.Context, repo *database.Repository, mod *models.Mod, outputDir string) error {
+	version, err := repo.GetDefaultVersion(ctx, mod.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get default version of %s: %w", mod.ID, err)
+	}
+	sources, err := repo.ListSourcesByVersion(ctx, version.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get sources: %w", err)
+	}
+	sources = langSources(sources)
+	if len(sources) == 0 {
+		fmt.Println("No sources found for the default version")
+		return nil
+	}
+
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	potPath := filepath.Join(outputDir, mod.ID+".pot")
+	opts := export.POOptions{ModID: mod.ID, SourceLang: sources[0].SourceLang}
+	fmt.Printf("Exporting %d entries to %s...\n", len(sources), potPath)
+	if err := export.NewExporter().ExportPOT(sources, opts, potPath); err != nil {
+		return fmt.Errorf("failed to export POT: %w", err)
+	}
+
+	fmt.Printf("\nExport complete for %s (%s)\n", mod.DisplayName, mod.ID)
+	fmt.Printf("Version: %s\n", version.Version)
+	fmt.Printf("Total entries: %d\n", len(sources))
+	fmt.Printf("Output: %s\n", potPath)
+	return nil
+}
+
+// langSources returns the sources that are not book entries.
+func langSources(sources []*models.TranslationSource) []*models.TranslationSource {
+	result := make([]*models.TranslationSource, 0, len(sources))
+	for _, s := range sources {
+		if !export.IsBookKey(s.Key) {
+			result = append(result, s)
+		}
+	}
+	return result
+}
+
+// printExportSummary prints the entry counts of a single-mod export.
+func printExportSummary(mod *models.Mod, translations []*models.TranslationWithSource, outputPath string) {
 	var translatedCount int
 	for _, t := range translations {
 		if t.TargetText != nil {
@@ -155,8 +297,6 @@ Examples:
 	fmt.Printf("Total entries: %d\n", len(translations))
 	fmt.Printf("Translated: %d\n", translatedCount)
 	fmt.Printf("Output: %s\n", outputPath)
-
-	return nil
 }
 
 // runExportAllCSV exports all mods' translations to CSV files
@@ -164,9 +304,9 @@ Examples:
 // Otherwise, exports all mods to a single combined CSV file
 func runExportAllCSV(dbPath, outputDir, targetLang, status string, perMod bool) error {
 	// Open database
-	repo, err := database.NewRepository(dbPath)
+	repo, err := openReadOnlyRepository(dbPath)
 	if err != nil {
-		return fmt.Errorf("failed to open database: %w", err)
+		return err
 	}
 	defer repo.Close()
 
@@ -195,43 +335,43 @@ func runExportAllCSV(dbPath, outputDir, targetLang, status string, perMod bool)
 	return runExportCombinedCSV(repo, ctx, mods, outputDir, targetLang, status)
 }
 
-// runExportPerModCSV exports each mod to a separate CSV file
+// runExportPerModCSV exports each mod to a separate CSV file.
+// Translations are streamed from the database, so memory does not grow with the mod size.
 func runExportPerModCSV(repo *database.Repository, ctx context.Context, mods []*models.Mod, outputDir, targetLang, status string) error {
 	totalMods := 0
 	totalRows := 0
 	translatedCount := 0
 
 	for _, mod := range mods {
-		filter := interfaces.TranslationFilter{
-			TargetLang: targetLang,
-		}
-		if status != "" {
-			filter.Status = status
-		}
-
-		translations, err := repo.ListTranslationsWithSourceByMod(ctx, mod.ID, filter)
-		if err != nil {
-			fmt.Printf("Warning: failed to get translations for %s: %v\n", mod.ID, err)
-			continue
-		}
-
-		if len(translations) == 0 {
-			continue
-		}
-
-		// Create CSV file for this mod
+		// The file is created on the first row so mods without translations get none
+		var out *translationCSV
 		csvPath := filepath.Join(outputDir, fmt.Sprintf("%s.csv", mod.ID))
-		if err := exportCSV(translations, csvPath); err != nil {
-			fmt.Printf("Warning: failed to export %s: %v\n", mod.ID, err)
-			continue
-		}
 
-		totalMods++
-		totalRows += len(translations)
-		for _, t := range translations {
+		err := repo.IterateTranslations(ctx, exportIterFilter(mod.ID, targetLang, status), func(t *models.TranslationWithSource) error {
+			if export.IsBookKey(t.Key) {
+				return nil
+			}
+			if out == nil {
+				var err error
+				if out, err = createTranslationCSV(csvPath, false); err != nil {
+					return err
+				}
+			}
 			if t.TargetText != nil {
 				translatedCount++
 			}
+			totalRows++
+			return out.Write(t)
+		})
+		if out != nil {
+			if closeErr := out.Close(); err == nil {
+				err = closeErr
+			}
+			totalMods++
+		}
+		if err != nil {
+			fmt.Printf("Warning: failed to export %s: %v\n", mod.ID, err)
+			continue
 		}
 	}
 
@@ -244,64 +384,38 @@ func runExportPerModCSV(repo *database.Repository, ctx context.Context, mods []*
 	return nil
 }
 
-// runExportCombinedCSV exports all mods to a single combined CSV file
+// runExportCombinedCSV exports all mods to a single combined CSV file.
+// Translations are streamed from the database one page at a time.
 func runExportCombinedCSV(repo *database.Repository, ctx context.Context, mods []*models.Mod, outputDir, targetLang, status string) error {
-	// Create combined CSV file
 	csvPath := filepath.Join(outputDir, "all_translations.csv")
-	file, err := os.Create(csvPath)
+	out, err := createTranslationCSV(csvPath, true)
 	if err != nil {
-		return fmt.Errorf("failed to create file: %w", err)
-	}
-	defer file.Close()
-
-	// Write UTF-8 BOM for Excel compatibility
-	file.Write([]byte{0xEF, 0xBB, 0xBF})
-
-	writer := csv.NewWriter(file)
-	defer writer.Flush()
-
-	// Write header - only key, source_text, target_text
-	header := []string{"key", "source_text", "target_text"}
-	if err := writer.Write(header); err != nil {
-		return fmt.Errorf("failed to write header: %w", err)
+		return err
 	}
+	defer out.Close()
 
 	totalRows := 0
 	translatedCount := 0
 
-	// Process each mod
+	// Process each mod, keeping the rows of a mod together
 	for _, mod := range mods {
-		filter := interfaces.TranslationFilter{
-			TargetLang: targetLang,
-		}
-		if status != "" {
-			filter.Status = status
-		}
-
-		translations, err := repo.ListTranslationsWithSourceByMod(ctx, mod.ID, filter)
-		if err != nil {
-			fmt.Printf("Warning: failed to get translations for %s: %v\n", mod.ID, err)
-			continue
-		}
-
-		// Write rows for this mod
-		for _, t := range translations {
-			targetText := ""
+		err := repo.IterateTranslations(ctx, exportIterFilter(mod.ID, targetLang, status), func(t *models.TranslationWithSource) error {
+			if export.IsBookKey(t.Key) {
+				return nil
+			}
 			if t.TargetText != nil {
-				targetText = *t.TargetText
 				translatedCount++
 			}
-			row := []string{t.Key, t.SourceText, targetText}
-			if err := writer.Write(row); err != nil {
-				return fmt.Errorf("failed to write row: %w", err)
-			}
 			totalRows++
+			return out.Write(t)
+		})
+		if err != nil {
+			return fmt.Errorf("failed to export %s: %w", mod.ID, err)
 		}
 	}
 
-	writer.Flush()
-	if err := writer.Error(); err != nil {
-		return fmt.Errorf("failed to flush writer: %w", err)
+	if err := out.Close(); err != nil {
+		return err
 	}
 
 	fmt.Printf("\nCombined CSV Export Complete\n")
@@ -313,157 +427,129 @@ func runExportCombinedCSV(repo *database.Repository, ctx context.Context, mods [
 	return nil
 }
 
+// exportIterFilter selects the translations of a mod's default version for CSV export.
+func exportIterFilter(modID, targetLang, status string) interfaces.TranslationIterFilter {
+	filter := interfaces.TranslationIterFilter{
+		ModID:      modID,
+		TargetLang: targetLang,
+	}
+	if status != "" {
+		filter.Statuses = []string{status}
+	}
+	return filter
+}
+
 // exportCSV exports translations to a CSV file
 // Format: key,source_text,target_text
 func exportCSV(translations []*models.TranslationWithSource, outputPath string) error {
-	file, err := os.Create(outputPath)
+	out, err := createTranslationCSV(outputPath, false)
 	if err != nil {
-		return fmt.Errorf("failed to create file: %w", err)
-	}
-	defer file.Close()
-
-	// Write UTF-8 BOM for Excel compatibility
-	file.Write([]byte{0xEF, 0xBB, 0xBF})
-
-	writer := csv.NewWriter(file)
-	defer writer.Flush()
-
-	// Write header
-	header := []string{"key", "source_text", "target_text"}
-	if err := writer.Write(header); err != nil {
-		return fmt.Errorf("failed to write header: %w", err)
+		return err
 	}
+	defer out.Close()
 
-	// Write rows
 	for _, t := range translations {
-		targetText := ""
-		if t.TargetText != nil {
-			targetText = *t.TargetText
-		}
-		row := []string{t.Key, t.SourceText, targetText}
-		if err := writer.Write(row); err != nil {
-			return fmt.Errorf("failed to write row: %w", err)
+		if err := out.Write(t); err != nil {
+			return err
 		}
 	}
+	return out.Close()
+}
 
-	return nil
+// translationCSV writes key,source_text,target_text rows to a CSV file with a UTF-8 BOM
+// for Excel compatibility. Combined CSVs start each row with a mod_id column so that
+// "translate -csv" can import them without -mod.
+type translationCSV struct {
+	file    *os.File
+	writer  *csv.Writer
+	withMod bool
+	closed  bool
 }
 
-// runExportResourcePack exports all translations as a Minecraft resource pack
-// Structure:
-//
-//	<outputDir>/
-//	├── pack.mcmeta
-//	└── assets/
-//	    └── <mod_id>/
-//	        └── lang/
-//	            └── <targetLang>.json
-func runExportResourcePack(dbPath, outputDir, targetLang, status string) error {
-	// Open database
-	repo, err := database.NewRepository(dbPath)
+// createTranslationCSV creates the file and writes the header.
+func createTranslationCSV(path string, withMod bool) (*translationCSV, error) {
+	file, err := os.Create(path)
 	if err != nil {
-		return fmt.Errorf("failed to open database: %w", err)
+		return nil, fmt.Errorf("failed to create file: %w", err)
 	}
-	defer repo.Close()
 
-	ctx := context.Background()
-
-	// Get all mods
-	mods, err := repo.ListMods(ctx, interfaces.ModFilter{})
-	if err != nil {
-		return fmt.Errorf("failed to list mods: %w", err)
+	// Write UTF-8 BOM for Excel compatibility
+	if _, err := file.Write([]byte{0xEF, 0xBB, 0xBF}); err != nil {
+		file.Close()
+		return nil, fmt.Errorf("failed to write BOM: %w", err)
 	}
 
-	if len(mods) == 0 {
-		fmt.Println("No mods found in database")
-		return nil
+	out := &translationCSV{file: file, writer: csv.NewWriter(file), withMod: withMod}
+	header := []string{"key", "source_text", "target_text"}
+	if withMod {
+		header = append([]string{"mod_id"}, header...)
 	}
-
-	// Create output directory structure
-	assetsDir := filepath.Join(outputDir, "assets")
-	if err := os.MkdirAll(assetsDir, 0755); err != nil {
-		return fmt.Errorf("failed to create assets directory: %w", err)
+	if err := out.writer.Write(header); err != nil {
+		file.Close()
+		return nil, fmt.Errorf("failed to write header: %w", err)
 	}
+	return out, nil
+}
 
-	// Create pack.mcmeta
-	if err := createPackMcmeta(outputDir); err != nil {
-		return fmt.Errorf("failed to create pack.mcmeta: %w", err)
+// Write writes one translation; untranslated entries have an empty target_text.
+func (c *translationCSV) Write(t *models.TranslationWithSource) error {
+	targetText := ""
+	if t.TargetText != nil {
+		targetText = *t.TargetText
 	}
-
-	totalMods := 0
-	totalEntries := 0
-	translatedCount := 0
-
-	// Process each mod
-	for _, mod := range mods {
-		filter := interfaces.TranslationFilter{
-			TargetLang: targetLang,
-		}
-		if status != "" {
-			filter.Status = status
-		}
-
-		translations, err := repo.ListTranslationsWithSourceByMod(ctx, mod.ID, filter)
-		if err != nil {
-			fmt.Printf("Warning: failed to get translations for %s: %v\n", mod.ID, err)
-			continue
-		}
-
-		// Only include mods with translated entries
-		translatedEntries := make(map[string]string)
-		for _, t := range translations {
-			if t.TargetText != nil && *t.TargetText != "" {
-				translatedEntries[t.Key] = *t.TargetText
-				translatedCount++
-			}
-		}
-
-		if len(translatedEntries) == 0 {
-			continue
-		}
-
-		// Create mod lang directory
-		langDir := filepath.Join(assetsDir, mod.ID, "lang")
-		if err := os.MkdirAll(langDir, 0755); err != nil {
-			fmt.Printf("Warning: failed to create directory for %s: %v\n", mod.ID, err)
-			continue
-		}
-
-		// Write lang file
-		langFile := filepath.Join(langDir, targetLang+".json")
-		if err := writeLangJSON(translatedEntries, langFile); err != nil {
-			fmt.Printf("Warning: failed to write lang file for %s: %v\n", mod.ID, err)
-			continue
-		}
-
-		totalMods++
-		totalEntries += len(translatedEntries)
+	row := []string{t.Key, t.SourceText, targetText}
+	if c.withMod {
+		row = append([]string{t.ModID}, row...)
+	}
+	if err := c.writer.Write(row); err != nil {
+		return fmt.Errorf("failed to write row: %w", err)
 	}
+	return nil
+}
 
-	fmt.Printf("\nResource Pack Export Complete\n")
-	fmt.Printf("Mods exported: %d\n", totalMods)
-	fmt.Printf("Total entries: %d\n", totalEntries)
-	fmt.Printf("Output directory: %s\n", outputDir)
-	fmt.Printf("\nTo use: Copy '%s' to your Minecraft resourcepacks folder\n", outputDir)
+// Close flushes and closes the file. Calling it again is a no-op.
+func (c *translationCSV) Close() error {
+	if c.closed {
+		return nil
+	}
+	c.closed = true
 
-	return nil
+	c.writer.Flush()
+	if err := c.writer.Error(); err != nil {
+		c.file.Close()
+		return fmt.Errorf("failed to flush writer: %w", err)
+	}
+	return c.file.Close()
 }
 
-// createPackMcmeta creates the pack.mcmeta file for the resource pack
-func createPackMcmeta(outputDir string) error {
-	packMcmeta := map[string]interface{}{
-		"pack": map[string]interface{}{
-			"pack_format": 15, // 1.20.x format
-			"description": "Mod翻訳リソースパック - Generated by moddict",
-		},
+// applicableTerms returns the global, category and mod terms for a mod.
+// Mod terms come first, then category and global terms, each by priority.
+func applicableTerms(ctx context.Context, repo *database.Repository, mod *models.Mod, targetLang string) ([]*models.Term, error) {
+	scopes := []string{models.ScopeGlobal, models.BuildScope(models.ScopeMod, mod.ID)}
+	for _, tag := range mod.Tags {
+		scopes = append(scopes, models.BuildScope(models.ScopeCategory, tag))
 	}
 
-	data, err := json.MarshalIndent(packMcmeta, "", "  ")
+	terms, err := repo.ListTerms(ctx, interfaces.TermFilter{Scopes: scopes, TargetLang: targetLang})
 	if err != nil {
-		return err
+		return nil, fmt.Errorf("failed to list terms: %w", err)
+	}
+
+	scopeRank := func(scope string) int {
+		switch scopeType, _ := models.ParseScope(scope); scopeType {
+		case models.ScopeMod:
+			return 0
+		case models.ScopeCategory:
+			return 1
+		default:
+			return 2
+		}
 	}
+	sort.SliceStable(terms, func(i, j int) bool {
+		return scopeRank(terms[i].Scope) < scopeRank(terms[j].Scope)
+	})
 
-	return os.WriteFile(filepath.Join(outputDir, "pack.mcmeta"), data, 0644)
+	return terms, nil
 }
 
 // writeLangJSON writes a language JSON file with sorted keys
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/iuif/minecraft-mod-dictionary/internal/parser"
+	"github.com/iuif/minecraft-mod-dictionary/pkg/models"
+)
+
+// runExportFTBQuests rewrites FTB Quests SNBT files to reference lang keys
+// and writes the matching lang files for KubeJS to load.
+// Structure:
+//
+//	<outputDir>/
+//	├── config/ftbquests/quests/
+//	│   └── ... (same layout as questsDir, strings replaced by {lang.key})
+//	└── kubejs/assets/<modID>/lang/
+//	    ├── en_us.json
+//	    └── <targetLang>.json
+func runExportFTBQuests(translations []*models.TranslationWithSource, modID, targetLang, questsDir, outputDir string) error {
+	info, err := os.Stat(questsDir)
+	if err != nil {
+		return fmt.Errorf("failed to read quests directory: %w", err)
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("quests path is not a directory: %s", questsDir)
+	}
+
+	// Create translation lookup map
+	translationMap := make(map[string]string)
+	for _, t := range translations {
+		if t.TargetText != nil && *t.TargetText != "" {
+			translationMap[t.Key] = *t.TargetText
+		}
+	}
+
+	snbtParser := parser.NewSNBTParser()
+	sourceLang := make(map[string]string)
+	targetLangEntries := make(map[string]string)
+	questsOut := filepath.Join(outputDir, "config", "ftbquests", "quests")
+	var fileCount int
+
+	err = filepath.Walk(questsDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || !strings.HasSuffix(path, ".snbt") {
+			return nil
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", path, err)
+		}
+
+		// Replace every translatable string with a reference to its lang key:
+		// plain strings become {lang.key}, text components {"translate": key}.
+		rewritten, entries, err := snbtParser.ApplyLangKeys(content)
+		if err != nil {
+			return fmt.Errorf("failed to rewrite %s: %w", path, err)
+		}
+		for _, entry := range entries {
+			sourceLang[entry.Key] = entry.Text
+			if translated, ok := translationMap[entry.Key]; ok {
+				targetLangEntries[entry.Key] = translated
+			}
+		}
+
+		relPath, err := filepath.Rel(questsDir, path)
+		if err != nil {
+			return err
+		}
+		destPath := filepath.Join(questsOut, relPath)
+		if err := os.MkdirAll(filepath.Dir(destPath), 0755); err != nil {
+			return fmt.Errorf("failed to create directory: %w", err)
+		}
+		if err := os.WriteFile(destPath, rewritten, 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", destPath, err)
+		}
+
+		fileCount++
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// Write lang files
+	langDir := filepath.Join(outputDir, "kubejs", "assets", modID, "lang")
+	if err := os.MkdirAll(langDir, 0755); err != nil {
+		return fmt.Errorf("failed to create lang directory: %w", err)
+	}
+	if err := writeLangJSON(sourceLang, filepath.Join(langDir, "en_us.json")); err != nil {
+		return fmt.Errorf("failed to write en_us.json: %w", err)
+	}
+	if err := writeLangJSON(targetLangEntries, filepath.Join(langDir, targetLang+".json")); err != nil {
+		return fmt.Errorf("failed to write %s.json: %w", targetLang, err)
+	}
+
+	fmt.Printf("\nFTB Quests Export Complete\n")
+	fmt.Printf("Quest files rewritten: %d\n", fileCount)
+	fmt.Printf("Lang keys: %d\n", len(sourceLang))
+	fmt.Printf("Translated (%s): %d\n", targetLang, len(targetLangEntries))
+	if missing := len(sourceLang) - len(targetLangEntries); missing > 0 {
+		fmt.Printf("Untranslated (fall back to en_us): %d\n", missing)
+	}
+	fmt.Printf("Output directory: %s\n", outputDir)
+	fmt.Printf("\nTo use: Copy 'config' and 'kubejs' from '%s' into your modpack\n", outputDir)
+
+	return nil
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/iuif/minecraft-mod-dictionary/internal/database"
+	"github.com/iuif/minecraft-mod-dictionary/internal/jar"
+	"github.com/iuif/minecraft-mod-dictionary/pkg/interfaces"
+	"github.com/iuif/minecraft-mod-dictionary/pkg/models"
+)
+
+// packTarget is a mod (and optionally a pinned version) to include in a resource pack.
+type packTarget struct {
+	mod     *models.Mod
+	version *models.ModVersion // nil means the mod's default version
+	jarFile string             // JAR file name in the modpack (modpack scope only)
+}
+
+// packCoverage is the per-mod coverage line of a modpack-scoped export.
+type packCoverage struct {
+	target     packTarget
+	translated int
+	total      int
+}
+
+// exportTargets returns every mod in the database at its default version.
+func exportTargets(ctx context.Context, repo *database.Repository) ([]packTarget, error) {
+	mods, err := repo.ListMods(ctx, interfaces.ModFilter{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list mods: %w", err)
+	}
+
+	targets := make([]packTarget, 0, len(mods))
+	for _, mod := range mods {
+		targets = append(targets, packTarget{mod: mod})
+	}
+	return targets, nil
+}
+
+// modpackTargets resolves the mods of a modpack to the exact versions stored in the database.
+// Mods or versions that are not in the database or archived are returned as human-readable notes.
+func modpackTargets(ctx context.Context, repo *database.Repository, pack *jar.Modpack) ([]packTarget, []string, error) {
+	var targets []packTarget
+	var missing []string
+
+	for _, pm := range pack.Mods {
+		mod, err := repo.GetMod(ctx, pm.ModID)
+		if err != nil {
+			missing = append(missing, fmt.Sprintf("%s (%s): mod not in database", pm.ModID, pm.FileName))
+			continue
+		}
+		if mod.IsArchived() {
+			missing = append(missing, fmt.Sprintf("%s (%s): mod archived", pm.ModID, pm.FileName))
+			continue
+		}
+
+		versions, err := repo.ListVersions(ctx, pm.ModID, interfaces.VersionFilter{IncludeArchived: true})
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list versions for %s: %w", pm.ModID, err)
+		}
+
+		version := matchPackVersion(versions, pm)
+		if version == nil {
+			missing = append(missing, fmt.Sprintf("%s %s (%s): version not in database", pm.ModID, pm.Version, pm.FileName))
+			continue
+		}
+		if version.IsArchived() {
+			missing = append(missing, fmt.Sprintf("%s %s (%s): version archived", pm.ModID, pm.Version, pm.FileName))
+			continue
+		}
+
+		targets = append(targets, packTarget{mod: mod, version: version, jarFile: pm.FileName})
+	}
+
+	return targets, missing, nil
+}
+
+// matchPackVersion finds the stored version of an installed mod.
+// An exact version match wins; otherwise the longest stored version string contained
+// in the JAR file name is used (manifest versions are guessed from file names).
+func matchPackVersion(versions []*models.ModVersion, pm jar.PackMod) *models.ModVersion {
+	for _, v := range versions {
+		if pm.Version != "" && v.Version == pm.Version {
+			return v
+		}
+	}
+
+	var best *models.ModVersion
+	for _, v := range versions {
+		if v.Version == "" || !strings.Contains(pm.FileName, v.Version) {
+			continue
+		}
+		if best == nil || len(v.Version) > len(best.Version) {
+			best = v
+		}
+	}
+	return best
+}
+
+// targetTranslations returns the translations of a target: the pinned version's
+// sources (via source_versions) or the default version's.
+func targetTranslations(ctx context.Context, repo *database.Repository, target packTarget, filter interfaces.TranslationFilter) ([]*models.TranslationWithSource, error) {
+	if target.version != nil {
+		return repo.ListTranslationsWithSourceByVersion(ctx, target.version.ID, filter)
+	}
+	return repo.ListTranslationsWithSourceByMod(ctx, target.mod.ID, filter)
+}
+
+// printPackCoverage prints translated/total keys for every mod of the modpack.
+func printPackCoverage(pack *jar.Modpack, coverage []packCoverage, missing []string) {
+	fmt.Printf("\nModpack Coverage (%s)\n", pack.Source)
+	fmt.Printf("%-30s %-20s %12s %8s\n", "Mod", "Version", "Translated", "Rate")
+
+	var translated, total int
+	for _, c := range coverage {
+		rate := 0.0
+		if c.total > 0 {
+			rate = float64(c.translated) * 100 / float64(c.total)
+		}
+		fmt.Printf("%-30s %-20s %12s %7.1f%%\n", c.target.mod.ID, c.target.version.Version,
+			fmt.Sprintf("%d/%d", c.translated, c.total), rate)
+		translated += c.translated
+		total += c.total
+	}
+
+	if total > 0 {
+		fmt.Printf("\nPack total: %d/%d keys (%.1f%%) across %d mods\n",
+			translated, total, float64(translated)*100/float64(total), len(coverage))
+	}
+
+	if len(missing) > 0 {
+		fmt.Printf("\nNot exported (%d of %d mods in pack):\n", len(missing), len(pack.Mods))
+		for _, m := range missing {
+			fmt.Printf("  - %s\n", m)
+		}
+	}
+}
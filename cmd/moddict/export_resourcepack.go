@@ -0,0 +1,383 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/iuif/minecraft-mod-dictionary/internal/database"
+	"github.com/iuif/minecraft-mod-dictionary/internal/export"
+	"github.com/iuif/minecraft-mod-dictionary/internal/jar"
+	"github.com/iuif/minecraft-mod-dictionary/pkg/interfaces"
+	"github.com/iuif/minecraft-mod-dictionary/pkg/models"
+)
+
+// resourcePackOptions controls resource pack layout and packaging.
+type resourcePackOptions struct {
+	mcVersions  string   // target version or range, e.g. "1.20.1" or "1.20.1-1.21.1"
+	description string   // pack.mcmeta description
+	iconPath    string   // custom pack.png (generated if empty)
+	overlays    []string // MC versions that get an overlay with version-specific lang files
+	zip         bool     // write a .zip instead of a directory
+	pack        string   // modpack mods folder or manifest; limits the export to its mods and versions
+}
+
+// runExportResourcePack exports all translations as a Minecraft resource pack
+// Structure:
+//
+//	<outputDir>/ (or <outputDir>.zip with -zip)
+//	├── pack.mcmeta
+//	├── pack.png
+//	├── credits.txt
+//	├── assets/
+//	│   └── <mod_id>/
+//	│       ├── lang/
+//	│       │   └── <targetLang>.json
+//	│       └── patchouli_books/<book>/<targetLang>/... (or Mantle book/<book>/<targetLang>/...)
+//	└── overlay_<mc_version>/   (with -overlay, only for mods whose lang differs)
+//	    └── assets/<mod_id>/lang/<targetLang>.json
+//
+// With opts.pack, only the mods installed in the modpack are exported, each at the
+// exact version found in the pack, and per-mod coverage is reported.
+func runExportResourcePack(dbPath, outputDir, targetLang, status string, opts resourcePackOptions) error {
+	pack, err := export.NewResourcePack(opts.description, opts.mcVersions)
+	if err != nil {
+		return err
+	}
+
+	overlayDirs := make(map[string]string, len(opts.overlays))
+	for _, mcVersion := range opts.overlays {
+		dir, err := pack.AddOverlay(mcVersion)
+		if err != nil {
+			return err
+		}
+		overlayDirs[mcVersion] = dir
+	}
+
+	if opts.iconPath != "" {
+		icon, err := os.ReadFile(opts.iconPath)
+		if err != nil {
+			return fmt.Errorf("failed to read icon: %w", err)
+		}
+		pack.AddFile("pack.png", icon)
+	}
+
+	// Open database
+	repo, err := openReadOnlyRepository(dbPath)
+	if err != nil {
+		return err
+	}
+	defer repo.Close()
+
+	ctx := context.Background()
+
+	var modpack *jar.Modpack
+	var targets []packTarget
+	var missing []string
+	if opts.pack != "" {
+		modpack, err = jar.NewExtractor().LoadModpack(opts.pack)
+		if err != nil {
+			return err
+		}
+		targets, missing, err = modpackTargets(ctx, repo, modpack)
+	} else {
+		targets, err = exportTargets(ctx, repo)
+	}
+	if err != nil {
+		return err
+	}
+
+	if len(targets) == 0 {
+		if modpack != nil {
+			printPackCoverage(modpack, nil, missing)
+			fmt.Println("\nNo mods of the modpack found in database")
+			return nil
+		}
+		fmt.Println("No mods found in database")
+		return nil
+	}
+
+	filter := interfaces.TranslationFilter{
+		TargetLang: targetLang,
+	}
+	if status != "" {
+		filter.Status = status
+	}
+
+	credits := newPackCredits()
+	overlayCount := 0
+	bookFileCount := 0
+	var coverage []packCoverage
+
+	// Process each mod
+	for _, target := range targets {
+		mod := target.mod
+		translations, err := targetTranslations(ctx, repo, target, filter)
+		if err != nil {
+			fmt.Printf("Warning: failed to get translations for %s: %v\n", mod.ID, err)
+			continue
+		}
+
+		translatedEntries := translatedLangEntries(translations)
+		bookEntries := translatedBookEntries(translations)
+
+		// Modpack targets report coverage against the keys of the installed version
+		if target.version != nil {
+			sources, err := repo.ListSourcesByVersion(ctx, target.version.ID)
+			if err != nil {
+				fmt.Printf("Warning: failed to count keys for %s: %v\n", mod.ID, err)
+			}
+			coverage = append(coverage, packCoverage{
+				target:     target,
+				translated: len(translatedEntries) + len(bookEntries),
+				total:      len(sources),
+			})
+		}
+
+		// Only include mods with translated entries
+		if len(translatedEntries) == 0 && len(bookEntries) == 0 {
+			continue
+		}
+
+		if len(translatedEntries) > 0 {
+			if err := pack.AddLang("", mod.ID, targetLang, translatedEntries); err != nil {
+				fmt.Printf("Warning: failed to write lang file for %s: %v\n", mod.ID, err)
+				continue
+			}
+		}
+		if len(bookEntries) > 0 {
+			count, err := addBookFiles(ctx, repo, pack, target, bookEntries, targetLang)
+			if err != nil {
+				fmt.Printf("Warning: failed to write book files for %s: %v\n", mod.ID, err)
+			}
+			bookFileCount += count
+		}
+		credits.add(mod, translations)
+
+		// Overlays carry the lang file of the mod version built for that MC version
+		for _, mcVersion := range opts.overlays {
+			entries, err := overlayLangEntries(ctx, repo, mod.ID, mcVersion, filter)
+			if err != nil {
+				fmt.Printf("Warning: failed to get %s translations for %s: %v\n", mcVersion, mod.ID, err)
+				continue
+			}
+			if len(entries) == 0 || sameLangEntries(entries, translatedEntries) {
+				continue
+			}
+			if err := pack.AddLang(overlayDirs[mcVersion], mod.ID, targetLang, entries); err != nil {
+				fmt.Printf("Warning: failed to write %s overlay for %s: %v\n", mcVersion, mod.ID, err)
+				continue
+			}
+			overlayCount++
+		}
+	}
+
+	pack.AddFile("credits.txt", []byte(credits.render(opts, pack.PackFormat)))
+
+	outputPath := outputDir
+	if opts.zip {
+		outputPath = strings.TrimSuffix(strings.TrimSuffix(outputDir, "/"), ".zip") + ".zip"
+		if err := pack.WriteZip(outputPath); err != nil {
+			return err
+		}
+	} else if err := pack.WriteDir(outputDir); err != nil {
+		return err
+	}
+
+	fmt.Printf("\nResource Pack Export Complete\n")
+	fmt.Printf("Target: Minecraft %s (pack_format %d)\n", opts.mcVersions, pack.PackFormat)
+	fmt.Printf("Mods exported: %d\n", len(credits.mods))
+	fmt.Printf("Total entries: %d\n", credits.total)
+	if bookFileCount > 0 {
+		fmt.Printf("Book files: %d\n", bookFileCount)
+	}
+	if len(opts.overlays) > 0 {
+		fmt.Printf("Overlay lang files: %d (%s)\n", overlayCount, strings.Join(opts.overlays, ", "))
+	}
+	fmt.Printf("Output: %s\n", outputPath)
+	if modpack != nil {
+		printPackCoverage(modpack, coverage, missing)
+	}
+	fmt.Printf("\nTo use: Copy '%s' to your Minecraft resourcepacks folder\n", outputPath)
+
+	return nil
+}
+
+// translatedLangEntries returns key -> target text for translated lang entries.
+// Book entries are excluded; see translatedBookEntries.
+func translatedLangEntries(translations []*models.TranslationWithSource) map[string]string {
+	entries := make(map[string]string)
+	for _, t := range translations {
+		if t.TargetText != nil && *t.TargetText != "" && !export.IsBookKey(t.Key) {
+			entries[t.Key] = *t.TargetText
+		}
+	}
+	return entries
+}
+
+// translatedBookEntries returns book key -> target text for translated book entries.
+func translatedBookEntries(translations []*models.TranslationWithSource) map[string]string {
+	entries := make(map[string]string)
+	for _, t := range translations {
+		if t.TargetText != nil && *t.TargetText != "" && export.IsBookKey(t.Key) {
+			entries[t.Key] = *t.TargetText
+		}
+	}
+	return entries
+}
+
+// addBookFiles rebuilds the translated book files of a mod from the original files stored
+// with its version at import time and adds them to the pack. Returns the number of files added.
+func addBookFiles(ctx context.Context, repo *database.Repository, pack *export.ResourcePack, target packTarget, entries map[string]string, targetLang string) (int, error) {
+	version := target.version
+	if version == nil {
+		defaultVersion, err := repo.GetDefaultVersion(ctx, target.mod.ID)
+		if err != nil {
+			return 0, err
+		}
+		version = defaultVersion
+	}
+
+	originals := make(map[string][]byte)
+	for key := range entries {
+		path, _, ok := export.SplitBookKey(key)
+		if !ok || originals[path] != nil {
+			continue
+		}
+		content, ok := version.Metadata[export.BookFileMetadataKey(path)]
+		if !ok {
+			return 0, fmt.Errorf("original of %s not stored with version %s (re-import with 'moddict import -jar')", path, version.Version)
+		}
+		originals[path] = []byte(content)
+	}
+
+	files, err := export.LocalizeBooks(originals, entries, targetLang)
+	if err != nil {
+		return 0, err
+	}
+	for path, content := range files {
+		pack.AddFile(path, content)
+	}
+	return len(files), nil
+}
+
+// overlayLangEntries returns the translated entries of the mod version built for mcVersion.
+// The default version wins if several versions target the same MC version.
+func overlayLangEntries(ctx context.Context, repo *database.Repository, modID, mcVersion string, filter interfaces.TranslationFilter) (map[string]string, error) {
+	versions, err := repo.ListVersions(ctx, modID, interfaces.VersionFilter{MCVersion: mcVersion})
+	if err != nil || len(versions) == 0 {
+		return nil, err
+	}
+
+	version := versions[len(versions)-1]
+	for _, v := range versions {
+		if v.IsDefault {
+			version = v
+		}
+	}
+
+	translations, err := repo.ListTranslationsWithSourceByVersion(ctx, version.ID, filter)
+	if err != nil {
+		return nil, err
+	}
+	return translatedLangEntries(translations), nil
+}
+
+func sameLangEntries(a, b map[string]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for key, value := range a {
+		if other, ok := b[key]; !ok || other != value {
+			return false
+		}
+	}
+	return true
+}
+
+// packCredits collects attribution for credits.txt.
+type packCredits struct {
+	mods        []string
+	entries     map[string]int // mod ID -> translated entries
+	translators map[string]int // translator -> translated entries
+	total       int
+}
+
+func newPackCredits() *packCredits {
+	return &packCredits{
+		entries:     make(map[string]int),
+		translators: make(map[string]int),
+	}
+}
+
+func (c *packCredits) add(mod *models.Mod, translations []*models.TranslationWithSource) {
+	name := mod.ID
+	if mod.DisplayName != "" && mod.DisplayName != mod.ID {
+		name = fmt.Sprintf("%s (%s)", mod.DisplayName, mod.ID)
+	}
+	c.mods = append(c.mods, name)
+
+	for _, t := range translations {
+		if t.TargetText == nil || *t.TargetText == "" {
+			continue
+		}
+		translator := "unknown"
+		if t.Translator != nil && *t.Translator != "" {
+			translator = *t.Translator
+		}
+		c.translators[translator]++
+		c.entries[name]++
+		c.total++
+	}
+}
+
+func (c *packCredits) render(opts resourcePackOptions, packFormat int) string {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "%s\n", opts.description)
+	fmt.Fprintf(&sb, "Generated by moddict %s on %s\n", version, time.Now().Format("2006-01-02"))
+	fmt.Fprintf(&sb, "Target: Minecraft %s (pack_format %d)\n", opts.mcVersions, packFormat)
+	if len(opts.overlays) > 0 {
+		fmt.Fprintf(&sb, "Overlays: %s\n", strings.Join(opts.overlays, ", "))
+	}
+
+	fmt.Fprintf(&sb, "\nMods (%d):\n", len(c.mods))
+	for _, name := range c.mods {
+		fmt.Fprintf(&sb, "  - %s: %d entries\n", name, c.entries[name])
+	}
+
+	translators := make([]string, 0, len(c.translators))
+	for name := range c.translators {
+		translators = append(translators, name)
+	}
+	sort.Slice(translators, func(i, j int) bool {
+		if c.translators[translators[i]] != c.translators[translators[j]] {
+			return c.translators[translators[i]] > c.translators[translators[j]]
+		}
+		return translators[i] < translators[j]
+	})
+
+	fmt.Fprintf(&sb, "\nTranslators:\n")
+	for _, name := range translators {
+		fmt.Fprintf(&sb, "  - %s: %d entries\n", name, c.translators[name])
+	}
+
+	sb.WriteString("\nOriginal texts belong to their respective mod authors.\n")
+	sb.WriteString("Translations are provided for use with the mods listed above.\n")
+
+	return sb.String()
+}
+
+// splitList splits a comma-separated flag value, dropping empty items.
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
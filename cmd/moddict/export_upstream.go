@@ -0,0 +1,179 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/iuif/minecraft-mod-dictionary/internal/parser"
+	"github.com/iuif/minecraft-mod-dictionary/pkg/models"
+)
+
+// Untranslated key policies for upstream export.
+const (
+	untranslatedOmit   = "omit"   // leave untranslated keys out (the game falls back to en_us)
+	untranslatedSource = "source" // include untranslated keys with the English text
+)
+
+// upstreamDiff summarizes changes against an existing upstream lang file.
+type upstreamDiff struct {
+	added     []string
+	changed   []string
+	removed   []string
+	unchanged int
+}
+
+// runExportUpstream writes <targetLang>.json following the key order, grouping and
+// indentation of the mod's en_us.json, ready to be committed to the mod repository.
+// If existingPath is set, a summary of added/changed/removed keys against it is written
+// to <targetLang>_summary.md for use in the PR body.
+func runExportUpstream(translations []*models.TranslationWithSource, modID, targetLang, originalPath, existingPath, policy, outputDir string) error {
+	if policy != untranslatedOmit && policy != untranslatedSource {
+		return fmt.Errorf("invalid -untranslated policy: %s (use %s or %s)", policy, untranslatedOmit, untranslatedSource)
+	}
+
+	source, err := os.ReadFile(originalPath)
+	if err != nil {
+		return fmt.Errorf("failed to read original file: %w", err)
+	}
+
+	sourceEntries, err := parser.NewJSONLangParser().Parse(source)
+	if err != nil {
+		return fmt.Errorf("failed to parse original file: %w", err)
+	}
+
+	// Create translation lookup map
+	translationMap := make(map[string]string)
+	for _, t := range translations {
+		if t.TargetText != nil && *t.TargetText != "" {
+			translationMap[t.Key] = *t.TargetText
+		}
+	}
+
+	values := make(map[string]string, len(sourceEntries))
+	var translatedCount int
+	for _, entry := range sourceEntries {
+		if translated, ok := translationMap[entry.Key]; ok {
+			values[entry.Key] = translated
+			translatedCount++
+		} else if policy == untranslatedSource {
+			values[entry.Key] = entry.Text
+		}
+	}
+
+	content, err := parser.MirrorJSONLang(source, values)
+	if err != nil {
+		return fmt.Errorf("failed to build %s.json: %w", targetLang, err)
+	}
+
+	outputPath := filepath.Join(outputDir, targetLang+".json")
+	if err := os.WriteFile(outputPath, content, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", outputPath, err)
+	}
+
+	fmt.Printf("\nUpstream Export Complete\n")
+	fmt.Printf("Source keys (en_us): %d\n", len(sourceEntries))
+	fmt.Printf("Translated: %d\n", translatedCount)
+	if missing := len(sourceEntries) - translatedCount; missing > 0 {
+		fmt.Printf("Untranslated (%s): %d\n", policy, missing)
+	}
+	fmt.Printf("Output: %s\n", outputPath)
+
+	if existingPath == "" {
+		return nil
+	}
+
+	existingContent, err := os.ReadFile(existingPath)
+	if err != nil {
+		return fmt.Errorf("failed to read existing file: %w", err)
+	}
+	existingEntries, err := parser.NewJSONLangParser().Parse(existingContent)
+	if err != nil {
+		return fmt.Errorf("failed to parse existing file: %w", err)
+	}
+
+	existing := make(map[string]string, len(existingEntries))
+	for _, entry := range existingEntries {
+		existing[entry.Key] = entry.Text
+	}
+
+	keyOrder, err := parser.JSONLangKeys(source)
+	if err != nil {
+		return fmt.Errorf("failed to parse original file: %w", err)
+	}
+	diff := diffUpstream(keyOrder, values, existing)
+
+	summary := formatUpstreamSummary(modID, targetLang, diff, translatedCount, len(sourceEntries))
+	summaryPath := filepath.Join(outputDir, targetLang+"_summary.md")
+	if err := os.WriteFile(summaryPath, []byte(summary), 0644); err != nil {
+		return fmt.Errorf("failed to write summary: %w", err)
+	}
+
+	fmt.Printf("\n%s", summary)
+	fmt.Printf("\nSummary written to: %s\n", summaryPath)
+
+	return nil
+}
+
+// diffUpstream compares the exported values with an existing lang file.
+// Added and changed keys follow the source key order; removed keys are sorted.
+func diffUpstream(keyOrder []string, values, existing map[string]string) upstreamDiff {
+	var diff upstreamDiff
+
+	for _, key := range keyOrder {
+		value, ok := values[key]
+		if !ok {
+			continue
+		}
+		old, exists := existing[key]
+		switch {
+		case !exists:
+			diff.added = append(diff.added, key)
+		case old != value:
+			diff.changed = append(diff.changed, key)
+		default:
+			diff.unchanged++
+		}
+	}
+
+	for key := range existing {
+		if _, ok := values[key]; !ok {
+			diff.removed = append(diff.removed, key)
+		}
+	}
+	sort.Strings(diff.removed)
+
+	return diff
+}
+
+// formatUpstreamSummary renders the diff as Markdown for a pull request body.
+func formatUpstreamSummary(modID, targetLang string, diff upstreamDiff, translated, total int) string {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "## %s translation update (%s)\n\n", targetLang, modID)
+	if total > 0 {
+		fmt.Fprintf(&sb, "- Coverage: %d / %d keys (%.1f%%)\n", translated, total, float64(translated)*100/float64(total))
+	}
+	fmt.Fprintf(&sb, "- Added: %d\n", len(diff.added))
+	fmt.Fprintf(&sb, "- Changed: %d\n", len(diff.changed))
+	fmt.Fprintf(&sb, "- Removed: %d\n", len(diff.removed))
+	fmt.Fprintf(&sb, "- Unchanged: %d\n", diff.unchanged)
+
+	writeKeyList := func(title string, keys []string) {
+		if len(keys) == 0 {
+			return
+		}
+		fmt.Fprintf(&sb, "\n<details>\n<summary>%s (%d)</summary>\n\n", title, len(keys))
+		for _, key := range keys {
+			fmt.Fprintf(&sb, "- `%s`\n", key)
+		}
+		sb.WriteString("\n</details>\n")
+	}
+	writeKeyList("Added keys", diff.added)
+	writeKeyList("Changed keys", diff.changed)
+	writeKeyList("Removed keys", diff.removed)
+
+	return sb.String()
+}
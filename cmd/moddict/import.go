@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"errors"
 	"flag"
 	"fmt"
 	"os"
@@ -9,6 +10,7 @@ import (
 	"strings"
 
 	"github.com/iuif/minecraft-mod-dictionary/internal/database"
+	"github.com/iuif/minecraft-mod-dictionary/internal/export"
 	"github.com/iuif/minecraft-mod-dictionary/internal/jar"
 	"github.com/iuif/minecraft-mod-dictionary/internal/parser"
 	"github.com/iuif/minecraft-mod-dictionary/pkg/interfaces"
@@ -19,10 +21,11 @@ func runImport(args []string) error {
 	fs := flag.NewFlagSet("import", flag.ExitOnError)
 
 	var (
-		dbPath   = fs.String("db", "moddict.db", "Database file path")
+		dbPath   = fs.String("db", "moddict.db", "Database file path or postgres:// URL")
 		workDir  = fs.String("work", "workspace/temp", "Working directory for extraction")
 		jarPath  = fs.String("jar", "", "Path to mod JAR file (required)")
 		langCode = fs.String("lang", "en_us", "Source language code")
+		dryRun   = fs.Bool("dry-run", false, "Show what would be imported without changing the database")
 	)
 
 	fs.Usage = func() {
@@ -31,6 +34,7 @@ func runImport(args []string) error {
 Import translations from a Minecraft mod JAR file.
 Reuses existing sources if mod_id + key + source_text matches.
 Sets the imported version as the default version.
+The import runs in a single transaction: on any error nothing is saved.
 
 Options:
 `)
@@ -39,6 +43,7 @@ Options:
 Examples:
   moddict import -jar create-1.20.1-0.5.1.jar
   moddict import -jar mods/botania.jar -db translations.db
+  moddict import -jar create-1.20.1-6.0.0.jar -dry-run
 `)
 	}
 
@@ -88,7 +93,40 @@ Examples:
 	fmt.Printf("Found %d lang files\n", len(result.LangFiles))
 
 	ctx := context.Background()
+	return runInTx(ctx, repo, *dryRun, func(txRepo *database.Repository) error {
+		return importExtractedJAR(ctx, txRepo, result, extractDir, *langCode)
+	})
+}
+
+// saveImportedMod saves the mod of an import. Importing an archived mod puts it back in use:
+// it is restored with UnarchiveMod, which also restores the versions archived with it.
+func saveImportedMod(ctx context.Context, repo *database.Repository, mod *models.Mod) error {
+	existing, err := repo.GetMod(ctx, mod.ID)
+	if err != nil && !errors.Is(err, database.ErrModNotFound) {
+		return fmt.Errorf("failed to get mod: %w", err)
+	}
+	archived := err == nil && existing.IsArchived()
+	if archived {
+		// Keep the archive time so that UnarchiveMod finds the versions archived with the mod
+		mod.ArchivedAt = existing.ArchivedAt
+	}
+
+	if err := repo.SaveMod(ctx, mod); err != nil {
+		return fmt.Errorf("failed to save mod: %w", err)
+	}
 
+	if archived {
+		if err := repo.UnarchiveMod(ctx, mod.ID); err != nil {
+			return fmt.Errorf("failed to restore archived mod: %w", err)
+		}
+		mod.ArchivedAt = nil
+		fmt.Printf("Restored archived mod: %s\n", mod.ID)
+	}
+	return nil
+}
+
+// importExtractedJAR saves the mod, version, sources and translations of an extracted JAR.
+func importExtractedJAR(ctx context.Context, repo *database.Repository, result *jar.ExtractResult, extractDir, langCode string) error {
 	// Save mod info
 	mod := &models.Mod{
 		ID:          result.ModID,
@@ -97,8 +135,8 @@ Examples:
 		Description: result.Description,
 	}
 
-	if err := repo.SaveMod(ctx, mod); err != nil {
-		return fmt.Errorf("failed to save mod: %w", err)
+	if err := saveImportedMod(ctx, repo, mod); err != nil {
+		return err
 	}
 
 	// Get or create version (reuses existing if mod_id + version matches)
@@ -113,6 +151,15 @@ Examples:
 		fmt.Printf("Reusing existing version: %s (ID=%d)\n", result.Version, modVersion.ID)
 	}
 
+	// Re-importing an archived version puts it back in use
+	if modVersion.IsArchived() {
+		if err := repo.UnarchiveVersion(ctx, modVersion.ID); err != nil {
+			return fmt.Errorf("failed to restore archived version: %w", err)
+		}
+		modVersion.ArchivedAt = nil
+		fmt.Printf("Restored archived version: %s\n", result.Version)
+	}
+
 	// Set this version as default
 	if err := repo.SetDefaultVersion(ctx, modVersion.ID); err != nil {
 		return fmt.Errorf("failed to set default version: %w", err)
@@ -127,14 +174,13 @@ Examples:
 	// First pass: Import source language (en_us) and create sources
 	sourceEntries := make(map[string]interfaces.ParsedEntry) // key -> entry
 	for _, langFile := range result.LangFiles {
-		if !isSourceLangFile(langFile, *langCode) {
+		if !isSourceLangFile(langFile, langCode) {
 			continue
 		}
 
 		content, err := os.ReadFile(langFile)
 		if err != nil {
-			fmt.Printf("Warning: failed to read %s: %v\n", langFile, err)
-			continue
+			return fmt.Errorf("failed to read %s: %w", langFile, err)
 		}
 
 		var langParser interfaces.Parser
@@ -146,8 +192,7 @@ Examples:
 
 		entries, err := langParser.Parse(content)
 		if err != nil {
-			fmt.Printf("Warning: failed to parse %s: %v\n", langFile, err)
-			continue
+			return fmt.Errorf("failed to parse %s: %w", langFile, err)
 		}
 
 		for _, entry := range entries {
@@ -167,8 +212,7 @@ Examples:
 
 		content, err := os.ReadFile(langFile)
 		if err != nil {
-			fmt.Printf("Warning: failed to read %s: %v\n", langFile, err)
-			continue
+			return fmt.Errorf("failed to read %s: %w", langFile, err)
 		}
 
 		var langParser interfaces.Parser
@@ -180,8 +224,7 @@ Examples:
 
 		entries, err := langParser.Parse(content)
 		if err != nil {
-			fmt.Printf("Warning: failed to parse %s: %v\n", langFile, err)
-			continue
+			return fmt.Errorf("failed to parse %s: %w", langFile, err)
 		}
 
 		for _, entry := range entries {
@@ -191,10 +234,38 @@ Examples:
 		fmt.Printf("Found %d official Japanese translations from %s\n", len(entries), filepath.Base(langFile))
 	}
 
+	// Book pass: Patchouli and Mantle book entries are stored with book keys, and the
+	// original files with the version, so that the resource pack export can rebuild them
+	bookEntries, bookTranslations, bookOriginals, err := collectBookEntries(extractDir, langCode, "ja_jp")
+	if err != nil {
+		return fmt.Errorf("failed to scan book files: %w", err)
+	}
+	for key := range modVersion.Metadata {
+		if export.IsBookFileMetadataKey(key) {
+			delete(modVersion.Metadata, key)
+		}
+	}
+	if len(bookEntries) > 0 {
+		for key, entry := range bookEntries {
+			sourceEntries[key] = entry
+		}
+		for key, text := range bookTranslations {
+			jaTranslations[key] = text
+		}
+		if modVersion.Metadata == nil {
+			modVersion.Metadata = make(map[string]string)
+		}
+		for path, content := range bookOriginals {
+			modVersion.Metadata[export.BookFileMetadataKey(path)] = string(content)
+		}
+		totalKeys += len(bookEntries)
+		fmt.Printf("Processed %d book keys from %d book files\n", len(bookEntries), len(bookOriginals))
+	}
+
 	// Third pass: Create sources and translations
 	for key, entry := range sourceEntries {
 		// Get or create source (reuses if mod_id + key + source_text matches)
-		source, created, err := repo.GetOrCreateSource(ctx, result.ModID, entry.Key, entry.Text, *langCode)
+		source, created, err := repo.GetOrCreateSource(ctx, result.ModID, entry.Key, entry.Text, langCode)
 		if err != nil {
 			return fmt.Errorf("failed to get/create source: %w", err)
 		}
@@ -202,9 +273,11 @@ Examples:
 		if created {
 			newSources++
 			// Try to copy translation from existing source with same key (preserves old translations)
-			if copied, err := repo.CopyTranslationFromSameKey(ctx, result.ModID, entry.Key, source.ID); err != nil {
-				fmt.Printf("Warning: failed to copy translation for %s: %v\n", key, err)
-			} else if copied {
+			copied, err := repo.CopyTranslationFromSameKey(ctx, result.ModID, entry.Key, source.ID)
+			if err != nil {
+				return fmt.Errorf("failed to copy translation for %s: %w", key, err)
+			}
+			if copied {
 				copiedTranslations++
 			}
 		} else {
@@ -279,6 +352,86 @@ Examples:
 	return nil
 }
 
+// runInTx runs an import in one transaction. With dryRun the import runs normally, so its
+// summary shows the would-be changes, and the transaction is rolled back afterwards.
+func runInTx(ctx context.Context, repo *database.Repository, dryRun bool, fn func(txRepo *database.Repository) error) error {
+	return repo.WithTx(ctx, func(txRepo *database.Repository) error {
+		if err := fn(txRepo); err != nil {
+			return err
+		}
+		if dryRun {
+			fmt.Println("\n[DRY RUN] Rolled back, no changes were written to the database")
+			return database.ErrRollback
+		}
+		return nil
+	})
+}
+
+// collectBookEntries parses the Patchouli and Mantle book files extracted from a JAR.
+// Source language entries are keyed by export.BookKey; entries from targetLang files are
+// returned under the key of the matching source language file. The contents of the
+// source language files are returned by path.
+func collectBookEntries(extractDir, sourceLang, targetLang string) (map[string]interfaces.ParsedEntry, map[string]string, map[string][]byte, error) {
+	registry := parser.NewDefaultRegistry()
+	entries := make(map[string]interfaces.ParsedEntry)
+	translations := make(map[string]string)
+	originals := make(map[string][]byte)
+
+	err := filepath.Walk(extractDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+
+		relPath, err := filepath.Rel(extractDir, path)
+		if err != nil {
+			return nil
+		}
+		relPath = filepath.ToSlash(relPath)
+
+		parserName, lang, ok := export.BookFileLang(relPath)
+		if !ok || (!strings.EqualFold(lang, sourceLang) && !strings.EqualFold(lang, targetLang)) {
+			return nil
+		}
+		bookParser, ok := registry.Get(parserName)
+		if !ok {
+			return nil
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", relPath, err)
+		}
+		parsed, err := bookParser.Parse(content)
+		if err != nil {
+			return fmt.Errorf("failed to parse %s: %w", relPath, err)
+		}
+
+		sourcePath := export.LocalizeBookPath(relPath, sourceLang)
+		if strings.EqualFold(lang, sourceLang) {
+			originals[sourcePath] = content
+		}
+		for _, entry := range parsed {
+			key := export.BookKey(sourcePath, entry.Key)
+			if strings.EqualFold(lang, sourceLang) {
+				entry.Key = key
+				entry.FilePath = relPath
+				entries[key] = entry
+			} else {
+				translations[key] = entry.Text
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	return entries, translations, originals, nil
+}
+
 func joinAuthors(authors []string) string {
 	if len(authors) == 0 {
 		return ""
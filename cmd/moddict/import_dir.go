@@ -10,6 +10,8 @@ import (
 	"strings"
 
 	"github.com/iuif/minecraft-mod-dictionary/internal/database"
+	"github.com/iuif/minecraft-mod-dictionary/internal/parser"
+	"github.com/iuif/minecraft-mod-dictionary/pkg/interfaces"
 	"github.com/iuif/minecraft-mod-dictionary/pkg/models"
 )
 
@@ -17,18 +19,22 @@ func runImportDir(args []string) error {
 	fs := flag.NewFlagSet("import-dir", flag.ExitOnError)
 
 	var (
-		dbPath    = fs.String("db", "moddict.db", "Database file path")
+		dbPath    = fs.String("db", "moddict.db", "Database file path or postgres:// URL")
 		modID     = fs.String("mod", "", "Mod ID (required)")
 		version   = fs.String("version", "", "Mod version (required)")
 		mcVersion = fs.String("mc", "", "Minecraft version (required)")
 		langDir   = fs.String("lang", "", "Path to lang directory containing en_us.json")
 		patchDir  = fs.String("patchouli", "", "Path to patchouli entries directory")
+		ftbDir    = fs.String("ftbquests", "", "Path to FTB Quests directory (config/ftbquests/quests)")
+		kubejsDir = fs.String("kubejs", "", "Path to KubeJS directory (imports assets/*/lang, reports script strings)")
+		dryRun    = fs.Bool("dry-run", false, "Show what would be imported without changing the database")
 	)
 
 	fs.Usage = func() {
 		fmt.Print(`Usage: moddict import-dir [options]
 
 Import translations from a directory (cloned repository).
+The import runs in a single transaction: on any error nothing is saved.
 
 Options:
 `)
@@ -38,6 +44,13 @@ Examples:
   moddict import-dir -mod bloodmagic -version 3.0.0 -mc 1.16.3 \
     -lang ./repo/src/main/resources/assets/bloodmagic/lang \
     -patchouli ./repo/src/main/resources/data/bloodmagic/patchouli_books/guide/en_us/entries
+
+  # Import a modpack's FTB Quests as a pseudo-mod
+  moddict import-dir -mod mypack -version 1.0.0 -mc 1.20.1 \
+    -ftbquests ./mypack/config/ftbquests/quests
+
+  # Import a modpack's KubeJS lang files and list hard-coded script strings
+  moddict import-dir -mod mypack -version 1.0.0 -mc 1.20.1 -kubejs ./mypack/kubejs
 `)
 	}
 
@@ -50,8 +63,8 @@ Examples:
 		return fmt.Errorf("mod, version, and mc are required")
 	}
 
-	if *langDir == "" && *patchDir == "" {
-		return fmt.Errorf("at least one of -lang or -patchouli is required")
+	if *langDir == "" && *patchDir == "" && *ftbDir == "" && *kubejsDir == "" {
+		return fmt.Errorf("at least one of -lang, -patchouli, -ftbquests or -kubejs is required")
 	}
 
 	// Open database
@@ -67,57 +80,87 @@ Examples:
 	}
 
 	ctx := context.Background()
+	return runInTx(ctx, repo, *dryRun, func(txRepo *database.Repository) error {
+		// Save or get mod
+		mod := &models.Mod{
+			ID:          *modID,
+			DisplayName: *modID,
+		}
+		if err := saveImportedMod(ctx, txRepo, mod); err != nil {
+			return err
+		}
 
-	// Save or get mod
-	mod := &models.Mod{
-		ID:          *modID,
-		DisplayName: *modID,
-	}
-	if err := repo.SaveMod(ctx, mod); err != nil {
-		return fmt.Errorf("failed to save mod: %w", err)
-	}
+		// Create version
+		modVersion := &models.ModVersion{
+			ModID:     *modID,
+			Version:   *version,
+			MCVersion: *mcVersion,
+		}
+		if err := txRepo.SaveVersion(ctx, modVersion); err != nil {
+			return fmt.Errorf("failed to save version: %w", err)
+		}
 
-	// Create version
-	modVersion := &models.ModVersion{
-		ModID:     *modID,
-		Version:   *version,
-		MCVersion: *mcVersion,
-	}
-	if err := repo.SaveVersion(ctx, modVersion); err != nil {
-		return fmt.Errorf("failed to save version: %w", err)
-	}
+		var totalKeys int
 
-	var totalKeys int
+		// Import lang file
+		if *langDir != "" {
+			langFile := filepath.Join(*langDir, "en_us.json")
+			count, err := importLangFile(ctx, txRepo, *modID, modVersion.ID, langFile)
+			if err != nil {
+				return fmt.Errorf("failed to import lang file: %w", err)
+			}
+			totalKeys += count
+			fmt.Printf("Imported %d keys from lang file\n", count)
+		}
 
-	// Import lang file
-	if *langDir != "" {
-		langFile := filepath.Join(*langDir, "en_us.json")
-		count, err := importLangFile(ctx, repo, *modID, modVersion.ID, langFile)
-		if err != nil {
-			return fmt.Errorf("failed to import lang file: %w", err)
+		// Import patchouli entries
+		if *patchDir != "" {
+			count, err := importPatchouliEntries(ctx, txRepo, *modID, modVersion.ID, *patchDir)
+			if err != nil {
+				return fmt.Errorf("failed to import patchouli entries: %w", err)
+			}
+			totalKeys += count
+			fmt.Printf("Imported %d keys from patchouli entries\n", count)
 		}
-		totalKeys += count
-		fmt.Printf("Imported %d keys from lang file\n", count)
-	}
 
-	// Import patchouli entries
-	if *patchDir != "" {
-		count, err := importPatchouliEntries(ctx, repo, *modID, modVersion.ID, *patchDir)
-		if err != nil {
-			return fmt.Errorf("failed to import patchouli entries: %w", err)
+		// Import FTB Quests
+		if *ftbDir != "" {
+			count, err := importFTBQuests(ctx, txRepo, *modID, modVersion.ID, *ftbDir)
+			if err != nil {
+				return fmt.Errorf("failed to import FTB Quests: %w", err)
+			}
+			totalKeys += count
+			fmt.Printf("Imported %d keys from FTB Quests\n", count)
 		}
-		totalKeys += count
-		fmt.Printf("Imported %d keys from patchouli entries\n", count)
-	}
 
-	// Update version stats
-	modVersion.Stats.TotalKeys = totalKeys
-	if err := repo.SaveVersion(ctx, modVersion); err != nil {
-		return fmt.Errorf("failed to update version stats: %w", err)
-	}
+		// Import KubeJS lang files and collect script candidates
+		var scriptCandidates []interfaces.ParsedEntry
+		if *kubejsDir != "" {
+			count, err := importKubeJSLang(ctx, txRepo, *modID, modVersion.ID, *kubejsDir)
+			if err != nil {
+				return fmt.Errorf("failed to import KubeJS lang files: %w", err)
+			}
+			totalKeys += count
+			fmt.Printf("Imported %d keys from KubeJS lang files\n", count)
 
-	fmt.Printf("\nImport complete: %d total keys\n", totalKeys)
-	return nil
+			scriptCandidates, err = scanKubeJSScripts(*kubejsDir)
+			if err != nil {
+				return fmt.Errorf("failed to scan KubeJS scripts: %w", err)
+			}
+		}
+
+		// Update version stats
+		modVersion.Stats.TotalKeys = totalKeys
+		if err := txRepo.SaveVersion(ctx, modVersion); err != nil {
+			return fmt.Errorf("failed to update version stats: %w", err)
+		}
+
+		fmt.Printf("\nImport complete: %d total keys\n", totalKeys)
+		if *kubejsDir != "" {
+			printKubeJSCandidates(scriptCandidates)
+		}
+		return nil
+	})
 }
 
 func importLangFile(ctx context.Context, repo *database.Repository, modID string, versionID int64, langFile string) (int, error) {
@@ -155,14 +198,12 @@ func importPatchouliEntries(ctx context.Context, repo *database.Repository, modI
 
 		content, err := os.ReadFile(path)
 		if err != nil {
-			fmt.Printf("Warning: failed to read %s: %v\n", path, err)
-			return nil
+			return fmt.Errorf("failed to read %s: %w", path, err)
 		}
 
 		var entry map[string]interface{}
 		if err := json.Unmarshal(content, &entry); err != nil {
-			fmt.Printf("Warning: failed to parse %s: %v\n", path, err)
-			return nil
+			return fmt.Errorf("failed to parse %s: %w", path, err)
 		}
 
 		// Get relative path for key prefix
@@ -217,17 +258,48 @@ func importPatchouliEntry(ctx context.Context, repo *database.Repository, modID
 	return count, nil
 }
 
+// importFTBQuests imports all *.snbt files under questsDir.
+// Keys are FTB Quests lang keys (ftbquests.quest.{id}.title, ...), see parser.SNBTParser.
+func importFTBQuests(ctx context.Context, repo *database.Repository, modID string, versionID int64, questsDir string) (int, error) {
+	snbtParser := parser.NewSNBTParser()
+	var totalKeys int
+
+	err := filepath.Walk(questsDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || !strings.HasSuffix(path, ".snbt") {
+			return nil
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", path, err)
+		}
+
+		entries, err := snbtParser.Parse(content)
+		if err != nil {
+			return fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+
+		for _, entry := range entries {
+			if err := importSingleEntry(ctx, repo, modID, versionID, entry.Key, entry.Text); err != nil {
+				return fmt.Errorf("failed to import %s: %w", path, err)
+			}
+			totalKeys++
+		}
+
+		return nil
+	})
+
+	return totalKeys, err
+}
+
 func importSingleEntry(ctx context.Context, repo *database.Repository, modID string, versionID int64, key, text string) error {
-	// Create or get source
-	source := &models.TranslationSource{
-		ModID:      modID,
-		Key:        key,
-		SourceText: text,
-		SourceLang: "en_us",
-		IsCurrent:  true,
-	}
-	if err := repo.SaveSource(ctx, source); err != nil {
-		return fmt.Errorf("failed to save source: %w", err)
+	// Get or create source (reuses if mod_id + key + source_text matches)
+	source, _, err := repo.GetOrCreateSource(ctx, modID, key, text, "en_us")
+	if err != nil {
+		return fmt.Errorf("failed to get/create source: %w", err)
 	}
 
 	// Link source to version
@@ -235,15 +307,18 @@ func importSingleEntry(ctx context.Context, repo *database.Repository, modID str
 		return fmt.Errorf("failed to link source to version: %w", err)
 	}
 
-	// Create translation (pending) - include both new and legacy fields
+	// Create translation (pending) unless the source already has one
+	existing, err := repo.GetTranslationForSource(ctx, source.ID)
+	if err != nil {
+		return fmt.Errorf("failed to check existing translation: %w", err)
+	}
+	if existing != nil {
+		return nil
+	}
 	trans := &models.Translation{
-		SourceID:     source.ID,
-		ModVersionID: versionID,
-		Key:          key,
-		SourceText:   text,
-		SourceLang:   "en_us",
-		TargetLang:   "ja_jp",
-		Status:       models.StatusPending,
+		SourceID:   source.ID,
+		TargetLang: "ja_jp",
+		Status:     models.StatusPending,
 	}
 	if err := repo.SaveTranslation(ctx, trans); err != nil {
 		return fmt.Errorf("failed to save translation: %w", err)
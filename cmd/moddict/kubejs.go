@@ -0,0 +1,102 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/iuif/minecraft-mod-dictionary/internal/database"
+	"github.com/iuif/minecraft-mod-dictionary/internal/parser"
+	"github.com/iuif/minecraft-mod-dictionary/pkg/interfaces"
+)
+
+// kubeJSScriptDirs are the kubejs subdirectories scanned for hard-coded strings.
+var kubeJSScriptDirs = []string{"startup_scripts", "server_scripts", "client_scripts"}
+
+// importKubeJSLang imports kubejs/assets/*/lang/en_us.json into the pack's pseudo-mod.
+// Returns the number of imported keys.
+func importKubeJSLang(ctx context.Context, repo *database.Repository, modID string, versionID int64, kubejsDir string) (int, error) {
+	langFiles, err := filepath.Glob(filepath.Join(kubejsDir, "assets", "*", "lang", "en_us.json"))
+	if err != nil {
+		return 0, fmt.Errorf("failed to find lang files: %w", err)
+	}
+
+	var totalKeys int
+	for _, langFile := range langFiles {
+		count, err := importLangFile(ctx, repo, modID, versionID, langFile)
+		if err != nil {
+			return totalKeys, err
+		}
+		fmt.Printf("  %s: %d keys\n", langFile, count)
+		totalKeys += count
+	}
+
+	return totalKeys, nil
+}
+
+// scanKubeJSScripts returns hard-coded display strings found in KubeJS scripts.
+// FilePath is set relative to kubejsDir.
+func scanKubeJSScripts(kubejsDir string) ([]interfaces.ParsedEntry, error) {
+	scriptParser := parser.NewKubeJSScriptParser()
+	var candidates []interfaces.ParsedEntry
+
+	for _, dir := range kubeJSScriptDirs {
+		root := filepath.Join(kubejsDir, dir)
+		if _, err := os.Stat(root); os.IsNotExist(err) {
+			continue
+		}
+
+		err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if info.IsDir() || !strings.HasSuffix(path, ".js") {
+				return nil
+			}
+
+			content, err := os.ReadFile(path)
+			if err != nil {
+				fmt.Printf("Warning: failed to read %s: %v\n", path, err)
+				return nil
+			}
+
+			entries, err := scriptParser.Parse(content)
+			if err != nil {
+				fmt.Printf("Warning: failed to parse %s: %v\n", path, err)
+				return nil
+			}
+
+			relPath, _ := filepath.Rel(kubejsDir, path)
+			for _, entry := range entries {
+				entry.FilePath = filepath.ToSlash(relPath)
+				candidates = append(candidates, entry)
+			}
+
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan %s: %w", root, err)
+		}
+	}
+
+	return candidates, nil
+}
+
+// printKubeJSCandidates prints script string candidates with file/line context.
+func printKubeJSCandidates(candidates []interfaces.ParsedEntry) {
+	fmt.Printf("\nHard-coded strings in scripts: %d\n", len(candidates))
+	if len(candidates) == 0 {
+		return
+	}
+
+	fmt.Println("Consider moving these into kubejs/assets/<namespace>/lang/en_us.json and using Text.translate():")
+	for _, c := range candidates {
+		call := ""
+		if c.Context != nil {
+			call = *c.Context
+		}
+		fmt.Printf("  %s:%d  [%s] %q\n", c.FilePath, c.LineNumber, call, c.Text)
+	}
+}
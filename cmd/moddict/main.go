@@ -31,14 +31,20 @@ func main() {
 		err = runView(args)
 	case "build":
 		err = runBuild(args)
+	case "db":
+		err = runDB(args)
 	case "migrate":
 		err = runMigrate(args)
 	case "repair":
 		err = runRepair(args)
 	case "analyze":
 		err = runAnalyze(args)
-	case "fix-schema":
-		err = runFixSchema(args)
+	case "terms":
+		err = runTerms(args)
+	case "tm":
+		err = runTM(args)
+	case "mod":
+		err = runMod(args)
 	case "version", "-v", "--version":
 		fmt.Printf("moddict version %s\n", version)
 		return
@@ -70,9 +76,12 @@ Commands:
   export      Export translations to various formats
   view        View translations in the database
   build       Build translation database from YAML files
-  migrate     Migrate existing data to new source-based schema
+  db          Manage database schema migrations (migrate, status)
   repair      Repair database inconsistencies
   analyze     Analyze translation consistency and discover patterns
+  terms       Export/import the term dictionary (TBX, CSV, YAML)
+  tm          Export/import translation memory (TMX)
+  mod         Archive, restore or purge mods and versions
   version     Show version information
   help        Show this help message
 
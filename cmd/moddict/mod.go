@@ -0,0 +1,293 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+
+	"github.com/iuif/minecraft-mod-dictionary/internal/database"
+	"github.com/iuif/minecraft-mod-dictionary/pkg/interfaces"
+	"github.com/iuif/minecraft-mod-dictionary/pkg/models"
+)
+
+func runMod(args []string) error {
+	if len(args) == 0 {
+		printModUsage()
+		return fmt.Errorf("subcommand is required")
+	}
+
+	switch args[0] {
+	case "archive":
+		return runModArchive(args[1:])
+	case "unarchive":
+		return runModUnarchive(args[1:])
+	case "purge":
+		return runModPurge(args[1:])
+	case "-h", "--help", "-help", "help":
+		printModUsage()
+		return nil
+	default:
+		return fmt.Errorf("unknown subcommand: %s", args[0])
+	}
+}
+
+func printModUsage() {
+	fmt.Print(`Usage: moddict mod <subcommand> [options]
+
+Archive mods and versions that are no longer used. Archived mods and versions
+are hidden from listings, analysis and exports, but their sources and
+translations are kept for translation memory (moddict tm export).
+
+Subcommands:
+  archive    Archive a mod (with all its versions) or a single version
+  unarchive  Restore an archived mod or version
+  purge      Permanently delete an archived mod or version
+
+Use "moddict mod <subcommand> --help" for more information.
+`)
+}
+
+// modTarget holds the flags shared by the mod subcommands.
+type modTarget struct {
+	dbPath    *string
+	modID     *string
+	version   *string
+	mcVersion *string
+}
+
+func addModTargetFlags(fs *flag.FlagSet) modTarget {
+	return modTarget{
+		dbPath:    fs.String("db", "moddict.db", "Database file path or postgres:// URL"),
+		modID:     fs.String("mod", "", "Mod ID (required)"),
+		version:   fs.String("version", "", "Mod version (empty for the whole mod)"),
+		mcVersion: fs.String("mc", "", "Minecraft version (needed if the version exists for several)"),
+	}
+}
+
+// resolveVersion finds the version of a mod given by -version and -mc, archived or not.
+func (t modTarget) resolveVersion(ctx context.Context, repo *database.Repository) (*models.ModVersion, error) {
+	versions, err := repo.ListVersions(ctx, *t.modID, interfaces.VersionFilter{MCVersion: *t.mcVersion, IncludeArchived: true})
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []*models.ModVersion
+	for _, v := range versions {
+		if v.Version == *t.version {
+			matches = append(matches, v)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("version %s of %s not found", *t.version, *t.modID)
+	case 1:
+		return matches[0], nil
+	default:
+		return nil, fmt.Errorf("version %s of %s exists for %d Minecraft versions (use -mc)", *t.version, *t.modID, len(matches))
+	}
+}
+
+// openRepository opens the database and brings it to the archive schema.
+func (t modTarget) openRepository() (*database.Repository, error) {
+	repo, err := database.NewRepository(*t.dbPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+	if err := repo.Migrate(); err != nil {
+		repo.Close()
+		return nil, fmt.Errorf("failed to migrate database: %w", err)
+	}
+	return repo, nil
+}
+
+func parseModTarget(fs *flag.FlagSet, t modTarget, args []string) error {
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *t.modID == "" {
+		fs.Usage()
+		return fmt.Errorf("-mod is required")
+	}
+	return nil
+}
+
+func runModArchive(args []string) error {
+	fs := flag.NewFlagSet("mod archive", flag.ExitOnError)
+	t := addModTargetFlags(fs)
+
+	fs.Usage = func() {
+		fmt.Print(`Usage: moddict mod archive -mod <id> [-version <version>] [options]
+
+Archive a mod with all its versions, or a single version. If the default
+version is archived, the newest remaining version becomes the default.
+
+Options:
+`)
+		fs.PrintDefaults()
+		fmt.Print(`
+Examples:
+  moddict mod archive -mod create
+  moddict mod archive -mod create -version 0.5.1
+`)
+	}
+
+	if err := parseModTarget(fs, t, args); err != nil {
+		return err
+	}
+
+	repo, err := t.openRepository()
+	if err != nil {
+		return err
+	}
+	defer repo.Close()
+
+	ctx := context.Background()
+
+	if *t.version == "" {
+		if err := repo.ArchiveMod(ctx, *t.modID); err != nil {
+			return err
+		}
+		fmt.Printf("Archived mod %s\n", *t.modID)
+		return nil
+	}
+
+	version, err := t.resolveVersion(ctx, repo)
+	if err != nil {
+		return err
+	}
+	if err := repo.ArchiveVersion(ctx, version.ID); err != nil {
+		return err
+	}
+	fmt.Printf("Archived %s v%s (%s)\n", *t.modID, version.Version, version.MCVersion)
+	if version.IsDefault {
+		if def, err := repo.GetDefaultVersion(ctx, *t.modID); err == nil {
+			fmt.Printf("Default version is now v%s\n", def.Version)
+		}
+	}
+	return nil
+}
+
+func runModUnarchive(args []string) error {
+	fs := flag.NewFlagSet("mod unarchive", flag.ExitOnError)
+	t := addModTargetFlags(fs)
+
+	fs.Usage = func() {
+		fmt.Print(`Usage: moddict mod unarchive -mod <id> [-version <version>] [options]
+
+Restore an archived mod or version. Restoring a mod also restores the
+versions archived with it; versions archived on their own before the mod
+stay archived. A restored version does not become the default again;
+re-import it to make it the default.
+
+Options:
+`)
+		fs.PrintDefaults()
+	}
+
+	if err := parseModTarget(fs, t, args); err != nil {
+		return err
+	}
+
+	repo, err := t.openRepository()
+	if err != nil {
+		return err
+	}
+	defer repo.Close()
+
+	ctx := context.Background()
+
+	if *t.version == "" {
+		if err := repo.UnarchiveMod(ctx, *t.modID); err != nil {
+			return err
+		}
+		fmt.Printf("Restored mod %s\n", *t.modID)
+		return nil
+	}
+
+	version, err := t.resolveVersion(ctx, repo)
+	if err != nil {
+		return err
+	}
+	if err := repo.UnarchiveVersion(ctx, version.ID); err != nil {
+		if errors.Is(err, database.ErrModArchived) {
+			return fmt.Errorf("%w: restore it with \"moddict mod unarchive -mod %s\"", err, *t.modID)
+		}
+		return err
+	}
+	fmt.Printf("Restored %s v%s (%s)\n", *t.modID, version.Version, version.MCVersion)
+	return nil
+}
+
+func runModPurge(args []string) error {
+	fs := flag.NewFlagSet("mod purge", flag.ExitOnError)
+	t := addModTargetFlags(fs)
+	noSnapshot := fs.Bool("no-snapshot", false, "Do not snapshot the database before purging")
+
+	fs.Usage = func() {
+		fmt.Print(`Usage: moddict mod purge -mod <id> [-version <version>] [options]
+
+Permanently delete an archived mod or version. Purging a mod deletes all its
+versions, sources and translations. Purging a version deletes its sources
+that are not used by another version, with their translations. Only archived
+mods and versions can be purged; archive them first.
+
+A snapshot of the database is saved first unless -no-snapshot is given;
+undo a purge with "moddict db restore -from latest".
+
+Options:
+`)
+		fs.PrintDefaults()
+	}
+
+	if err := parseModTarget(fs, t, args); err != nil {
+		return err
+	}
+
+	repo, err := t.openRepository()
+	if err != nil {
+		return err
+	}
+	defer repo.Close()
+
+	ctx := context.Background()
+
+	mod, err := repo.GetMod(ctx, *t.modID)
+	if err != nil {
+		return err
+	}
+
+	var version *models.ModVersion
+	if *t.version == "" {
+		if !mod.IsArchived() {
+			return fmt.Errorf("mod %s is not archived (run \"moddict mod archive -mod %s\" first)", mod.ID, mod.ID)
+		}
+	} else {
+		if version, err = t.resolveVersion(ctx, repo); err != nil {
+			return err
+		}
+		if !version.IsArchived() {
+			return fmt.Errorf("%s v%s is not archived (run \"moddict mod archive -mod %s -version %s\" first)", mod.ID, version.Version, mod.ID, version.Version)
+		}
+	}
+
+	if !*noSnapshot {
+		if err := snapshotBeforeChange(*t.dbPath, "purge"); err != nil {
+			return err
+		}
+	}
+
+	if version == nil {
+		if err := repo.DeleteMod(ctx, mod.ID); err != nil {
+			return err
+		}
+		fmt.Printf("Purged mod %s\n", mod.ID)
+		return nil
+	}
+
+	if err := repo.DeleteVersion(ctx, version.ID); err != nil {
+		return err
+	}
+	fmt.Printf("Purged %s v%s (%s)\n", mod.ID, version.Version, version.MCVersion)
+	return nil
+}
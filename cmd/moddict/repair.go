@@ -13,21 +13,28 @@ func runRepair(args []string) error {
 	fs := flag.NewFlagSet("repair", flag.ExitOnError)
 
 	var (
-		dbPath = fs.String("db", "moddict.db", "Database file path")
-		dryRun = fs.Bool("dry-run", false, "Show what would be repaired without making changes")
+		dbPath     = fs.String("db", "moddict.db", "Database file path or postgres:// URL")
+		dryRun     = fs.Bool("dry-run", false, "Show what would be repaired without making changes")
+		noSnapshot = fs.Bool("no-snapshot", false, "Do not snapshot the database before repairing")
 	)
 
 	fs.Usage = func() {
 		fmt.Print(`Usage: moddict repair [options]
 
 Repair database inconsistencies:
-1. Set is_default=true for only the latest version of each mod
-2. Merge duplicate sources (same mod_id + key + source_text)
+1. Set is_default=true for only the latest active version of each mod
+2. Resolve duplicate and orphaned rows (schema migration with unique
+   indexes and foreign keys)
 3. Clean up orphaned data
 4. Verify data integrity
 5. Reset is_current flag for all sources
 6. Ensure all sources are linked to default version
 
+Archived mods, and sources of archived versions, are left as they are.
+
+A snapshot of the database is saved first unless -dry-run or -no-snapshot
+is given; undo a repair with "moddict db restore -from latest".
+
 Options:
 `)
 		fs.PrintDefaults()
@@ -37,6 +44,12 @@ Options:
 		return err
 	}
 
+	if !*dryRun && !*noSnapshot {
+		if err := snapshotBeforeChange(*dbPath, "repair"); err != nil {
+			return err
+		}
+	}
+
 	repo, err := database.NewRepository(*dbPath)
 	if err != nil {
 		return fmt.Errorf("failed to open database: %w", err)
@@ -46,20 +59,49 @@ Options:
 	ctx := context.Background()
 	db := repo.GetDB()
 
+	// Archived rows are skipped; databases before the archive migration have none
+	hasArchive := repo.HasColumn("mod_versions", "archived_at")
+	activeOnly := ""
+	if hasArchive {
+		activeOnly = " AND archived_at IS NULL"
+	}
+	// notArchivedSource is a condition on the translation_sources row named by table that
+	// skips sources linked to an archived version.
+	notArchivedSource := func(table string) string {
+		if !hasArchive {
+			return ""
+		}
+		return fmt.Sprintf(`
+			AND NOT EXISTS (
+				SELECT 1 FROM source_versions asv
+				JOIN mod_versions amv ON amv.id = asv.mod_version_id
+				WHERE asv.source_id = %s.id AND amv.archived_at IS NOT NULL
+			)`, table)
+	}
+
 	fmt.Println("=== Database Repair ===")
 	fmt.Println()
+	if *dryRun && !hasArchive {
+		fmt.Println("Note: the schema is not up to date, so some steps cannot be checked.")
+		fmt.Println("Run \"moddict db migrate\" first for a complete report.")
+		fmt.Println()
+	}
 
 	// 1. Fix is_default for mod_versions
 	fmt.Println("Step 1: Fixing mod_versions.is_default...")
+	modsQuery := db
+	if hasArchive {
+		modsQuery = modsQuery.Where("archived_at IS NULL")
+	}
 	var mods []*models.Mod
-	if err := db.Find(&mods).Error; err != nil {
+	if err := modsQuery.Find(&mods).Error; err != nil {
 		return fmt.Errorf("failed to get mods: %w", err)
 	}
 
 	versionsFixed := 0
 	for _, mod := range mods {
 		var versions []*models.ModVersion
-		if err := db.Where("mod_id = ?", mod.ID).Order("id DESC").Find(&versions).Error; err != nil {
+		if err := db.Where("mod_id = ?"+activeOnly, mod.ID).Order("id DESC").Find(&versions).Error; err != nil {
 			return fmt.Errorf("failed to get versions for %s: %w", mod.ID, err)
 		}
 
@@ -92,172 +134,43 @@ Options:
 	}
 	fmt.Printf("  Versions to fix: %d\n\n", versionsFixed)
 
-	// 2. Find and merge duplicate sources
-	fmt.Println("Step 2: Finding duplicate sources (same mod_id + key + source_text)...")
-	type DuplicateGroup struct {
-		ModID      string
-		Key        string
-		SourceText string
-		Count      int64
-		MinID      int64
-	}
-	var duplicates []DuplicateGroup
-	err = db.Raw(`
-		SELECT mod_id, key, source_text, COUNT(*) as count, MIN(id) as min_id
-		FROM translation_sources
-		GROUP BY mod_id, key, source_text
-		HAVING count > 1
-	`).Scan(&duplicates).Error
+	// 2. Duplicate sources, links and translations are prevented by unique indexes since
+	// schema version 5; older databases are fixed by the migration
+	fmt.Println("Step 2: Checking unique and foreign key constraints...")
+	report, err := repo.CheckConstraints(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to find duplicates: %w", err)
+		return err
 	}
-
-	fmt.Printf("  Found %d duplicate groups\n", len(duplicates))
-
-	sourcesMerged := 0
-	for _, dup := range duplicates {
-		if *dryRun {
-			fmt.Printf("  Would merge %d duplicates for key: %s\n", dup.Count, dup.Key)
-			sourcesMerged += int(dup.Count - 1)
-			continue
-		}
-
-		// Get all source IDs for this duplicate group with their translation status
-		type SourceWithStatus struct {
-			ID        int64
-			Status    string
-			TargetText string
-		}
-		var sources []SourceWithStatus
-		db.Raw(`
-			SELECT ts.id,
-				COALESCE(t.status, 'pending') as status,
-				COALESCE(t.target_text, '') as target_text
-			FROM translation_sources ts
-			LEFT JOIN translations t ON ts.id = t.source_id
-			WHERE ts.mod_id = ? AND ts.key = ? AND ts.source_text = ?
-			ORDER BY
-				CASE
-					WHEN t.status = 'official' THEN 1
-					WHEN t.status = 'translated' THEN 2
-					WHEN t.status = 'needs_review' THEN 3
-					WHEN t.status = 'pending' THEN 4
-					ELSE 5
-				END ASC,
-				ts.id ASC
-		`, dup.ModID, dup.Key, dup.SourceText).Scan(&sources)
-
-		if len(sources) <= 1 {
-			continue
-		}
-
-		// Keep the source with the best translation status
-		keepID := sources[0].ID
-		deleteIDs := make([]int64, 0, len(sources)-1)
-		for i := 1; i < len(sources); i++ {
-			deleteIDs = append(deleteIDs, sources[i].ID)
+	fmt.Printf("  Duplicate sources:       %d\n", report.DuplicateSources)
+	fmt.Printf("  Duplicate version links: %d\n", report.DuplicateSourceVersions)
+	fmt.Printf("  Duplicate translations:  %d\n", report.DuplicateTranslations)
+	fmt.Printf("  Orphaned rows:           %d\n", report.MissingMods+report.OrphanSourceVersions+report.OrphanTranslations)
+	if !*dryRun && report.Total() > 0 {
+		// Only the constraints migration resolves violations; later migrations are left to
+		// "moddict db migrate", and newer databases are never reverted to it
+		version, err := repo.SchemaVersion(ctx)
+		if err != nil {
+			return err
 		}
-
-		// Update source_versions to point to the kept source
-		for _, delID := range deleteIDs {
-			db.Exec(`
-				UPDATE source_versions SET source_id = ?
-				WHERE source_id = ? AND NOT EXISTS (
-					SELECT 1 FROM source_versions sv2
-					WHERE sv2.source_id = ? AND sv2.mod_version_id = source_versions.mod_version_id
-				)
-			`, keepID, delID, keepID)
-
-			// Delete duplicate source_versions
-			db.Exec(`DELETE FROM source_versions WHERE source_id = ?`, delID)
-
-			// Update translations to point to the kept source (keep the first translation)
-			var transCount int64
-			db.Raw(`SELECT COUNT(*) FROM translations WHERE source_id = ?`, keepID).Scan(&transCount)
-			if transCount == 0 {
-				// No translation for kept source, update one from deleted
-				db.Exec(`
-					UPDATE translations SET source_id = ?
-					WHERE source_id = ? AND id = (
-						SELECT MIN(id) FROM translations WHERE source_id = ?
-					)
-				`, keepID, delID, delID)
+		if version < database.ConstraintsSchemaVersion {
+			if _, err := repo.MigrateTo(ctx, database.ConstraintsSchemaVersion); err != nil {
+				return fmt.Errorf("failed to migrate database: %w", err)
 			}
-			// Delete remaining translations for deleted source
-			db.Exec(`DELETE FROM translations WHERE source_id = ?`, delID)
-
-			// Delete the duplicate source
-			db.Exec(`DELETE FROM translation_sources WHERE id = ?`, delID)
-			sourcesMerged++
 		}
-	}
-	fmt.Printf("  Sources merged: %d\n\n", sourcesMerged)
-
-	// 3. Clean up duplicate translations (same source_id) with priority-based consolidation
-	fmt.Println("Step 3: Finding duplicate translations (same source_id)...")
-	type DuplicateTranslation struct {
-		SourceID int64
-		Count    int64
-		MinID    int64
-	}
-	var duplicateTranslations []DuplicateTranslation
-	err = db.Raw(`
-		SELECT source_id, COUNT(*) as count, MIN(id) as min_id
-		FROM translations
-		GROUP BY source_id
-		HAVING count > 1
-	`).Scan(&duplicateTranslations).Error
-	if err != nil {
-		return fmt.Errorf("failed to find duplicate translations: %w", err)
-	}
-
-	fmt.Printf("  Found %d source IDs with duplicate translations\n", len(duplicateTranslations))
-
-	translationsMerged := 0
-	if len(duplicateTranslations) > 0 && !*dryRun {
-		for _, dup := range duplicateTranslations {
-			// Get all translations for this source_id with their status
-			type TranslationRecord struct {
-				ID        int64
-				Status    string
-				TargetText string
-			}
-			var records []TranslationRecord
-			db.Raw(`
-				SELECT id, status, target_text FROM translations
-				WHERE source_id = ?
-				ORDER BY
-					CASE
-						WHEN status = 'official' THEN 1
-						WHEN status = 'translated' THEN 2
-						WHEN status = 'needs_review' THEN 3
-						WHEN status = 'pending' THEN 4
-						ELSE 5
-					END ASC,
-					id ASC
-			`, dup.SourceID).Scan(&records)
-
-			if len(records) > 1 {
-				// Keep the first one (highest priority), delete the rest
-				keepID := records[0].ID
-				keepStatus := records[0].Status
 
-				if *dryRun {
-					fmt.Printf("  Would keep translation id=%d (status=%s) and delete %d others\n",
-						keepID, keepStatus, len(records)-1)
-				} else {
-					// Delete other translations
-					db.Exec(`DELETE FROM translations WHERE source_id = ? AND id != ?`,
-						dup.SourceID, keepID)
-					translationsMerged += len(records) - 1
-				}
-			}
+		after, err := repo.CheckConstraints(ctx)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("  Resolved %d violations\n", report.Total()-after.Total())
+		if after.Total() > 0 {
+			fmt.Printf("  Remaining violations:    %d\n", after.Total())
 		}
-		fmt.Printf("  Removed %d duplicate translations\n", translationsMerged)
 	}
+	fmt.Println()
 
-	// 4. Clean up sources without translations
-	fmt.Println("Step 4: Finding orphaned sources (no translation)...")
+	// 3. Clean up sources without translations
+	fmt.Println("Step 3: Finding orphaned sources (no translation)...")
 	var orphanedSources int64
 	db.Raw(`
 		SELECT COUNT(*) FROM translation_sources s
@@ -276,8 +189,8 @@ Options:
 		fmt.Printf("  Created pending translations for orphaned sources\n")
 	}
 
-	// 5. Verify counts
-	fmt.Println("\nStep 5: Verifying data integrity...")
+	// 4. Verify counts
+	fmt.Println("\nStep 4: Verifying data integrity...")
 
 	// Check for sources without version links
 	var unlinkedSources int64
@@ -305,15 +218,16 @@ Options:
 		fmt.Printf("  Linked orphaned sources to default versions\n")
 	}
 
-	// 6. Reset is_current flag for all sources (deprecated field but need consistency)
-	fmt.Println("\nStep 6: Resetting is_current flag for all sources...")
+	// 5. Reset is_current flag for all sources (deprecated field but need consistency)
+	fmt.Println("\nStep 5: Resetting is_current flag for all sources...")
 	if !*dryRun {
 		db.Exec(`UPDATE translation_sources SET is_current = true`)
 		fmt.Printf("  All sources marked as is_current=true\n")
 	}
 
-	// 7. Link all sources to default version (for sources linked to other versions but not default)
-	fmt.Println("\nStep 7: Ensuring all sources are linked to default version...")
+	// 6. Link all sources to default version (for sources linked to other versions but not default)
+	// Sources of archived versions stay out of the default version
+	fmt.Println("\nStep 6: Ensuring all sources are linked to default version...")
 	var sourcesNotLinkedToDefault int64
 	for _, mod := range mods {
 		defaultVersion, err := repo.GetDefaultVersion(ctx, mod.ID)
@@ -329,7 +243,7 @@ Options:
 				SELECT 1 FROM source_versions sv
 				WHERE sv.source_id = s.id AND sv.mod_version_id = ?
 			)
-		`, mod.ID, defaultVersion.ID).Scan(&count)
+		`+notArchivedSource("s"), mod.ID, defaultVersion.ID).Scan(&count)
 
 		if count > 0 {
 			fmt.Printf("  %s: %d sources not linked to default version\n", mod.ID, count)
@@ -345,7 +259,7 @@ Options:
 						SELECT 1 FROM source_versions sv
 						WHERE sv.source_id = s.id AND sv.mod_version_id = ?
 					)
-				`, defaultVersion.ID, mod.ID, defaultVersion.ID)
+				`+notArchivedSource("s"), defaultVersion.ID, mod.ID, defaultVersion.ID)
 			}
 		}
 	}
@@ -355,8 +269,8 @@ Options:
 		fmt.Printf("  Linked %d sources to default versions\n", sourcesNotLinkedToDefault)
 	}
 
-	// 8. Detect and fix source=target translations (untranslated entries)
-	fmt.Println("\nStep 8: Detecting source=target translations (untranslated entries)...")
+	// 7. Detect and fix source=target translations (untranslated entries)
+	fmt.Println("\nStep 7: Detecting source=target translations (untranslated entries)...")
 	type SameAsSource struct {
 		ModID string
 		Count int64
@@ -393,8 +307,8 @@ Options:
 		fmt.Printf("  No source=target translations found (good!)\n")
 	}
 
-	// 9. Clean up duplicate sources (same mod_id + key, different source_text from old versions)
-	fmt.Println("\nStep 9: Cleaning up duplicate sources (same mod_id + key)...")
+	// 8. Clean up duplicate sources (same mod_id + key, different source_text from old versions)
+	fmt.Println("\nStep 8: Cleaning up duplicate sources (same mod_id + key)...")
 	type DuplicateKey struct {
 		ModID string
 		Key   string
@@ -405,7 +319,7 @@ Options:
 		SELECT mod_id, key, COUNT(*) as count
 		FROM translation_sources
 		GROUP BY mod_id, key
-		HAVING count > 1
+		HAVING COUNT(*) > 1
 	`).Scan(&duplicateKeys)
 
 	duplicateSourcesRemoved := 0
@@ -420,9 +334,9 @@ Options:
 					SELECT ts.id FROM translation_sources ts
 					JOIN source_versions sv ON ts.id = sv.source_id
 					JOIN mod_versions mv ON sv.mod_version_id = mv.id
-					WHERE ts.mod_id = ? AND ts.key = ? AND mv.is_default = 1
+					WHERE ts.mod_id = ? AND ts.key = ? AND mv.is_default = ?
 					LIMIT 1
-				`, dk.ModID, dk.Key).Scan(&defaultSourceID)
+				`, dk.ModID, dk.Key, true).Scan(&defaultSourceID)
 
 				if defaultSourceID == 0 {
 					// No source linked to default, keep the newest one
@@ -434,12 +348,12 @@ Options:
 				}
 
 				if defaultSourceID > 0 {
-					// Delete other sources and their translations
+					// Delete other sources and their translations, keeping archived versions intact
 					var otherSourceIDs []int64
 					db.Raw(`
 						SELECT id FROM translation_sources
 						WHERE mod_id = ? AND key = ? AND id != ?
-					`, dk.ModID, dk.Key, defaultSourceID).Scan(&otherSourceIDs)
+					`+notArchivedSource("translation_sources"), dk.ModID, dk.Key, defaultSourceID).Scan(&otherSourceIDs)
 
 					for _, sid := range otherSourceIDs {
 						db.Exec(`DELETE FROM translations WHERE source_id = ?`, sid)
@@ -0,0 +1,300 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/iuif/minecraft-mod-dictionary/internal/database"
+	"github.com/iuif/minecraft-mod-dictionary/internal/export"
+	"github.com/iuif/minecraft-mod-dictionary/pkg/interfaces"
+	"github.com/iuif/minecraft-mod-dictionary/pkg/models"
+)
+
+func runTerms(args []string) error {
+	if len(args) == 0 {
+		printTermsUsage()
+		return fmt.Errorf("subcommand is required")
+	}
+
+	switch args[0] {
+	case "export":
+		return runTermsExport(args[1:])
+	case "import":
+		return runTermsImport(args[1:])
+	case "-h", "--help", "-help", "help":
+		printTermsUsage()
+		return nil
+	default:
+		return fmt.Errorf("unknown subcommand: %s", args[0])
+	}
+}
+
+func printTermsUsage() {
+	fmt.Print(`Usage: moddict terms <subcommand> [options]
+
+Exchange the term dictionary as TBX-Basic or CSV glossaries.
+
+Subcommands:
+  export  Export terms as TBX, CSV, JSON or data/terms YAML files
+  import  Import a TBX or CSV glossary into the database or data/terms YAML files
+
+Use "moddict terms <subcommand> --help" for more information.
+`)
+}
+
+func runTermsExport(args []string) error {
+	fs := flag.NewFlagSet("terms export", flag.ExitOnError)
+
+	var (
+		dbPath     = fs.String("db", "moddict.db", "Database file path or postgres:// URL")
+		format     = fs.String("format", "tbx", "Output format (tbx, csv, json, yaml)")
+		outPath    = fs.String("out", "", "Output file path (directory for yaml) (required)")
+		scope      = fs.String("scope", "", "Export only this scope (e.g. global, category:tech, mod:create)")
+		sourceLang = fs.String("source-lang", "en_us", "Source language")
+		targetLang = fs.String("lang", "ja_jp", "Target language")
+		force      = fs.Bool("force", false, "Overwrite existing files with the yaml format")
+	)
+
+	fs.Usage = func() {
+		fmt.Print(`Usage: moddict terms export -out <path> [options]
+
+Export the term dictionary with scope, tags, context, priority and notes.
+The yaml format writes one file per scope in the data/terms layout
+(global.yaml, categories/<name>.yaml, mods/<mod_id>.yaml); existing files
+are only replaced with -force.
+
+Options:
+`)
+		fs.PrintDefaults()
+		fmt.Print(`
+Examples:
+  moddict terms export -format tbx -out glossary.tbx
+  moddict terms export -format csv -scope mod:create -out create_terms.csv
+  moddict terms export -format yaml -out data/terms -force
+`)
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *outPath == "" {
+		fs.Usage()
+		return fmt.Errorf("-out is required")
+	}
+
+	repo, err := database.NewRepository(*dbPath)
+	if err != nil {
+		return fmt.Errorf("failed to open database: %w", err)
+	}
+	defer repo.Close()
+
+	terms, err := repo.ListTerms(context.Background(), interfaces.TermFilter{Scope: *scope, TargetLang: *targetLang})
+	if err != nil {
+		return err
+	}
+
+	exporter := export.NewExporter()
+	switch *format {
+	case "tbx":
+		err = exporter.ExportTBX(terms, *sourceLang, *targetLang, *outPath)
+	case "csv":
+		err = exporter.ExportTermsCSV(terms, *outPath)
+	case "json":
+		err = exporter.ExportTerms(terms, *outPath)
+	case "yaml":
+		var written []string
+		written, err = writeTermYAML(terms, *outPath, *force)
+		if err == nil {
+			fmt.Printf("Exported %d terms to %d files in %s\n", len(terms), len(written), *outPath)
+			return nil
+		}
+	default:
+		return fmt.Errorf("unknown format: %s", *format)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to export terms: %w", err)
+	}
+
+	fmt.Printf("Exported %d terms to %s\n", len(terms), *outPath)
+	return nil
+}
+
+func runTermsImport(args []string) error {
+	fs := flag.NewFlagSet("terms import", flag.ExitOnError)
+
+	var (
+		dbPath     = fs.String("db", "moddict.db", "Database file path or postgres:// URL")
+		filePath   = fs.String("file", "", "TBX (.tbx) or CSV (.csv) glossary to import (required)")
+		yamlOut    = fs.String("yaml-out", "", "Write data/terms YAML files to this directory instead of the database")
+		force      = fs.Bool("force", false, "Overwrite existing YAML files with -yaml-out")
+		sourceLang = fs.String("source-lang", "en_us", "Source language")
+		targetLang = fs.String("lang", "ja_jp", "Target language")
+		dryRun     = fs.Bool("dry-run", false, "Show what would be imported without changing the database")
+	)
+
+	fs.Usage = func() {
+		fmt.Print(`Usage: moddict terms import -file <glossary> [options]
+
+Import a TBX or CSV glossary. Terms are matched by scope and source text:
+existing terms are updated, new terms are added.
+
+With -yaml-out the glossary is converted to data/terms YAML files instead
+(one file per scope), so spreadsheet edits can be committed and loaded by
+"moddict build". Existing files are only replaced with -force.
+
+Options:
+`)
+		fs.PrintDefaults()
+		fmt.Print(`
+Examples:
+  moddict terms import -file glossary.tbx
+  moddict terms import -file terms.csv -yaml-out data/terms
+`)
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *filePath == "" {
+		fs.Usage()
+		return fmt.Errorf("-file is required")
+	}
+
+	content, err := os.ReadFile(*filePath)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", *filePath, err)
+	}
+
+	var terms []*models.Term
+	switch strings.ToLower(filepath.Ext(*filePath)) {
+	case ".tbx", ".xml":
+		terms, err = export.ParseTBX(content, *sourceLang, *targetLang)
+	case ".csv":
+		terms, err = export.ParseTermsCSV(content, *sourceLang, *targetLang)
+	default:
+		return fmt.Errorf("unsupported glossary file: %s (expected .tbx or .csv)", *filePath)
+	}
+	if err != nil {
+		return err
+	}
+
+	if *yamlOut != "" {
+		written, err := writeTermYAML(terms, *yamlOut, *force)
+		if err != nil {
+			return err
+		}
+		for _, path := range written {
+			fmt.Printf("  Wrote %s\n", path)
+		}
+		fmt.Printf("Converted %d terms to %d files in %s\n", len(terms), len(written), *yamlOut)
+		return nil
+	}
+
+	repo, err := database.NewRepository(*dbPath)
+	if err != nil {
+		return fmt.Errorf("failed to open database: %w", err)
+	}
+	defer repo.Close()
+
+	if err := repo.Migrate(); err != nil {
+		return fmt.Errorf("failed to migrate database: %w", err)
+	}
+
+	ctx := context.Background()
+	return runInTx(ctx, repo, *dryRun, func(txRepo *database.Repository) error {
+		existing, err := txRepo.ListTerms(ctx, interfaces.TermFilter{TargetLang: *targetLang})
+		if err != nil {
+			return err
+		}
+		byKey := make(map[string]*models.Term, len(existing))
+		for _, term := range existing {
+			byKey[termKey(term)] = term
+		}
+
+		// Later rows win when a glossary lists the same term twice
+		var toSave []*models.Term
+		index := make(map[string]int)
+		for _, term := range terms {
+			key := termKey(term)
+			if i, ok := index[key]; ok {
+				toSave[i] = term
+				continue
+			}
+			index[key] = len(toSave)
+			toSave = append(toSave, term)
+		}
+
+		var added, updated int
+		for _, term := range toSave {
+			if current, ok := byKey[termKey(term)]; ok {
+				term.ID = current.ID
+				term.CreatedAt = current.CreatedAt
+				updated++
+			} else {
+				added++
+			}
+		}
+
+		if err := txRepo.BulkSaveTerms(ctx, toSave); err != nil {
+			return fmt.Errorf("failed to save terms: %w", err)
+		}
+
+		fmt.Printf("Imported %d terms from %s\n", len(toSave), *filePath)
+		fmt.Printf("  Added:   %d\n", added)
+		fmt.Printf("  Updated: %d\n", updated)
+		return nil
+	})
+}
+
+// termKey identifies a term by scope and case-insensitive source text.
+func termKey(term *models.Term) string {
+	return term.Scope + "\x00" + strings.ToLower(term.SourceText)
+}
+
+// writeTermYAML writes terms as data/terms YAML files under dir and returns the written paths.
+// Existing files are only replaced with force; otherwise nothing is written.
+func writeTermYAML(terms []*models.Term, dir string, force bool) ([]string, error) {
+	files, err := export.BuildTermYAML(terms)
+	if err != nil {
+		return nil, err
+	}
+
+	paths := make([]string, 0, len(files))
+	for rel := range files {
+		paths = append(paths, rel)
+	}
+	sort.Strings(paths)
+
+	if !force {
+		var existing []string
+		for _, rel := range paths {
+			if _, err := os.Stat(filepath.Join(dir, rel)); err == nil {
+				existing = append(existing, filepath.Join(dir, rel))
+			}
+		}
+		if len(existing) > 0 {
+			return nil, fmt.Errorf("refusing to overwrite existing files (use -force): %s", strings.Join(existing, ", "))
+		}
+	}
+
+	written := make([]string, 0, len(paths))
+	for _, rel := range paths {
+		path := filepath.Join(dir, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return nil, fmt.Errorf("failed to create directory for %s: %w", path, err)
+		}
+		if err := os.WriteFile(path, files[rel], 0644); err != nil {
+			return nil, fmt.Errorf("failed to write %s: %w", path, err)
+		}
+		written = append(written, path)
+	}
+
+	return written, nil
+}
@@ -0,0 +1,251 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/iuif/minecraft-mod-dictionary/internal/database"
+	"github.com/iuif/minecraft-mod-dictionary/internal/export"
+	"github.com/iuif/minecraft-mod-dictionary/pkg/interfaces"
+	"github.com/iuif/minecraft-mod-dictionary/pkg/models"
+)
+
+// memoryPriorityDefault is the priority of imported translation memory entries.
+// Memory entries are only suggestions and never overwrite translations.
+const memoryPriorityDefault = 10
+
+func runTM(args []string) error {
+	if len(args) == 0 {
+		printTMUsage()
+		return fmt.Errorf("subcommand is required")
+	}
+
+	switch args[0] {
+	case "export":
+		return runTMExport(args[1:])
+	case "import":
+		return runTMImport(args[1:])
+	case "-h", "--help", "-help", "help":
+		printTMUsage()
+		return nil
+	default:
+		return fmt.Errorf("unknown subcommand: %s", args[0])
+	}
+}
+
+func printTMUsage() {
+	fmt.Print(`Usage: moddict tm <subcommand> [options]
+
+Exchange translation memory with CAT tools as TMX 1.4b.
+
+Subcommands:
+  export  Export translations as a TMX file (deduplicated by source text)
+  import  Import a TMX file as translation memory suggestions
+
+Use "moddict tm <subcommand> --help" for more information.
+`)
+}
+
+func runTMExport(args []string) error {
+	fs := flag.NewFlagSet("tm export", flag.ExitOnError)
+
+	var (
+		dbPath     = fs.String("db", "moddict.db", "Database file path or postgres:// URL")
+		outPath    = fs.String("out", "", "Output TMX file path (required)")
+		modID      = fs.String("mod", "", "Export only this mod (empty for all mods)")
+		sourceLang = fs.String("source-lang", "en_us", "Source language")
+		targetLang = fs.String("lang", "ja_jp", "Target language")
+	)
+
+	fs.Usage = func() {
+		fmt.Print(`Usage: moddict tm export -out <file> [options]
+
+Export translations as a TMX 1.4b translation memory. Units are deduplicated by
+source text; each unit lists the mods and keys using its translation as
+x-moddict-mod / x-moddict-key properties. Pending translations are skipped.
+Archived mods and versions are included.
+
+Options:
+`)
+		fs.PrintDefaults()
+		fmt.Print(`
+Examples:
+  moddict tm export -out moddict.tmx
+  moddict tm export -mod create -out create.tmx
+`)
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *outPath == "" {
+		fs.Usage()
+		return fmt.Errorf("-out is required")
+	}
+
+	repo, err := database.NewRepository(*dbPath)
+	if err != nil {
+		return fmt.Errorf("failed to open database: %w", err)
+	}
+	defer repo.Close()
+
+	ctx := context.Background()
+
+	var modIDs []string
+	if *modID != "" {
+		modIDs = []string{*modID}
+	} else {
+		// Archived mods keep their translations for reuse as translation memory
+		mods, err := repo.ListMods(ctx, interfaces.ModFilter{IncludeArchived: true})
+		if err != nil {
+			return fmt.Errorf("failed to list mods: %w", err)
+		}
+		for _, mod := range mods {
+			modIDs = append(modIDs, mod.ID)
+		}
+	}
+
+	var entries []export.TMXEntry
+	for _, id := range modIDs {
+		filter := interfaces.TranslationIterFilter{ModID: id, TargetLang: *targetLang, IncludeArchived: true}
+		err := repo.IterateTranslations(ctx, filter, func(t *models.TranslationWithSource) error {
+			if t.TargetText == nil || t.Status == models.StatusPending {
+				return nil
+			}
+			entries = append(entries, export.TMXEntry{
+				ModID:      id,
+				Key:        t.Key,
+				SourceText: t.SourceText,
+				TargetText: *t.TargetText,
+				Status:     t.Status,
+			})
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("failed to get translations for %s: %w", id, err)
+		}
+	}
+
+	if dir := filepath.Dir(*outPath); dir != "" {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create output directory: %w", err)
+		}
+	}
+
+	exporter := export.NewExporter()
+	if err := exporter.ExportTMX(entries, *sourceLang, *targetLang, *outPath); err != nil {
+		return fmt.Errorf("failed to export TMX: %w", err)
+	}
+
+	fmt.Printf("Exported %d translations (%d units) to %s\n",
+		len(entries), len(export.DedupTMXEntries(entries)), *outPath)
+	return nil
+}
+
+func runTMImport(args []string) error {
+	fs := flag.NewFlagSet("tm import", flag.ExitOnError)
+
+	var (
+		dbPath     = fs.String("db", "moddict.db", "Database file path or postgres:// URL")
+		tmxPath    = fs.String("tmx", "", "TMX file to import (required)")
+		sourceLang = fs.String("source-lang", "en_us", "Source language")
+		targetLang = fs.String("lang", "ja_jp", "Target language")
+		origin     = fs.String("origin", "", "Name of the memory source (default: TMX file name)")
+		priority   = fs.Int("priority", memoryPriorityDefault, "Suggestion priority (higher is shown first)")
+		dryRun     = fs.Bool("dry-run", false, "Show what would be imported without changing the database")
+	)
+
+	fs.Usage = func() {
+		fmt.Print(`Usage: moddict tm import -tmx <file> [options]
+
+Import a TMX file as translation memory. Entries are stored as suggestions
+shown by "translate -pending"; existing translations are never overwritten.
+
+Options:
+`)
+		fs.PrintDefaults()
+		fmt.Print(`
+Examples:
+  moddict tm import -tmx omegat-project.tmx
+  moddict tm import -tmx community.tmx -origin community -priority 5
+`)
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *tmxPath == "" {
+		fs.Usage()
+		return fmt.Errorf("-tmx is required")
+	}
+
+	content, err := os.ReadFile(*tmxPath)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", *tmxPath, err)
+	}
+
+	units, err := export.ParseTMX(content, *sourceLang, *targetLang)
+	if err != nil {
+		return err
+	}
+
+	memoryOrigin := *origin
+	if memoryOrigin == "" {
+		memoryOrigin = strings.TrimSuffix(filepath.Base(*tmxPath), filepath.Ext(*tmxPath))
+	}
+
+	repo, err := database.NewRepository(*dbPath)
+	if err != nil {
+		return fmt.Errorf("failed to open database: %w", err)
+	}
+	defer repo.Close()
+
+	if err := repo.Migrate(); err != nil {
+		return fmt.Errorf("failed to migrate database: %w", err)
+	}
+
+	ctx := context.Background()
+
+	var entries []*models.MemoryEntry
+	for _, unit := range units {
+		entry := &models.MemoryEntry{
+			SourceText: unit.Source,
+			SourceLang: *sourceLang,
+			TargetText: unit.Target,
+			TargetLang: *targetLang,
+			Origin:     memoryOrigin,
+			Priority:   *priority,
+		}
+		if len(unit.Origins) > 0 {
+			entry.ModID = unit.Origins[0].ModID
+			entry.Key = unit.Origins[0].Key
+		}
+		entries = append(entries, entry)
+	}
+
+	return runInTx(ctx, repo, *dryRun, func(txRepo *database.Repository) error {
+		saved, err := txRepo.SaveMemoryEntries(ctx, entries)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Imported %d memory entries from %s (origin: %s)\n", saved, *tmxPath, memoryOrigin)
+		if skipped := len(entries) - saved; skipped > 0 {
+			fmt.Printf("  Skipped %d duplicates\n", skipped)
+		}
+
+		pending, err := txRepo.CountPendingWithMemory(ctx, *targetLang)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("  Pending translations with suggestions: %d\n", pending)
+
+		return nil
+	})
+}
@@ -3,11 +3,14 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/iuif/minecraft-mod-dictionary/internal/database"
+	"github.com/iuif/minecraft-mod-dictionary/internal/export"
 	"github.com/iuif/minecraft-mod-dictionary/pkg/interfaces"
 	"github.com/iuif/minecraft-mod-dictionary/pkg/models"
 	"gopkg.in/yaml.v3"
@@ -17,16 +20,22 @@ func runTranslate(args []string) error {
 	fs := flag.NewFlagSet("translate", flag.ExitOnError)
 
 	var (
-		dbPath     = fs.String("db", "moddict.db", "Database file path")
+		dbPath     = fs.String("db", "moddict.db", "Database file path or postgres:// URL")
 		modID      = fs.String("mod", "", "Mod ID (required)")
 		fromYAML   = fs.String("yaml", "", "Import translations from YAML file")
 		fromJSON   = fs.String("json", "", "Import translations from JSON file (ja_jp.json format)")
+		fromXLIFF  = fs.String("xliff", "", "Import translations from XLIFF 2.0 file (changed units only)")
+		fromPO     = fs.String("po", "", "Import translations from gettext PO file (changed entries only)")
+		fromCSV    = fs.String("csv", "", "Import translations from CSV file (key,source_text,target_text; mod_id column for combined CSVs)")
+		csvDir     = fs.String("csv-dir", "", "Import every per-mod CSV (<mod>.csv, <mod>_<lang>.csv) in a directory (no -mod needed)")
+		conflicts  = fs.String("conflicts", "", "Write CSV import conflicts to this CSV file")
 		official   = fs.String("official", "", "Import official translations from ja_jp.json (translator=official, status=verified)")
 		status     = fs.Bool("status", false, "Show translation status")
 		pending    = fs.Bool("pending", false, "List pending translations")
 		limit      = fs.Int("limit", 20, "Limit for listing")
 		offset     = fs.Int("offset", 0, "Offset for listing (pagination)")
 		exportJSON = fs.String("export", "", "Export pending to JSON file")
+		dryRun     = fs.Bool("dry-run", false, "Run an import and show the result without changing the database")
 	)
 
 	fs.Usage = func() {
@@ -34,6 +43,7 @@ func runTranslate(args []string) error {
 
 Add or update translations in the database.
 Translations are managed per-mod (not per-version) using the source_id schema.
+Each import runs in a single transaction: on any error nothing is saved.
 
 Options:
 `)
@@ -57,6 +67,24 @@ Examples:
 
   # Import from YAML
   moddict translate -mod bloodmagic -yaml ./translations.yaml
+
+  # Import an XLIFF 2.0 file edited in a CAT tool (from export -format xliff)
+  moddict translate -mod bloodmagic -xliff ./bloodmagic_ja_jp.xlf
+
+  # Import a PO file (from export -format po, or translated from the POT template)
+  moddict translate -mod bloodmagic -po ./bloodmagic_ja_jp.po
+
+  # Import an edited CSV (from export -format csv); conflicts are reported, not applied
+  moddict translate -mod bloodmagic -csv ./bloodmagic.csv
+
+  # Import a combined CSV (export -all); each row's mod comes from its mod_id column
+  moddict translate -csv data/translations/all_translations.csv
+
+  # Import all per-mod CSVs of a directory and save the conflicts for review
+  moddict translate -csv-dir data/translations -conflicts conflicts.csv
+
+  # Preview an import without saving it
+  moddict translate -mod bloodmagic -json ./translations_ja.json -dry-run
 `)
 	}
 
@@ -64,7 +92,7 @@ Examples:
 		return err
 	}
 
-	if *modID == "" {
+	if *modID == "" && *csvDir == "" && *fromCSV == "" {
 		fs.Usage()
 		return fmt.Errorf("mod ID is required")
 	}
@@ -77,6 +105,20 @@ Examples:
 
 	ctx := context.Background()
 
+	// Bulk CSV mode takes the mod from each file name
+	if *csvDir != "" {
+		return runInTx(ctx, repo, *dryRun, func(txRepo *database.Repository) error {
+			return importFromCSVDir(ctx, txRepo, *csvDir, *conflicts)
+		})
+	}
+
+	// A combined CSV (all_translations.csv) takes the mod from its mod_id column
+	if *fromCSV != "" && *modID == "" {
+		return runInTx(ctx, repo, *dryRun, func(txRepo *database.Repository) error {
+			return importFromCSV(ctx, txRepo, "", *fromCSV, *conflicts)
+		})
+	}
+
 	// Verify mod exists
 	if _, err := repo.GetMod(ctx, *modID); err != nil {
 		return fmt.Errorf("mod %s not found: %w", *modID, err)
@@ -97,19 +139,36 @@ Examples:
 		return listPending(ctx, repo, *modID, *offset, *limit)
 	}
 
-	// Import from JSON
-	if *fromJSON != "" {
-		return importFromJSON(ctx, repo, *modID, *fromJSON)
-	}
-
-	// Import from YAML
-	if *fromYAML != "" {
-		return importFromYAML(ctx, repo, *modID, *fromYAML)
+	// Import modes run in one transaction
+	var importFn func(txRepo *database.Repository) error
+	switch {
+	case *fromJSON != "":
+		importFn = func(txRepo *database.Repository) error {
+			return importFromJSON(ctx, txRepo, *modID, *fromJSON)
+		}
+	case *fromXLIFF != "":
+		importFn = func(txRepo *database.Repository) error {
+			return importFromXLIFF(ctx, txRepo, *modID, *fromXLIFF)
+		}
+	case *fromPO != "":
+		importFn = func(txRepo *database.Repository) error {
+			return importFromPO(ctx, txRepo, *modID, *fromPO)
+		}
+	case *fromCSV != "":
+		importFn = func(txRepo *database.Repository) error {
+			return importFromCSV(ctx, txRepo, *modID, *fromCSV, *conflicts)
+		}
+	case *fromYAML != "":
+		importFn = func(txRepo *database.Repository) error {
+			return importFromYAML(ctx, txRepo, *modID, *fromYAML)
+		}
+	case *official != "":
+		importFn = func(txRepo *database.Repository) error {
+			return importOfficialJSON(ctx, txRepo, *modID, *official)
+		}
 	}
-
-	// Import official translations
-	if *official != "" {
-		return importOfficialJSON(ctx, repo, *modID, *official)
+	if importFn != nil {
+		return runInTx(ctx, repo, *dryRun, importFn)
 	}
 
 	fs.Usage()
@@ -168,7 +227,16 @@ func listPending(ctx context.Context, repo *database.Repository, modID string, o
 			text = text[:60] + "..."
 		}
 		fmt.Printf("Key: %s\n", t.Key)
-		fmt.Printf("Source: %s\n\n", text)
+		fmt.Printf("Source: %s\n", text)
+
+		// Translation memory is optional; older databases have no memory table
+		suggestions, err := repo.FindMemorySuggestions(ctx, t.SourceText, t.TargetLang)
+		if err == nil {
+			for _, s := range suggestions {
+				fmt.Printf("Suggestion (%s): %s\n", s.Origin, s.TargetText)
+			}
+		}
+		fmt.Println()
 	}
 
 	return nil
@@ -216,150 +284,264 @@ func importFromJSON(ctx context.Context, repo *database.Repository, modID, jsonP
 		return fmt.Errorf("failed to parse JSON: %w", err)
 	}
 
-	// Update translations by looking up source by mod_id and key
-	var updated, notFound, skippedEmpty, skippedSameAsSource, propagated int
-	for key, target := range translations {
-		// Skip empty translations to prevent data corruption
-		if target == "" {
-			skippedEmpty++
-			continue
-		}
+	// Update translations by looking up source by mod_id and key, and auto-propagate them
+	// to other pending entries with identical source_text
+	stats, err := export.ApplyTranslations(ctx, repo, modID, translations, export.TranslationImportOptions{TargetLang: "ja_jp", Propagate: true})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Updated %d translations from %s\n", stats.Updated, jsonPath)
+	if stats.Propagated > 0 {
+		fmt.Printf("Auto-propagated to identical source_text: %d\n", stats.Propagated)
+	}
+	printTranslationImportSkips(stats)
+	return nil
+}
+
+// importFromXLIFF applies an XLIFF 2.0 file edited in a CAT tool.
+// Only units whose target, state or notes differ from the database are saved.
+// Units whose source text changed since the export are skipped.
+func importFromXLIFF(ctx context.Context, repo *database.Repository, modID, xliffPath string) error {
+	content, err := os.ReadFile(xliffPath)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", xliffPath, err)
+	}
+
+	doc, err := export.ParseXLIFF(content)
+	if err != nil {
+		return err
+	}
+	if doc.TargetLang != "" && doc.TargetLang != "ja_jp" {
+		return fmt.Errorf("unsupported target language: %s (only ja_jp is supported)", doc.TargetLang)
+	}
 
-		source, err := repo.GetSourceByModAndKey(ctx, modID, key)
+	var updated, unchanged, notFound, staleSource, skippedEmpty int
+	for _, unit := range doc.Units {
+		source, err := repo.GetSourceByModAndKey(ctx, modID, unit.Key)
 		if err != nil {
-			fmt.Printf("Warning: failed to get source for %s: %v\n", key, err)
-			continue
+			return fmt.Errorf("failed to get source for %s: %w", unit.Key, err)
 		}
 		if source == nil {
 			notFound++
 			continue
 		}
 
-		// Skip if target is same as source (untranslated)
-		if target == source.SourceText {
-			skippedSameAsSource++
+		// The source changed after the export; the unit was translated against old text
+		if unit.Source != source.SourceText {
+			staleSource++
 			continue
 		}
 
-		// Get translation by source_id
 		trans, err := repo.GetTranslationBySourceID(ctx, source.ID)
-		if err != nil {
-			fmt.Printf("Warning: translation not found for %s: %v\n", key, err)
+		if errors.Is(err, database.ErrTranslationNotFound) {
+			notFound++
 			continue
 		}
+		if err != nil {
+			return fmt.Errorf("failed to get translation for %s: %w", unit.Key, err)
+		}
 
-		trans.TargetText = &target
-		trans.Status = models.StatusTranslated
-		if err := repo.SaveTranslation(ctx, trans); err != nil {
-			fmt.Printf("Warning: failed to update %s: %v\n", key, err)
-			continue
+		changed, skipped := false, false
+
+		// Empty targets never clear an existing translation
+		if unit.Target != "" {
+			if trans.TargetText == nil || *trans.TargetText != unit.Target {
+				target := unit.Target
+				trans.TargetText = &target
+				changed = true
+			}
+			if trans.Status != unit.Status {
+				trans.Status = unit.Status
+				changed = true
+			}
+		} else if unit.Status != models.StatusPending {
+			skipped = true
 		}
-		updated++
 
-		// Auto-propagate: Apply the same translation to other pending entries with identical source_text
-		pendingSources, err := repo.GetPendingSourcesBySameText(ctx, modID, source.SourceText, source.ID)
-		if err != nil {
-			fmt.Printf("Warning: failed to get pending sources for propagation: %v\n", err)
-			continue
+		if unit.Notes != "" && (trans.Notes == nil || *trans.Notes != unit.Notes) {
+			notes := unit.Notes
+			trans.Notes = &notes
+			changed = true
 		}
 
-		for _, pendingSource := range pendingSources {
-			pendingTrans, err := repo.GetTranslationBySourceID(ctx, pendingSource.ID)
-			if err != nil {
-				continue
-			}
-			pendingTrans.TargetText = &target
-			pendingTrans.Status = models.StatusTranslated
-			if err := repo.SaveTranslation(ctx, pendingTrans); err != nil {
-				continue
+		if skipped {
+			skippedEmpty++
+		}
+		if !changed {
+			if !skipped {
+				unchanged++
 			}
-			propagated++
+			continue
 		}
-	}
 
-	fmt.Printf("Updated %d translations from %s\n", updated, jsonPath)
-	if propagated > 0 {
-		fmt.Printf("Auto-propagated to identical source_text: %d\n", propagated)
+		if err := repo.SaveTranslation(ctx, trans); err != nil {
+			return fmt.Errorf("failed to update %s: %w", unit.Key, err)
+		}
+		updated++
 	}
+
+	fmt.Printf("XLIFF import from %s\n", xliffPath)
+	fmt.Printf("========================================\n")
+	fmt.Printf("Units:                  %d\n", len(doc.Units))
+	fmt.Printf("Updated:                %d\n", updated)
+	fmt.Printf("Unchanged:              %d\n", unchanged)
 	if skippedEmpty > 0 {
-		fmt.Printf("Skipped empty translations: %d\n", skippedEmpty)
+		fmt.Printf("Skipped empty target:   %d\n", skippedEmpty)
 	}
-	if skippedSameAsSource > 0 {
-		fmt.Printf("Skipped same-as-source (untranslated): %d\n", skippedSameAsSource)
+	if staleSource > 0 {
+		fmt.Printf("Skipped (source changed since export): %d\n", staleSource)
 	}
 	if notFound > 0 {
-		fmt.Printf("Keys not found in DB: %d\n", notFound)
+		fmt.Printf("Keys not in DB:         %d\n", notFound)
 	}
+
 	return nil
 }
 
-func importFromYAML(ctx context.Context, repo *database.Repository, modID, yamlPath string) error {
-	content, err := os.ReadFile(yamlPath)
+// importFromPO applies a gettext PO file. msgctxt is the key; fuzzy entries are saved as
+// needs_review. Only entries whose msgstr, fuzzy flag or translator comments differ from
+// the database are saved, and entries whose msgid no longer matches the source are skipped.
+func importFromPO(ctx context.Context, repo *database.Repository, modID, poPath string) error {
+	content, err := os.ReadFile(poPath)
 	if err != nil {
-		return fmt.Errorf("failed to read %s: %w", yamlPath, err)
+		return fmt.Errorf("failed to read %s: %w", poPath, err)
 	}
 
-	// Parse YAML - support multiple formats
-	var data map[string]interface{}
-	if err := yaml.Unmarshal(content, &data); err != nil {
-		return fmt.Errorf("failed to parse YAML: %w", err)
+	entries, err := export.ParsePO(content)
+	if err != nil {
+		return fmt.Errorf("failed to parse PO: %w", err)
 	}
 
-	// Extract translations from YAML structure
-	translations := extractTranslationsFromYAML(data)
-
-	var updated, notFound, skippedEmpty, skippedSameAsSource int
-	for key, target := range translations {
-		// Skip empty translations to prevent data corruption
-		if target == "" {
+	var updated, unchanged, notFound, staleSource, skippedEmpty, noContext int
+	for _, entry := range entries {
+		if entry.Context == "" {
+			noContext++
+			continue
+		}
+		if entry.Str == "" {
 			skippedEmpty++
 			continue
 		}
 
-		source, err := repo.GetSourceByModAndKey(ctx, modID, key)
+		source, err := repo.GetSourceByModAndKey(ctx, modID, entry.Context)
 		if err != nil {
-			fmt.Printf("Warning: failed to get source for %s: %v\n", key, err)
-			continue
+			return fmt.Errorf("failed to get source for %s: %w", entry.Context, err)
 		}
 		if source == nil {
 			notFound++
 			continue
 		}
-
-		// Skip if target is same as source (untranslated)
-		if target == source.SourceText {
-			skippedSameAsSource++
+		if entry.ID != source.SourceText {
+			staleSource++
 			continue
 		}
 
 		trans, err := repo.GetTranslationBySourceID(ctx, source.ID)
+		if errors.Is(err, database.ErrTranslationNotFound) {
+			notFound++
+			continue
+		}
 		if err != nil {
-			fmt.Printf("Warning: translation not found for %s: %v\n", key, err)
+			return fmt.Errorf("failed to get translation for %s: %w", entry.Context, err)
+		}
+
+		changed := false
+		textChanged := trans.TargetText == nil || *trans.TargetText != entry.Str
+		if textChanged {
+			target := entry.Str
+			trans.TargetText = &target
+			changed = true
+		}
+
+		// Keep verified/official status for untouched entries; removing "fuzzy" marks them translated
+		status := trans.Status
+		switch {
+		case entry.Fuzzy():
+			status = models.StatusNeedsReview
+		case textChanged, status == models.StatusPending, status == models.StatusNeedsReview:
+			status = models.StatusTranslated
+		}
+		if status != trans.Status {
+			trans.Status = status
+			changed = true
+		}
+
+		if notes := strings.Join(entry.Comments, "\n"); notes != "" && (trans.Notes == nil || *trans.Notes != notes) {
+			trans.Notes = &notes
+			changed = true
+		}
+
+		if !changed {
+			unchanged++
 			continue
 		}
 
-		trans.TargetText = &target
-		trans.Status = models.StatusTranslated
 		if err := repo.SaveTranslation(ctx, trans); err != nil {
-			fmt.Printf("Warning: failed to update %s: %v\n", key, err)
-			continue
+			return fmt.Errorf("failed to update %s: %w", entry.Context, err)
 		}
 		updated++
 	}
 
-	fmt.Printf("Updated %d translations from %s\n", updated, yamlPath)
+	fmt.Printf("PO import from %s\n", poPath)
+	fmt.Printf("========================================\n")
+	fmt.Printf("Entries:                %d\n", len(entries))
+	fmt.Printf("Updated:                %d\n", updated)
+	fmt.Printf("Unchanged:              %d\n", unchanged)
 	if skippedEmpty > 0 {
-		fmt.Printf("Skipped empty translations: %d\n", skippedEmpty)
+		fmt.Printf("Skipped empty msgstr:   %d\n", skippedEmpty)
 	}
-	if skippedSameAsSource > 0 {
-		fmt.Printf("Skipped same-as-source (untranslated): %d\n", skippedSameAsSource)
+	if staleSource > 0 {
+		fmt.Printf("Skipped (source changed since export): %d\n", staleSource)
+	}
+	if noContext > 0 {
+		fmt.Printf("Skipped without msgctxt: %d\n", noContext)
 	}
 	if notFound > 0 {
-		fmt.Printf("Keys not found in DB: %d\n", notFound)
+		fmt.Printf("Keys not in DB:         %d\n", notFound)
+	}
+
+	return nil
+}
+
+func importFromYAML(ctx context.Context, repo *database.Repository, modID, yamlPath string) error {
+	content, err := os.ReadFile(yamlPath)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", yamlPath, err)
+	}
+
+	// Parse YAML - support multiple formats
+	var data map[string]interface{}
+	if err := yaml.Unmarshal(content, &data); err != nil {
+		return fmt.Errorf("failed to parse YAML: %w", err)
+	}
+
+	// Extract translations from YAML structure
+	translations := extractTranslationsFromYAML(data)
+
+	stats, err := export.ApplyTranslations(ctx, repo, modID, translations, export.TranslationImportOptions{TargetLang: "ja_jp"})
+	if err != nil {
+		return err
 	}
+
+	fmt.Printf("Updated %d translations from %s\n", stats.Updated, yamlPath)
+	printTranslationImportSkips(stats)
 	return nil
 }
 
+// printTranslationImportSkips prints the keys skipped by a JSON or YAML import.
+func printTranslationImportSkips(stats *export.TranslationImportStats) {
+	if stats.SkippedEmpty > 0 {
+		fmt.Printf("Skipped empty translations: %d\n", stats.SkippedEmpty)
+	}
+	if stats.SameAsSource > 0 {
+		fmt.Printf("Skipped same-as-source (untranslated): %d\n", stats.SameAsSource)
+	}
+	if stats.NotFound > 0 {
+		fmt.Printf("Keys not found in DB: %d\n", stats.NotFound)
+	}
+}
+
 func extractTranslationsFromYAML(data map[string]interface{}) map[string]string {
 	result := make(map[string]string)
 
@@ -438,65 +620,20 @@ func importOfficialJSON(ctx context.Context, repo *database.Repository, modID, j
 		return fmt.Errorf("failed to parse JSON: %w", err)
 	}
 
-	// Track statistics
-	var updated, alreadyOfficial, notFound, skippedEmpty, skippedSameAsSource int
-	officialStr := "official"
-
-	for key, target := range officialTranslations {
-		// Skip empty translations
-		if target == "" {
-			skippedEmpty++
-			continue
-		}
-
-		source, err := repo.GetSourceByModAndKey(ctx, modID, key)
-		if err != nil {
-			fmt.Printf("Warning: failed to get source for %s: %v\n", key, err)
-			continue
-		}
-		if source == nil {
-			notFound++
-			continue
-		}
-
-		// Skip if target is same as source (untranslated in official file)
-		if target == source.SourceText {
-			skippedSameAsSource++
-			continue
-		}
-
-		trans, err := repo.GetTranslationBySourceID(ctx, source.ID)
-		if err != nil {
-			fmt.Printf("Warning: translation not found for %s: %v\n", key, err)
-			continue
-		}
-
-		// Check if already marked as official
-		if trans.Translator != nil && *trans.Translator == "official" {
-			alreadyOfficial++
-			continue
-		}
-
-		// Update with official translation
-		trans.TargetText = &target
-		trans.Status = models.StatusVerified
-		trans.Translator = &officialStr
-		if err := repo.SaveTranslation(ctx, trans); err != nil {
-			fmt.Printf("Warning: failed to update %s: %v\n", key, err)
-			continue
-		}
-		updated++
+	stats, err := export.ApplyTranslations(ctx, repo, modID, officialTranslations, export.TranslationImportOptions{TargetLang: "ja_jp", Official: true})
+	if err != nil {
+		return err
 	}
 
 	// Print summary
 	fmt.Printf("Official translation import from %s\n", jsonPath)
 	fmt.Printf("========================================\n")
-	fmt.Printf("Total official keys:    %d\n", len(officialTranslations))
-	fmt.Printf("Updated to official:    %d\n", updated)
-	fmt.Printf("Already official:       %d\n", alreadyOfficial)
-	fmt.Printf("Skipped empty:          %d\n", skippedEmpty)
-	fmt.Printf("Skipped same-as-source: %d\n", skippedSameAsSource)
-	fmt.Printf("Keys not in DB:         %d\n", notFound)
+	fmt.Printf("Total official keys:    %d\n", stats.Keys)
+	fmt.Printf("Updated to official:    %d\n", stats.Updated)
+	fmt.Printf("Already official:       %d\n", stats.AlreadyOfficial)
+	fmt.Printf("Skipped empty:          %d\n", stats.SkippedEmpty)
+	fmt.Printf("Skipped same-as-source: %d\n", stats.SameAsSource)
+	fmt.Printf("Keys not in DB:         %d\n", stats.NotFound)
 
 	return nil
 }
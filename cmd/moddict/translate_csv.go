@@ -0,0 +1,193 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/iuif/minecraft-mod-dictionary/internal/database"
+	"github.com/iuif/minecraft-mod-dictionary/internal/export"
+)
+
+// csvLangSuffix matches the language suffix of per-mod CSV names ("create_ja_jp" -> "_ja_jp").
+var csvLangSuffix = regexp.MustCompile(`_[a-z]{2,3}_[a-z]{2,3}$`)
+
+// csvTargetLang is the language CSV imports apply to, like the other translate imports.
+const csvTargetLang = "ja_jp"
+
+// readTranslationCSV reads a CSV written by export -format csv.
+func readTranslationCSV(path string) ([]export.TranslationCSVRow, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	rows, err := export.ParseTranslationCSV(content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return rows, nil
+}
+
+// importFromCSV applies a translation CSV to a mod (see export.ApplyTranslationCSV).
+// modID may be empty if every row has a mod_id column, as in all_translations.csv.
+func importFromCSV(ctx context.Context, repo *database.Repository, modID, csvPath, conflictsPath string) error {
+	rows, err := readTranslationCSV(csvPath)
+	if err != nil {
+		return err
+	}
+	if modID == "" {
+		for _, row := range rows {
+			if row.ModID == "" {
+				return fmt.Errorf("%s line %d has no mod_id (use -mod)", csvPath, row.Line)
+			}
+		}
+	}
+
+	stats, err := export.ApplyTranslationCSV(ctx, repo, modID, csvTargetLang, csvPath, rows)
+	if err != nil {
+		return fmt.Errorf("failed to import %s: %w", csvPath, err)
+	}
+
+	fmt.Printf("CSV import from %s\n", csvPath)
+	fmt.Printf("========================================\n")
+	printCSVImportStats(stats)
+	return reportCSVConflicts(stats.Conflicts, conflictsPath)
+}
+
+// importFromCSVDir applies every per-mod CSV (<mod>.csv or <mod>_<lang>.csv) in a directory.
+func importFromCSVDir(ctx context.Context, repo *database.Repository, dir, conflictsPath string) error {
+	matches, err := filepath.Glob(filepath.Join(dir, "*.csv"))
+	if err != nil {
+		return fmt.Errorf("failed to list CSV files: %w", err)
+	}
+	sort.Strings(matches)
+
+	total := &export.CSVImportStats{}
+	var files, unknownFiles int
+	for _, path := range matches {
+		modID, ok := csvFileModID(ctx, repo, path)
+		if !ok {
+			unknownFiles++
+			fmt.Printf("  %-40s skipped (no mod %q in DB)\n", filepath.Base(path), modID)
+			continue
+		}
+
+		rows, err := readTranslationCSV(path)
+		if err != nil {
+			return err
+		}
+		stats, err := export.ApplyTranslationCSV(ctx, repo, modID, csvTargetLang, path, rows)
+		if err != nil {
+			return fmt.Errorf("failed to import %s: %w", path, err)
+		}
+		files++
+		fmt.Printf("  %-40s %d updated, %d conflicts\n", filepath.Base(path), stats.Updated, len(stats.Conflicts))
+		total.Add(stats)
+	}
+
+	fmt.Printf("\nCSV import from %s\n", dir)
+	fmt.Printf("========================================\n")
+	fmt.Printf("Files:                  %d\n", files)
+	if unknownFiles > 0 {
+		fmt.Printf("Files for unknown mods: %d\n", unknownFiles)
+	}
+	printCSVImportStats(total)
+	return reportCSVConflicts(total.Conflicts, conflictsPath)
+}
+
+// csvFileModID derives the mod of a per-mod CSV from its file name. The language suffix of
+// "<mod>_<lang>.csv" is only stripped if the full name is not a mod itself.
+func csvFileModID(ctx context.Context, repo *database.Repository, path string) (string, bool) {
+	name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	if _, err := repo.GetMod(ctx, name); err == nil {
+		return name, true
+	}
+
+	stripped := csvLangSuffix.ReplaceAllString(name, "")
+	if stripped != name {
+		if _, err := repo.GetMod(ctx, stripped); err == nil {
+			return stripped, true
+		}
+	}
+	return stripped, false
+}
+
+func printCSVImportStats(stats *export.CSVImportStats) {
+	fmt.Printf("Rows:                   %d\n", stats.Rows)
+	fmt.Printf("Updated:                %d\n", stats.Updated)
+	fmt.Printf("Unchanged:              %d\n", stats.Unchanged)
+	if stats.SkippedEmpty > 0 {
+		fmt.Printf("Skipped empty target:   %d\n", stats.SkippedEmpty)
+	}
+	if stats.SameAsSource > 0 {
+		fmt.Printf("Skipped same as source: %d\n", stats.SameAsSource)
+	}
+	if stats.NotFound > 0 {
+		fmt.Printf("Keys not in DB:         %d\n", stats.NotFound)
+	}
+	if stats.UnknownMod > 0 {
+		fmt.Printf("Rows for unknown mods:  %d\n", stats.UnknownMod)
+	}
+	fmt.Printf("Conflicts:              %d\n", len(stats.Conflicts))
+}
+
+// reportCSVConflicts prints conflicts and, if path is set, writes them to a CSV for review.
+func reportCSVConflicts(conflicts []export.CSVConflict, path string) error {
+	if len(conflicts) == 0 {
+		return nil
+	}
+
+	const maxShown = 20
+	fmt.Printf("\nConflicts (not applied):\n")
+	for i, c := range conflicts {
+		if i == maxShown {
+			fmt.Printf("  ... and %d more\n", len(conflicts)-maxShown)
+			break
+		}
+		switch c.Reason {
+		case export.CSVConflictSourceChanged:
+			fmt.Printf("  %s:%d %s/%s: source changed\n    CSV:     %q\n    Current: %q\n",
+				filepath.Base(c.File), c.Line, c.ModID, c.Key, c.CSVSource, c.CurrentSource)
+		case export.CSVConflictProtected:
+			fmt.Printf("  %s:%d %s/%s: would overwrite a verified translation\n    CSV:     %q\n    Current: %q\n",
+				filepath.Base(c.File), c.Line, c.ModID, c.Key, c.CSVTarget, c.CurrentTarget)
+		}
+	}
+
+	if path == "" {
+		return nil
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer file.Close()
+
+	// Write UTF-8 BOM for Excel compatibility
+	file.Write([]byte{0xEF, 0xBB, 0xBF})
+
+	writer := csv.NewWriter(file)
+	header := []string{"file", "line", "mod", "key", "reason", "csv_source", "current_source", "csv_target", "current_target"}
+	if err := writer.Write(header); err != nil {
+		return fmt.Errorf("failed to write header: %w", err)
+	}
+	for _, c := range conflicts {
+		row := []string{c.File, fmt.Sprint(c.Line), c.ModID, c.Key, c.Reason, c.CSVSource, c.CurrentSource, c.CSVTarget, c.CurrentTarget}
+		if err := writer.Write(row); err != nil {
+			return fmt.Errorf("failed to write row: %w", err)
+		}
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return fmt.Errorf("failed to flush writer: %w", err)
+	}
+
+	fmt.Printf("\nConflict report: %s\n", path)
+	return nil
+}
@@ -13,7 +13,7 @@ func runView(args []string) error {
 	fs := flag.NewFlagSet("view", flag.ExitOnError)
 
 	var (
-		dbPath     = fs.String("db", "moddict.db", "Database file path")
+		dbPath     = fs.String("db", "moddict.db", "Database file path or postgres:// URL")
 		modID      = fs.String("mod", "", "Mod ID to filter (optional, shows all mods if not specified)")
 		status     = fs.String("status", "", "Filter by status (pending, translated, verified, inherited, needs_review)")
 		search     = fs.String("search", "", "Search in source_text or target_text")
@@ -23,6 +23,7 @@ func runView(args []string) error {
 		compact    = fs.Bool("compact", false, "Compact view (key and target only)")
 		_          = fs.Bool("recent", false, "Order by most recently updated")
 		id         = fs.Int64("id", 0, "Show specific translation by ID")
+		archived   = fs.Bool("archived", false, "Include translations of archived mods and versions")
 	)
 
 	fs.Usage = func() {
@@ -62,9 +63,9 @@ Examples:
 		return err
 	}
 
-	repo, err := database.NewRepository(*dbPath)
+	repo, err := openReadOnlyRepository(*dbPath)
 	if err != nil {
-		return fmt.Errorf("failed to open database: %w", err)
+		return err
 	}
 	defer repo.Close()
 
@@ -76,7 +77,7 @@ Examples:
 	}
 
 	// View with filters
-	return viewTranslations(ctx, repo, *modID, *status, *search, *offset, *limit, *compact)
+	return viewTranslations(ctx, repo, *modID, *status, *search, *offset, *limit, *compact, *archived)
 }
 
 func viewByID(ctx context.Context, repo *database.Repository, id int64) error {
@@ -134,7 +135,18 @@ func viewByID(ctx context.Context, repo *database.Repository, id int64) error {
 	return nil
 }
 
-func viewTranslations(ctx context.Context, repo *database.Repository, modID, status, search string, offset, limit int, compact bool) error {
+// notArchivedTranslation skips translations of archived mods and of sources that are only
+// linked to archived versions.
+const notArchivedTranslation = `translation_sources.mod_id NOT IN (SELECT id FROM mods WHERE archived_at IS NOT NULL)
+	AND (EXISTS (
+		SELECT 1 FROM source_versions sv
+		JOIN mod_versions mv ON mv.id = sv.mod_version_id
+		WHERE sv.source_id = translation_sources.id AND mv.archived_at IS NULL
+	) OR NOT EXISTS (
+		SELECT 1 FROM source_versions sv WHERE sv.source_id = translation_sources.id
+	))`
+
+func viewTranslations(ctx context.Context, repo *database.Repository, modID, status, search string, offset, limit int, compact, archived bool) error {
 	// Build query
 	query := repo.DB().WithContext(ctx).
 		Table("translations").
@@ -152,13 +164,16 @@ func viewTranslations(ctx context.Context, repo *database.Repository, modID, sta
 	if modID != "" {
 		query = query.Where("translation_sources.mod_id = ?", modID)
 	}
+	if !archived {
+		query = query.Where(notArchivedTranslation)
+	}
 	if status != "" {
 		query = query.Where("translations.status = ?", status)
 	}
 	if search != "" {
 		searchPattern := "%" + search + "%"
 		query = query.Where(
-			"translation_sources.source_text LIKE ? OR translations.target_text LIKE ?",
+			"LOWER(translation_sources.source_text) LIKE LOWER(?) OR LOWER(translations.target_text) LIKE LOWER(?)",
 			searchPattern, searchPattern,
 		)
 	}
@@ -172,13 +187,16 @@ func viewTranslations(ctx context.Context, repo *database.Repository, modID, sta
 	if modID != "" {
 		countQuery = countQuery.Where("translation_sources.mod_id = ?", modID)
 	}
+	if !archived {
+		countQuery = countQuery.Where(notArchivedTranslation)
+	}
 	if status != "" {
 		countQuery = countQuery.Where("translations.status = ?", status)
 	}
 	if search != "" {
 		searchPattern := "%" + search + "%"
 		countQuery = countQuery.Where(
-			"translation_sources.source_text LIKE ? OR translations.target_text LIKE ?",
+			"LOWER(translation_sources.source_text) LIKE LOWER(?) OR LOWER(translations.target_text) LIKE LOWER(?)",
 			searchPattern, searchPattern,
 		)
 	}
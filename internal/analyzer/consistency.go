@@ -10,47 +10,42 @@ import (
 )
 
 // findConsistencyIssues finds cases where the same source text has different translations.
+// Mods are analyzed one at a time, so memory is bounded by the largest mod.
 func (a *Analyzer) findConsistencyIssues(ctx context.Context, modID string) ([]ConsistencyIssue, int, error) {
-	db := a.repo.GetDB()
-
-	// Build the base query for getting all translation pairs
-	type TranslationPair struct {
-		ModID      string
-		Key        string
-		SourceText string
-		TargetText string
-		Status     string
+	modIDs := []string{modID}
+	if modID == "" {
+		mods, err := a.repo.ListMods(ctx, interfaces.ModFilter{})
+		if err != nil {
+			return nil, 0, err
+		}
+		modIDs = modIDs[:0]
+		for _, mod := range mods {
+			modIDs = append(modIDs, mod.ID)
+		}
 	}
 
-	var pairs []TranslationPair
+	var issues []ConsistencyIssue
 	var totalCount int
-
-	query := db.Table("translations").
-		Select(`
-			translation_sources.mod_id,
-			translation_sources.key,
-			translation_sources.source_text,
-			translations.target_text,
-			translations.status
-		`).
-		Joins("JOIN translation_sources ON translation_sources.id = translations.source_id").
-		Joins("JOIN source_versions ON source_versions.source_id = translation_sources.id").
-		Joins("JOIN mod_versions ON mod_versions.id = source_versions.mod_version_id").
-		Where("mod_versions.is_default = ?", true).
-		Where("translations.status IN ?", []string{"translated", "verified", "official"}).
-		Where("translations.target_text IS NOT NULL").
-		Where("translations.target_text != ''")
-
-	if modID != "" {
-		query = query.Where("translation_sources.mod_id = ?", modID)
+	for _, id := range modIDs {
+		modIssues, count, err := a.findModConsistencyIssues(ctx, id)
+		if err != nil {
+			return nil, 0, err
+		}
+		issues = append(issues, modIssues...)
+		totalCount += count
 	}
 
-	if err := query.Scan(&pairs).Error; err != nil {
-		return nil, 0, err
-	}
+	// Sort issues by number of affected keys (descending)
+	sort.Slice(issues, func(i, j int) bool {
+		return len(issues[i].Keys) > len(issues[j].Keys)
+	})
 
-	totalCount = len(pairs)
+	return issues, totalCount, nil
+}
 
+// findModConsistencyIssues finds the consistency issues of one mod and returns them with
+// the number of translations checked.
+func (a *Analyzer) findModConsistencyIssues(ctx context.Context, modID string) ([]ConsistencyIssue, int, error) {
 	// Group by (mod_id, source_text) and find inconsistencies
 	type groupKey struct {
 		modID      string
@@ -64,8 +59,10 @@ func (a *Analyzer) findConsistencyIssues(ctx context.Context, modID string) ([]C
 	}
 
 	groups := make(map[groupKey]*groupData)
+	var totalCount int
 
-	for _, pair := range pairs {
+	err := a.eachTranslationPair(ctx, modID, func(pair SourceTranslationPair) error {
+		totalCount++
 		key := groupKey{modID: pair.ModID, sourceText: pair.SourceText}
 		if groups[key] == nil {
 			groups[key] = &groupData{
@@ -80,6 +77,10 @@ func (a *Analyzer) findConsistencyIssues(ctx context.Context, modID string) ([]C
 		groups[key].translations[pair.TargetText].count++
 		groups[key].keys = append(groups[key].keys, pair.Key)
 		groups[key].statuses[pair.Status] = true
+		return nil
+	})
+	if err != nil {
+		return nil, 0, err
 	}
 
 	// Find groups with multiple different translations
@@ -162,11 +163,6 @@ func (a *Analyzer) findConsistencyIssues(ctx context.Context, modID string) ([]C
 		issues = append(issues, issue)
 	}
 
-	// Sort issues by number of affected keys (descending)
-	sort.Slice(issues, func(i, j int) bool {
-		return len(issues[i].Keys) > len(issues[j].Keys)
-	})
-
 	return issues, totalCount, nil
 }
 
@@ -7,6 +7,7 @@ import (
 	"unicode"
 
 	"github.com/iuif/minecraft-mod-dictionary/pkg/interfaces"
+	"github.com/iuif/minecraft-mod-dictionary/pkg/models"
 )
 
 // phraseData holds information about a discovered phrase.
@@ -18,16 +19,10 @@ type phraseData struct {
 
 // minePhrases discovers phrase patterns through N-gram mining.
 func (a *Analyzer) minePhrases(ctx context.Context, modID string, minCount int) ([]PhraseIssue, error) {
-	// Get all translation pairs
-	pairs, err := a.getAllTranslationPairs(ctx, modID)
-	if err != nil {
-		return nil, err
-	}
-
 	// Extract N-grams from source texts and build phrase-to-translations mapping
 	phraseMap := make(map[string]*phraseData)
 
-	for _, pair := range pairs {
+	err := a.eachTranslationPair(ctx, modID, func(pair SourceTranslationPair) error {
 		// Extract N-grams (1 to 4 words)
 		ngrams := extractNgrams(pair.SourceText, 1, 4)
 
@@ -55,6 +50,10 @@ func (a *Analyzer) minePhrases(ctx context.Context, modID string, minCount int)
 				}
 			}
 		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	// Get terms for dictionary lookup
@@ -145,37 +144,26 @@ func (a *Analyzer) minePhrases(ctx context.Context, modID string, minCount int)
 	return issues, nil
 }
 
-// getAllTranslationPairs retrieves all translation pairs from the database.
-func (a *Analyzer) getAllTranslationPairs(ctx context.Context, modID string) ([]SourceTranslationPair, error) {
-	db := a.repo.GetDB()
-
-	var pairs []SourceTranslationPair
-
-	query := db.Table("translations").
-		Select(`
-			translation_sources.mod_id,
-			translation_sources.key,
-			translation_sources.source_text,
-			translations.target_text,
-			translations.status
-		`).
-		Joins("JOIN translation_sources ON translation_sources.id = translations.source_id").
-		Joins("JOIN source_versions ON source_versions.source_id = translation_sources.id").
-		Joins("JOIN mod_versions ON mod_versions.id = source_versions.mod_version_id").
-		Where("mod_versions.is_default = ?", true).
-		Where("translations.status IN ?", []string{"translated", "verified", "official"}).
-		Where("translations.target_text IS NOT NULL").
-		Where("translations.target_text != ''")
-
-	if modID != "" {
-		query = query.Where("translation_sources.mod_id = ?", modID)
-	}
+// analyzedStatuses are the translation statuses compared by the analyses.
+var analyzedStatuses = []string{models.StatusTranslated, models.StatusVerified, models.StatusOfficial}
 
-	if err := query.Scan(&pairs).Error; err != nil {
-		return nil, err
+// eachTranslationPair calls fn for each translated pair of the default versions (of one mod,
+// or all mods if modID is empty). Rows are read page by page instead of all at once.
+func (a *Analyzer) eachTranslationPair(ctx context.Context, modID string, fn func(SourceTranslationPair) error) error {
+	filter := interfaces.TranslationIterFilter{
+		ModID:          modID,
+		Statuses:       analyzedStatuses,
+		TranslatedOnly: true,
 	}
-
-	return pairs, nil
+	return a.repo.IterateTranslations(ctx, filter, func(t *models.TranslationWithSource) error {
+		return fn(SourceTranslationPair{
+			ModID:      t.ModID,
+			Key:        t.Key,
+			SourceText: t.SourceText,
+			TargetText: *t.TargetText,
+			Status:     t.Status,
+		})
+	})
 }
 
 // extractNgrams extracts word N-grams from text.
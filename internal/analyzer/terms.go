@@ -21,16 +21,10 @@ func (a *Analyzer) checkTermCompliance(ctx context.Context, modID string) ([]Ter
 		return nil, nil
 	}
 
-	// Get all translation pairs
-	pairs, err := a.getAllTranslationPairs(ctx, modID)
-	if err != nil {
-		return nil, err
-	}
-
 	// Check each translation against terms
 	violationMap := make(map[string]*TermViolation)
 
-	for _, pair := range pairs {
+	err = a.eachTranslationPair(ctx, modID, func(pair SourceTranslationPair) error {
 		for _, term := range terms {
 			// Check if source_text contains the term
 			if !containsWord(pair.SourceText, term.SourceText) {
@@ -62,6 +56,10 @@ func (a *Analyzer) checkTermCompliance(ctx context.Context, modID string) ([]Ter
 			})
 			violationMap[key].ViolationCount++
 		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	// Convert to list and sort by violation count
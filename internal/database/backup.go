@@ -0,0 +1,241 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	sqlite3 "github.com/mattn/go-sqlite3"
+)
+
+// ErrBackupUnsupported is returned by Backup and Restore on PostgreSQL, which is backed up
+// with pg_dump instead.
+var ErrBackupUnsupported = errors.New("backup is only supported for SQLite databases (use pg_dump for PostgreSQL)")
+
+// snapshotTimeFormat sorts lexicographically in creation order.
+const snapshotTimeFormat = "20060102-150405.000"
+
+// Snapshot is a timestamped backup of a database file, created by SnapshotPath + Backup.
+type Snapshot struct {
+	Path      string
+	Label     string // command that triggered the snapshot, empty for manual backups
+	CreatedAt time.Time
+	Size      int64
+}
+
+// Backup copies the database to destPath with the SQLite online backup API. The copy is a
+// consistent snapshot even while other connections are writing. destPath is replaced
+// atomically, so an interrupted backup never leaves a truncated file behind.
+func (r *Repository) Backup(ctx context.Context, destPath string) error {
+	if r.Dialect() != DialectSQLite {
+		return ErrBackupUnsupported
+	}
+
+	tmp := destPath + ".tmp"
+	os.Remove(tmp)
+
+	destDB, err := sql.Open("sqlite3", tmp)
+	if err != nil {
+		return fmt.Errorf("failed to create backup file: %w", err)
+	}
+	err = r.copyDatabase(ctx, destDB, true)
+	destDB.Close()
+	if err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("failed to back up database: %w", err)
+	}
+
+	if err := os.Rename(tmp, destPath); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("failed to move backup to %s: %w", destPath, err)
+	}
+	return nil
+}
+
+// Restore replaces the contents of the database with the backup at srcPath. The backup is
+// checked with PRAGMA quick_check first, so a damaged file does not overwrite the database.
+func (r *Repository) Restore(ctx context.Context, srcPath string) error {
+	if r.Dialect() != DialectSQLite {
+		return ErrBackupUnsupported
+	}
+	if _, err := os.Stat(srcPath); err != nil {
+		return fmt.Errorf("backup not found: %w", err)
+	}
+
+	srcDB, err := sql.Open("sqlite3", srcPath+"?_query_only=true")
+	if err != nil {
+		return fmt.Errorf("failed to open backup: %w", err)
+	}
+	defer srcDB.Close()
+
+	var check string
+	if err := srcDB.QueryRowContext(ctx, "PRAGMA quick_check").Scan(&check); err != nil {
+		return fmt.Errorf("failed to check backup %s: %w", srcPath, err)
+	}
+	if check != "ok" {
+		return fmt.Errorf("backup %s is damaged: %s", srcPath, check)
+	}
+
+	if err := r.copyDatabase(ctx, srcDB, false); err != nil {
+		return fmt.Errorf("failed to restore database: %w", err)
+	}
+	return nil
+}
+
+// copyDatabase copies all pages from the repository to other (toOther) or the reverse.
+func (r *Repository) copyDatabase(ctx context.Context, other *sql.DB, toOther bool) error {
+	sqlDB, err := r.db.DB()
+	if err != nil {
+		return fmt.Errorf("failed to get sql.DB: %w", err)
+	}
+	repoConn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer repoConn.Close()
+	otherConn, err := other.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer otherConn.Close()
+
+	dest, src := otherConn, repoConn
+	if !toOther {
+		dest, src = repoConn, otherConn
+	}
+
+	return dest.Raw(func(destDriver any) error {
+		return src.Raw(func(srcDriver any) error {
+			destSQLite, ok := destDriver.(*sqlite3.SQLiteConn)
+			if !ok {
+				return fmt.Errorf("unexpected driver connection %T", destDriver)
+			}
+			srcSQLite, ok := srcDriver.(*sqlite3.SQLiteConn)
+			if !ok {
+				return fmt.Errorf("unexpected driver connection %T", srcDriver)
+			}
+
+			backup, err := destSQLite.Backup("main", srcSQLite, "main")
+			if err != nil {
+				return err
+			}
+			// All pages in one step: the source is read in a single transaction
+			if _, err := backup.Step(-1); err != nil {
+				backup.Finish()
+				return err
+			}
+			return backup.Finish()
+		})
+	})
+}
+
+// SnapshotDir returns the directory holding the snapshots of a SQLite database file.
+func SnapshotDir(dbPath string) string {
+	return filepath.Join(filepath.Dir(sqlitePath(dbPath)), "snapshots")
+}
+
+// SnapshotPath returns the file name for a snapshot of dbPath taken at t, e.g.
+// snapshots/moddict-20251228-153000.000-repair.db.
+func SnapshotPath(dbPath, label string, t time.Time) string {
+	name := snapshotPrefix(dbPath) + t.Format(snapshotTimeFormat)
+	if label = sanitizeLabel(label); label != "" {
+		name += "-" + label
+	}
+	return filepath.Join(SnapshotDir(dbPath), name+".db")
+}
+
+// ListSnapshots returns the snapshots of dbPath, oldest first.
+func ListSnapshots(dbPath string) ([]Snapshot, error) {
+	dir := SnapshotDir(dbPath)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read snapshot directory: %w", err)
+	}
+
+	prefix := snapshotPrefix(dbPath)
+	var snapshots []Snapshot
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, prefix) || !strings.HasSuffix(name, ".db") {
+			continue
+		}
+		rest := strings.TrimSuffix(strings.TrimPrefix(name, prefix), ".db")
+		if len(rest) < len(snapshotTimeFormat) {
+			continue
+		}
+		createdAt, err := time.ParseInLocation(snapshotTimeFormat, rest[:len(snapshotTimeFormat)], time.Local)
+		if err != nil {
+			continue
+		}
+
+		snapshot := Snapshot{
+			Path:      filepath.Join(dir, name),
+			Label:     strings.TrimPrefix(rest[len(snapshotTimeFormat):], "-"),
+			CreatedAt: createdAt,
+		}
+		if info, err := entry.Info(); err == nil {
+			snapshot.Size = info.Size()
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+
+	sort.Slice(snapshots, func(i, j int) bool { return snapshots[i].Path < snapshots[j].Path })
+	return snapshots, nil
+}
+
+// PruneSnapshots deletes all but the newest keep snapshots of dbPath and returns the
+// deleted ones. keep <= 0 keeps everything. Snapshots at a path in protect (e.g. the
+// file being restored) are never deleted.
+func PruneSnapshots(dbPath string, keep int, protect ...string) ([]Snapshot, error) {
+	snapshots, err := ListSnapshots(dbPath)
+	if err != nil || keep <= 0 || len(snapshots) <= keep {
+		return nil, err
+	}
+
+	protected := make(map[string]bool, len(protect))
+	for _, path := range protect {
+		protected[cleanSnapshotPath(path)] = true
+	}
+
+	var pruned []Snapshot
+	for _, snapshot := range snapshots[:len(snapshots)-keep] {
+		if protected[cleanSnapshotPath(snapshot.Path)] {
+			continue
+		}
+		if err := os.Remove(snapshot.Path); err != nil {
+			return nil, fmt.Errorf("failed to delete snapshot %s: %w", snapshot.Path, err)
+		}
+		pruned = append(pruned, snapshot)
+	}
+	return pruned, nil
+}
+
+// cleanSnapshotPath returns path in a form that compares equal for the same file.
+func cleanSnapshotPath(path string) string {
+	if abs, err := filepath.Abs(path); err == nil {
+		return abs
+	}
+	return filepath.Clean(path)
+}
+
+// snapshotPrefix is the database file name without extension, followed by a dash.
+func snapshotPrefix(dbPath string) string {
+	base := filepath.Base(sqlitePath(dbPath))
+	return strings.TrimSuffix(base, filepath.Ext(base)) + "-"
+}
+
+var labelInvalidChars = regexp.MustCompile(`[^a-z0-9_]+`)
+
+func sanitizeLabel(label string) string {
+	return strings.Trim(labelInvalidChars.ReplaceAllString(strings.ToLower(label), "_"), "_")
+}
@@ -0,0 +1,128 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/iuif/minecraft-mod-dictionary/pkg/models"
+)
+
+func TestRepository_BackupAndRestore(t *testing.T) {
+	repo := setupTestRepository(t)
+	ctx := context.Background()
+
+	if repo.Dialect() != DialectSQLite {
+		if err := repo.Backup(ctx, filepath.Join(t.TempDir(), "backup.db")); !errors.Is(err, ErrBackupUnsupported) {
+			t.Errorf("Backup() error = %v, want ErrBackupUnsupported", err)
+		}
+		return
+	}
+
+	repo.SaveMod(ctx, &models.Mod{ID: "create", DisplayName: "Create"})
+
+	backupPath := filepath.Join(t.TempDir(), "backup.db")
+	if err := repo.Backup(ctx, backupPath); err != nil {
+		t.Fatalf("Backup() error = %v", err)
+	}
+	if _, err := os.Stat(backupPath + ".tmp"); !os.IsNotExist(err) {
+		t.Error("Backup() left its temporary file behind")
+	}
+
+	// Changes after the backup are undone by Restore
+	repo.SaveMod(ctx, &models.Mod{ID: "botania", DisplayName: "Botania"})
+	if err := repo.DeleteMod(ctx, "create"); err != nil {
+		t.Fatalf("DeleteMod() error = %v", err)
+	}
+
+	if err := repo.Restore(ctx, backupPath); err != nil {
+		t.Fatalf("Restore() error = %v", err)
+	}
+	if _, err := repo.GetMod(ctx, "create"); err != nil {
+		t.Errorf("GetMod(create) after Restore() error = %v", err)
+	}
+	if _, err := repo.GetMod(ctx, "botania"); !errors.Is(err, ErrModNotFound) {
+		t.Errorf("GetMod(botania) after Restore() error = %v, want ErrModNotFound", err)
+	}
+
+	t.Run("damaged backup", func(t *testing.T) {
+		damaged := filepath.Join(t.TempDir(), "damaged.db")
+		if err := os.WriteFile(damaged, []byte("not a database"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := repo.Restore(ctx, damaged); err == nil {
+			t.Error("Restore() of a damaged file expected error")
+		}
+		if _, err := repo.GetMod(ctx, "create"); err != nil {
+			t.Errorf("GetMod() after failed Restore() error = %v", err)
+		}
+	})
+}
+
+func TestSnapshots(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "moddict.db")
+	base := time.Date(2025, 12, 28, 15, 30, 0, 0, time.Local)
+
+	if err := os.MkdirAll(SnapshotDir(dbPath), 0755); err != nil {
+		t.Fatal(err)
+	}
+	labels := []string{"", "repair", "build-clean", "Pre Restore"}
+	for i, label := range labels {
+		path := SnapshotPath(dbPath, label, base.Add(time.Duration(i)*time.Minute))
+		if err := os.WriteFile(path, nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Snapshots of another database in the same directory are ignored
+	other := SnapshotPath(filepath.Join(filepath.Dir(dbPath), "moddict-old.db"), "", base)
+	if err := os.WriteFile(other, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	snapshots, err := ListSnapshots(dbPath)
+	if err != nil {
+		t.Fatalf("ListSnapshots() error = %v", err)
+	}
+	wantLabels := []string{"", "repair", "build_clean", "pre_restore"}
+	if len(snapshots) != len(wantLabels) {
+		t.Fatalf("ListSnapshots() got %d, want %d", len(snapshots), len(wantLabels))
+	}
+	for i, s := range snapshots {
+		if s.Label != wantLabels[i] {
+			t.Errorf("snapshot %d label = %q, want %q", i, s.Label, wantLabels[i])
+		}
+		if want := base.Add(time.Duration(i) * time.Minute); !s.CreatedAt.Equal(want) {
+			t.Errorf("snapshot %d CreatedAt = %v, want %v", i, s.CreatedAt, want)
+		}
+	}
+
+	// A protected snapshot (the file being restored) survives pruning
+	pruned, err := PruneSnapshots(dbPath, 2, snapshots[0].Path)
+	if err != nil {
+		t.Fatalf("PruneSnapshots() error = %v", err)
+	}
+	if len(pruned) != 1 || pruned[0].Path != snapshots[1].Path {
+		t.Errorf("PruneSnapshots() deleted %v, want only the unprotected oldest", pruned)
+	}
+	if _, err := os.Stat(snapshots[0].Path); err != nil {
+		t.Errorf("PruneSnapshots() deleted a protected snapshot: %v", err)
+	}
+
+	pruned, err = PruneSnapshots(dbPath, 2)
+	if err != nil {
+		t.Fatalf("PruneSnapshots() error = %v", err)
+	}
+	if len(pruned) != 1 || pruned[0].Path != snapshots[0].Path {
+		t.Errorf("PruneSnapshots() deleted %v, want the oldest", pruned)
+	}
+	remaining, _ := ListSnapshots(dbPath)
+	if len(remaining) != 2 {
+		t.Errorf("ListSnapshots() after prune got %d, want 2", len(remaining))
+	}
+	if _, err := os.Stat(other); err != nil {
+		t.Errorf("PruneSnapshots() deleted a snapshot of another database: %v", err)
+	}
+}
@@ -0,0 +1,103 @@
+package database
+
+import (
+	"testing"
+	"time"
+
+	"github.com/iuif/minecraft-mod-dictionary/pkg/models"
+)
+
+// The models below are the definitions of the changed tables as of the last release that
+// created its schema with AutoMigrate, before schema_migrations existed. Unchanged
+// tables use the current models.
+
+type baselineMod struct {
+	ID          string `gorm:"primaryKey"`
+	DisplayName string
+	Author      string
+	Description string
+	Tags        []string          `gorm:"serializer:json"`
+	Metadata    map[string]string `gorm:"serializer:json"`
+	CreatedAt   time.Time         `gorm:"autoCreateTime"`
+	UpdatedAt   time.Time         `gorm:"autoUpdateTime"`
+}
+
+func (baselineMod) TableName() string { return "mods" }
+
+type baselineModVersion struct {
+	ID              int64  `gorm:"primaryKey;autoIncrement"`
+	ModID           string `gorm:"index;not null"`
+	Version         string `gorm:"not null"`
+	MCVersion       string `gorm:"not null"`
+	Loader          string
+	IsDefault       bool `gorm:"default:false"`
+	ParentVersionID *int64
+	Stats           models.VersionStats `gorm:"embedded"`
+	Metadata        map[string]string   `gorm:"serializer:json"`
+	CreatedAt       time.Time           `gorm:"autoCreateTime"`
+	UpdatedAt       time.Time           `gorm:"autoUpdateTime"`
+}
+
+func (baselineModVersion) TableName() string { return "mod_versions" }
+
+type baselineTranslationSource struct {
+	ID         int64     `gorm:"primaryKey;autoIncrement"`
+	ModID      string    `gorm:"index;not null"`
+	Key        string    `gorm:"not null"`
+	SourceText string    `gorm:"not null"`
+	SourceLang string    `gorm:"default:en_us"`
+	IsCurrent  bool      `gorm:"default:true;index"`
+	CreatedAt  time.Time `gorm:"autoCreateTime"`
+	UpdatedAt  time.Time `gorm:"autoUpdateTime"`
+}
+
+func (baselineTranslationSource) TableName() string { return "translation_sources" }
+
+type baselineSourceVersion struct {
+	ID           int64     `gorm:"primaryKey;autoIncrement"`
+	SourceID     int64     `gorm:"index;not null"`
+	ModVersionID int64     `gorm:"index;not null"`
+	CreatedAt    time.Time `gorm:"autoCreateTime"`
+}
+
+func (baselineSourceVersion) TableName() string { return "source_versions" }
+
+type baselineTranslation struct {
+	ID         int64 `gorm:"primaryKey;autoIncrement"`
+	SourceID   int64 `gorm:"index"`
+	TargetText *string
+	TargetLang string `gorm:"default:ja_jp;index"`
+	Status     string `gorm:"default:pending;index"`
+	Translator *string
+	Tags       []string `gorm:"serializer:json"`
+	Notes      *string
+	CreatedAt  time.Time `gorm:"autoCreateTime"`
+	UpdatedAt  time.Time `gorm:"autoUpdateTime"`
+
+	ModVersionID int64 `gorm:"index"`
+	Key          string
+	SourceText   string
+	SourceLang   string `gorm:"default:en_us"`
+}
+
+func (baselineTranslation) TableName() string { return "translations" }
+
+// fixtureBaselineSchema creates the unversioned schema that AutoMigrate produced before
+// schema_migrations was introduced.
+func fixtureBaselineSchema(t *testing.T, repo *Repository) {
+	t.Helper()
+
+	err := repo.GetDB().AutoMigrate(
+		&baselineMod{},
+		&baselineModVersion{},
+		&models.Term{},
+		&baselineTranslationSource{},
+		&baselineSourceVersion{},
+		&baselineTranslation{},
+		&models.FilePattern{},
+		&models.VersionDiff{},
+	)
+	if err != nil {
+		t.Fatalf("AutoMigrate() of the baseline schema error = %v", err)
+	}
+}
@@ -0,0 +1,128 @@
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// ConstraintsSchemaVersion is the migration that adds the unique indexes and foreign keys
+// of the source schema. Existing violations are resolved by the migration itself.
+const ConstraintsSchemaVersion = 5
+
+// ConstraintReport counts rows that violate the unique indexes and foreign keys of the
+// source schema.
+type ConstraintReport struct {
+	MissingMods             int64 // mods referenced by versions or sources but not in mods
+	DuplicateSources        int64 // extra sources with the same mod_id + key + source_text
+	DuplicateSourceVersions int64 // extra links between the same source and version
+	DuplicateTranslations   int64 // extra translations for the same source_id + target_lang
+	OrphanSourceVersions    int64 // links to a missing source or version
+	OrphanTranslations      int64 // translations of a missing source
+}
+
+// Total returns the number of violations.
+func (r *ConstraintReport) Total() int64 {
+	return r.MissingMods + r.DuplicateSources + r.DuplicateSourceVersions +
+		r.DuplicateTranslations + r.OrphanSourceVersions + r.OrphanTranslations
+}
+
+// CheckConstraints reports rows that violate the source schema constraints. The result is
+// all zeros on databases that are at ConstraintsSchemaVersion or later.
+func (r *Repository) CheckConstraints(ctx context.Context) (*ConstraintReport, error) {
+	db := r.db.WithContext(ctx)
+	report := &ConstraintReport{}
+
+	for _, table := range []string{"mods", "mod_versions", "translation_sources", "source_versions", "translations"} {
+		if !db.Migrator().HasTable(table) {
+			return report, nil
+		}
+	}
+
+	checks := []struct {
+		count *int64
+		query string
+	}{
+		{&report.MissingMods, `SELECT COUNT(*) FROM (
+			SELECT mod_id FROM mod_versions UNION SELECT mod_id FROM translation_sources
+		) m WHERE m.mod_id NOT IN (SELECT id FROM mods)`},
+		{&report.DuplicateSources, `SELECT CAST(COALESCE(SUM(n - 1), 0) AS bigint) FROM (
+			SELECT COUNT(*) AS n FROM translation_sources GROUP BY mod_id, key, source_text HAVING COUNT(*) > 1
+		) d`},
+		{&report.DuplicateSourceVersions, `SELECT CAST(COALESCE(SUM(n - 1), 0) AS bigint) FROM (
+			SELECT COUNT(*) AS n FROM source_versions GROUP BY source_id, mod_version_id HAVING COUNT(*) > 1
+		) d`},
+		{&report.DuplicateTranslations, `SELECT CAST(COALESCE(SUM(n - 1), 0) AS bigint) FROM (
+			SELECT COUNT(*) AS n FROM translations GROUP BY source_id, target_lang HAVING COUNT(*) > 1
+		) d`},
+		{&report.OrphanSourceVersions, `SELECT COUNT(*) FROM source_versions
+			WHERE source_id NOT IN (SELECT id FROM translation_sources)
+				OR mod_version_id NOT IN (SELECT id FROM mod_versions)`},
+		{&report.OrphanTranslations, `SELECT COUNT(*) FROM translations
+			WHERE source_id IS NULL OR source_id NOT IN (SELECT id FROM translation_sources)`},
+	}
+	for _, check := range checks {
+		if err := db.Raw(check.query).Scan(check.count).Error; err != nil {
+			return nil, fmt.Errorf("failed to check constraints: %w", err)
+		}
+	}
+
+	return report, nil
+}
+
+// resolveConstraintViolations fixes the rows counted by CheckConstraints:
+//   - missing mods are created from their ID so versions and sources are kept
+//   - duplicate sources are merged into the oldest one
+//   - duplicate links and links or translations without a parent are deleted
+//   - of duplicate translations the best one is kept (by status, then non-empty
+//     target, then most recently updated)
+func resolveConstraintViolations(tx *gorm.DB) error {
+	return execAll(tx,
+		`INSERT INTO mods (id, display_name, created_at, updated_at)
+		SELECT m.mod_id, m.mod_id, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP FROM (
+			SELECT mod_id FROM mod_versions UNION SELECT mod_id FROM translation_sources
+		) m WHERE m.mod_id NOT IN (SELECT id FROM mods)`,
+		`UPDATE source_versions SET source_id = (
+			SELECT MIN(s2.id) FROM translation_sources s1
+			JOIN translation_sources s2 ON s2.mod_id = s1.mod_id AND s2.key = s1.key AND s2.source_text = s1.source_text
+			WHERE s1.id = source_versions.source_id
+		) WHERE source_id IN (SELECT id FROM translation_sources)`,
+		`UPDATE translations SET source_id = (
+			SELECT MIN(s2.id) FROM translation_sources s1
+			JOIN translation_sources s2 ON s2.mod_id = s1.mod_id AND s2.key = s1.key AND s2.source_text = s1.source_text
+			WHERE s1.id = translations.source_id
+		) WHERE source_id IN (SELECT id FROM translation_sources)`,
+		`DELETE FROM translation_sources WHERE id NOT IN (
+			SELECT MIN(id) FROM translation_sources GROUP BY mod_id, key, source_text
+		)`,
+		`DELETE FROM source_versions
+		WHERE source_id NOT IN (SELECT id FROM translation_sources)
+			OR mod_version_id NOT IN (SELECT id FROM mod_versions)`,
+		`DELETE FROM source_versions WHERE id NOT IN (
+			SELECT MIN(id) FROM source_versions GROUP BY source_id, mod_version_id
+		)`,
+		`DELETE FROM translations
+		WHERE source_id IS NULL OR source_id NOT IN (SELECT id FROM translation_sources)`,
+		`DELETE FROM translations WHERE id IN (
+			SELECT id FROM (
+				SELECT id, ROW_NUMBER() OVER (
+					PARTITION BY source_id, target_lang
+					ORDER BY
+						CASE status
+							WHEN 'official' THEN 1
+							WHEN 'verified' THEN 1
+							WHEN 'translated' THEN 2
+							WHEN 'inherited' THEN 3
+							WHEN 'needs_review' THEN 4
+							ELSE 5
+						END,
+						CASE WHEN target_text IS NULL OR target_text = '' THEN 1 ELSE 0 END,
+						updated_at DESC,
+						id DESC
+				) AS rn
+				FROM translations
+			) ranked WHERE ranked.rn > 1
+		)`,
+	)
+}
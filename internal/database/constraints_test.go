@@ -0,0 +1,238 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/iuif/minecraft-mod-dictionary/pkg/models"
+)
+
+// seedConstraintViolations fills a version 4 database with rows the constraints reject.
+func seedConstraintViolations(t *testing.T, repo *Repository) {
+	t.Helper()
+
+	if err := execAll(repo.GetDB(),
+		`INSERT INTO mods (id, display_name) VALUES ('create', 'Create')`,
+		`INSERT INTO mod_versions (id, mod_id, version, mc_version, is_default) VALUES (1, 'create', '0.5.1', '1.20.1', true)`,
+		// Version and source of a mod that is not in mods
+		`INSERT INTO mod_versions (id, mod_id, version, mc_version) VALUES (2, 'ghost', '1.0', '1.20.1')`,
+		`INSERT INTO translation_sources (id, mod_id, key, source_text) VALUES (30, 'ghost', 'item.ghost.a', 'A')`,
+		// Two sources for the same mod_id + key + source_text
+		`INSERT INTO translation_sources (id, mod_id, key, source_text) VALUES (10, 'create', 'item.create.wrench', 'Wrench')`,
+		`INSERT INTO translation_sources (id, mod_id, key, source_text) VALUES (11, 'create', 'item.create.wrench', 'Wrench')`,
+		`INSERT INTO source_versions (source_id, mod_version_id) VALUES (10, 1)`,
+		`INSERT INTO source_versions (source_id, mod_version_id) VALUES (11, 1)`,
+		`INSERT INTO source_versions (source_id, mod_version_id) VALUES (10, 1)`,
+		// Links to a missing source and a missing version
+		`INSERT INTO source_versions (source_id, mod_version_id) VALUES (99, 1)`,
+		`INSERT INTO source_versions (source_id, mod_version_id) VALUES (10, 99)`,
+		// The pending translation of source 10 loses against the translated one of source 11
+		`INSERT INTO translations (id, source_id, target_lang, status) VALUES (100, 10, 'ja_jp', 'pending')`,
+		`INSERT INTO translations (id, source_id, target_text, target_lang, status) VALUES (101, 11, 'レンチ', 'ja_jp', 'translated')`,
+		`INSERT INTO translations (id, source_id, target_lang, status) VALUES (102, 99, 'ja_jp', 'pending')`,
+	); err != nil {
+		t.Fatalf("failed to seed violations: %v", err)
+	}
+}
+
+func TestMigrate_ResolvesConstraintViolations(t *testing.T) {
+	repo := setupTestRepositoryAt(t, ConstraintsSchemaVersion-1)
+	ctx := context.Background()
+	seedConstraintViolations(t, repo)
+
+	report, err := repo.CheckConstraints(ctx)
+	if err != nil {
+		t.Fatalf("CheckConstraints() error = %v", err)
+	}
+	want := ConstraintReport{
+		MissingMods:             1,
+		DuplicateSources:        1,
+		DuplicateSourceVersions: 1,
+		DuplicateTranslations:   0, // only duplicated once the sources are merged
+		OrphanSourceVersions:    2,
+		OrphanTranslations:      1,
+	}
+	if *report != want {
+		t.Errorf("CheckConstraints() = %+v, want %+v", *report, want)
+	}
+
+	if err := repo.Migrate(); err != nil {
+		t.Fatalf("Migrate() error = %v", err)
+	}
+
+	report, err = repo.CheckConstraints(ctx)
+	if err != nil {
+		t.Fatalf("CheckConstraints() error = %v", err)
+	}
+	if report.Total() != 0 {
+		t.Errorf("CheckConstraints() after Migrate() = %+v, want no violations", *report)
+	}
+
+	if _, err := repo.GetMod(ctx, "ghost"); err != nil {
+		t.Errorf("GetMod(ghost) error = %v, want the missing mod to be created", err)
+	}
+
+	trans, err := repo.GetTranslation(ctx, 1, "item.create.wrench")
+	if err != nil {
+		t.Fatalf("GetTranslation() error = %v", err)
+	}
+	if trans.ID != 101 || trans.SourceID != 10 {
+		t.Errorf("GetTranslation() = (id %d, source %d), want the translated row 101 moved to source 10", trans.ID, trans.SourceID)
+	}
+}
+
+func TestConstraints_Enforced(t *testing.T) {
+	tests := []struct {
+		name string
+		stmt string
+	}{
+		{
+			name: "duplicate source",
+			stmt: `INSERT INTO translation_sources (mod_id, key, source_text) VALUES ('create', 'item.create.wrench', 'Wrench')`,
+		},
+		{
+			name: "duplicate version link",
+			stmt: `INSERT INTO source_versions (source_id, mod_version_id) VALUES (10, 1)`,
+		},
+		{
+			name: "second translation for the same language",
+			stmt: `INSERT INTO translations (source_id, target_lang, status) VALUES (10, 'ja_jp', 'pending')`,
+		},
+		{
+			name: "source of a missing mod",
+			stmt: `INSERT INTO translation_sources (mod_id, key, source_text) VALUES ('missing', 'a', 'A')`,
+		},
+		{
+			name: "translation of a missing source",
+			stmt: `INSERT INTO translations (source_id, target_lang, status) VALUES (999, 'ja_jp', 'pending')`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := setupTestRepository(t)
+			if err := execAll(repo.GetDB(),
+				`INSERT INTO mods (id, display_name) VALUES ('create', 'Create')`,
+				`INSERT INTO mod_versions (id, mod_id, version, mc_version) VALUES (1, 'create', '0.5.1', '1.20.1')`,
+				`INSERT INTO translation_sources (id, mod_id, key, source_text) VALUES (10, 'create', 'item.create.wrench', 'Wrench')`,
+				`INSERT INTO source_versions (source_id, mod_version_id) VALUES (10, 1)`,
+				`INSERT INTO translations (source_id, target_lang, status) VALUES (10, 'ja_jp', 'pending')`,
+			); err != nil {
+				t.Fatalf("failed to seed: %v", err)
+			}
+
+			if err := repo.GetDB().Exec(tt.stmt).Error; err == nil {
+				t.Errorf("Exec(%s) expected constraint error", tt.stmt)
+			}
+		})
+	}
+}
+
+func TestConstraints_DeleteModCascades(t *testing.T) {
+	repo := setupTestRepository(t)
+	ctx := context.Background()
+
+	repo.SaveMod(ctx, &models.Mod{ID: "create", DisplayName: "Create"})
+	version := &models.ModVersion{ModID: "create", Version: "0.5.1", MCVersion: "1.20.1"}
+	repo.SaveVersion(ctx, version)
+	source, _, err := repo.GetOrCreateSource(ctx, "create", "item.create.wrench", "Wrench", "en_us")
+	if err != nil {
+		t.Fatalf("GetOrCreateSource() error = %v", err)
+	}
+	repo.LinkSourceToVersion(ctx, source.ID, version.ID)
+	repo.SaveTranslation(ctx, &models.Translation{SourceID: source.ID, TargetLang: "ja_jp", Status: models.StatusPending})
+
+	if err := repo.DeleteMod(ctx, "create"); err != nil {
+		t.Fatalf("DeleteMod() error = %v", err)
+	}
+
+	for _, table := range []string{"mod_versions", "translation_sources", "source_versions", "translations"} {
+		var count int64
+		repo.GetDB().Table(table).Count(&count)
+		if count != 0 {
+			t.Errorf("%s has %d rows after DeleteMod(), want 0", table, count)
+		}
+	}
+}
+
+func TestRepository_Upserts(t *testing.T) {
+	repo := setupTestRepository(t)
+	ctx := context.Background()
+
+	repo.SaveMod(ctx, &models.Mod{ID: "create", DisplayName: "Create"})
+	version := &models.ModVersion{ModID: "create", Version: "0.5.1", MCVersion: "1.20.1"}
+	repo.SaveVersion(ctx, version)
+
+	t.Run("GetOrCreateSource", func(t *testing.T) {
+		first, created, err := repo.GetOrCreateSource(ctx, "create", "item.create.shaft", "Shaft", "en_us")
+		if err != nil || !created {
+			t.Fatalf("GetOrCreateSource() = (%v, %v), want created", created, err)
+		}
+		second, created, err := repo.GetOrCreateSource(ctx, "create", "item.create.shaft", "Shaft", "en_us")
+		if err != nil || created {
+			t.Fatalf("GetOrCreateSource() second call = (%v, %v), want existing", created, err)
+		}
+		if first.ID != second.ID {
+			t.Errorf("GetOrCreateSource() IDs = %d, %d, want the same source", first.ID, second.ID)
+		}
+	})
+
+	source, _, _ := repo.GetOrCreateSource(ctx, "create", "item.create.cog", "Cogwheel", "en_us")
+
+	t.Run("LinkSourceToVersion", func(t *testing.T) {
+		for i := 0; i < 2; i++ {
+			if err := repo.LinkSourceToVersion(ctx, source.ID, version.ID); err != nil {
+				t.Fatalf("LinkSourceToVersion() error = %v", err)
+			}
+		}
+		links, err := repo.GetSourceVersions(ctx, source.ID)
+		if err != nil {
+			t.Fatalf("GetSourceVersions() error = %v", err)
+		}
+		if len(links) != 1 {
+			t.Errorf("GetSourceVersions() got %d links, want 1", len(links))
+		}
+	})
+
+	t.Run("SaveTranslation", func(t *testing.T) {
+		pending := &models.Translation{SourceID: source.ID, TargetLang: "ja_jp", Status: models.StatusPending}
+		if err := repo.SaveTranslation(ctx, pending); err != nil {
+			t.Fatalf("SaveTranslation() error = %v", err)
+		}
+
+		target := "歯車"
+		translated := &models.Translation{SourceID: source.ID, TargetText: &target, TargetLang: "ja_jp", Status: models.StatusTranslated}
+		if err := repo.SaveTranslation(ctx, translated); err != nil {
+			t.Fatalf("SaveTranslation() second insert error = %v", err)
+		}
+		if translated.ID != pending.ID {
+			t.Errorf("SaveTranslation() ID = %d, want existing row %d", translated.ID, pending.ID)
+		}
+
+		got, err := repo.GetTranslation(ctx, version.ID, "item.create.cog")
+		if err != nil {
+			t.Fatalf("GetTranslation() error = %v", err)
+		}
+		if got.Status != models.StatusTranslated || got.TargetText == nil || *got.TargetText != target {
+			t.Errorf("GetTranslation() = (%s, %v), want translated %s", got.Status, got.TargetText, target)
+		}
+	})
+}
+
+// setupTestRepositoryAt creates a repository migrated to the given version.
+func setupTestRepositoryAt(t *testing.T, version int) *Repository {
+	t.Helper()
+
+	repo, err := NewRepository(testDSN(t))
+	if err != nil {
+		t.Fatalf("NewRepository() error = %v", err)
+	}
+	t.Cleanup(func() {
+		repo.Close()
+	})
+
+	if _, err := repo.MigrateTo(context.Background(), version); err != nil {
+		t.Fatalf("MigrateTo(%d) error = %v", version, err)
+	}
+	return repo
+}
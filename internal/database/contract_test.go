@@ -0,0 +1,380 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/iuif/minecraft-mod-dictionary/pkg/interfaces"
+	"github.com/iuif/minecraft-mod-dictionary/pkg/models"
+	"github.com/iuif/minecraft-mod-dictionary/pkg/repotest"
+)
+
+// TestSourceRepositoryContract runs the same scenario against the database and the
+// in-memory fake, so tests written against repotest hold for the real repository.
+func TestSourceRepositoryContract(t *testing.T) {
+	tests := []struct {
+		name string
+		repo func(t *testing.T) interfaces.SourceRepository
+	}{
+		{"database", func(t *testing.T) interfaces.SourceRepository { return setupTestRepository(t) }},
+		{"repotest", func(t *testing.T) interfaces.SourceRepository { return repotest.NewRepository() }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testSourceRepositoryContract(t, tt.repo(t))
+		})
+	}
+}
+
+func testSourceRepositoryContract(t *testing.T, repo interfaces.SourceRepository) {
+	ctx := context.Background()
+
+	if _, err := repo.GetMod(ctx, "create"); !errors.Is(err, interfaces.ErrModNotFound) {
+		t.Errorf("GetMod() error = %v, want ErrModNotFound", err)
+	}
+	if _, err := repo.GetSource(ctx, 999); !errors.Is(err, interfaces.ErrSourceNotFound) {
+		t.Errorf("GetSource() error = %v, want ErrSourceNotFound", err)
+	}
+	if _, err := repo.GetDefaultVersion(ctx, "create"); !errors.Is(err, interfaces.ErrVersionNotFound) {
+		t.Errorf("GetDefaultVersion() error = %v, want ErrVersionNotFound", err)
+	}
+
+	if err := repo.SaveMod(ctx, &models.Mod{ID: "create", DisplayName: "Create"}); err != nil {
+		t.Fatalf("SaveMod() error = %v", err)
+	}
+
+	v1, created, err := repo.GetOrCreateVersion(ctx, "create", "0.5.1", "forge")
+	if err != nil || !created {
+		t.Fatalf("GetOrCreateVersion() = %v, %v; want created", created, err)
+	}
+	v2, _, err := repo.GetOrCreateVersion(ctx, "create", "6.0.0", "neoforge")
+	if err != nil {
+		t.Fatalf("GetOrCreateVersion() error = %v", err)
+	}
+	if again, created, _ := repo.GetOrCreateVersion(ctx, "create", "0.5.1", "forge"); created || again.ID != v1.ID {
+		t.Errorf("GetOrCreateVersion() of existing version = %d, %v; want %d, false", again.ID, created, v1.ID)
+	}
+
+	// Without a default, the newest version is used
+	if def, err := repo.GetDefaultVersion(ctx, "create"); err != nil || def.ID != v2.ID {
+		t.Errorf("GetDefaultVersion() without default = %v, %v; want version %d", def, err, v2.ID)
+	}
+	if err := repo.SetDefaultVersion(ctx, v1.ID); err != nil {
+		t.Fatalf("SetDefaultVersion() error = %v", err)
+	}
+	if def, _ := repo.GetDefaultVersion(ctx, "create"); def == nil || def.ID != v1.ID {
+		t.Errorf("GetDefaultVersion() = %v, want version %d", def, v1.ID)
+	}
+
+	// Sources: "cogwheel" is unchanged between versions, "wrench" changed its text
+	cog, created, err := repo.GetOrCreateSource(ctx, "create", "block.create.cogwheel", "Cogwheel", "en_us")
+	if err != nil || !created {
+		t.Fatalf("GetOrCreateSource() = %v, %v; want created", created, err)
+	}
+	if again, created, _ := repo.GetOrCreateSource(ctx, "create", "block.create.cogwheel", "Cogwheel", "en_us"); created || again.ID != cog.ID {
+		t.Errorf("GetOrCreateSource() of existing source = %d, %v; want %d, false", again.ID, created, cog.ID)
+	}
+	wrenchOld, _, _ := repo.GetOrCreateSource(ctx, "create", "item.create.wrench", "Wrench", "en_us")
+	wrenchNew, _, _ := repo.GetOrCreateSource(ctx, "create", "item.create.wrench", "Wrench Tool", "en_us")
+	gear, _, _ := repo.GetOrCreateSource(ctx, "create", "item.create.gear", "Gear", "en_us")
+
+	links := []struct{ source, version int64 }{
+		{cog.ID, v1.ID}, {cog.ID, v2.ID}, {cog.ID, v1.ID},
+		{wrenchOld.ID, v1.ID}, {wrenchNew.ID, v2.ID}, {gear.ID, v1.ID},
+	}
+	for _, l := range links {
+		if err := repo.LinkSourceToVersion(ctx, l.source, l.version); err != nil {
+			t.Fatalf("LinkSourceToVersion(%d, %d) error = %v", l.source, l.version, err)
+		}
+	}
+	if svs, _ := repo.GetSourceVersions(ctx, cog.ID); len(svs) != 2 {
+		t.Errorf("GetSourceVersions() got %d links, want 2", len(svs))
+	}
+	if sources, _ := repo.ListSourcesByVersion(ctx, v2.ID); len(sources) != 2 {
+		t.Errorf("ListSourcesByVersion() got %d, want 2", len(sources))
+	}
+	if sources, _ := repo.ListSourcesByMod(ctx, "create", false); len(sources) != 4 {
+		t.Errorf("ListSourcesByMod() got %d, want 4", len(sources))
+	}
+
+	// The default version decides which wrench source a key lookup returns
+	if s, _ := repo.GetSourceByModAndKey(ctx, "create", "item.create.wrench"); s == nil || s.ID != wrenchOld.ID {
+		t.Errorf("GetSourceByModAndKey() = %v, want the default version's source %d", s, wrenchOld.ID)
+	}
+	if s, err := repo.GetSourceByModAndKey(ctx, "create", "item.create.missing"); s != nil || err != nil {
+		t.Errorf("GetSourceByModAndKey() of missing key = %v, %v; want nil, nil", s, err)
+	}
+	if s, err := repo.GetSourceByKey(ctx, "create", "item.create.wrench", "Spanner"); s != nil || err != nil {
+		t.Errorf("GetSourceByKey() of missing text = %v, %v; want nil, nil", s, err)
+	}
+
+	// Translations are upserted on source + language
+	text := func(s string) *string { return &s }
+	first := &models.Translation{SourceID: cog.ID, TargetLang: "ja_jp", TargetText: text("歯車"), Status: models.StatusTranslated}
+	if err := repo.SaveTranslation(ctx, first); err != nil {
+		t.Fatalf("SaveTranslation() error = %v", err)
+	}
+	second := &models.Translation{SourceID: cog.ID, TargetLang: "ja_jp", TargetText: text("コグホイール"), Status: models.StatusVerified}
+	if err := repo.SaveTranslation(ctx, second); err != nil {
+		t.Fatalf("SaveTranslation() upsert error = %v", err)
+	}
+	if second.ID != first.ID {
+		t.Errorf("SaveTranslation() upsert ID = %d, want %d", second.ID, first.ID)
+	}
+	got, err := repo.GetTranslationBySource(ctx, cog.ID, "ja_jp")
+	if err != nil || *got.TargetText != "コグホイール" || got.Status != models.StatusVerified {
+		t.Errorf("GetTranslationBySource() = %v, %v; want the upserted translation", got, err)
+	}
+	if _, err := repo.GetTranslationBySource(ctx, gear.ID, "ja_jp"); !errors.Is(err, interfaces.ErrTranslationNotFound) {
+		t.Errorf("GetTranslationBySource() error = %v, want ErrTranslationNotFound", err)
+	}
+
+	if err := repo.BulkSaveTranslations(ctx, []*models.Translation{
+		{SourceID: wrenchOld.ID, TargetLang: "ja_jp", TargetText: text("レンチ"), Status: models.StatusTranslated,
+			Tags: []string{"item", "tool"}, Notes: text("Not a spanner")},
+		{SourceID: wrenchNew.ID, TargetLang: "ja_jp", TargetText: text("レンチツール"), Status: models.StatusTranslated,
+			Tags: []string{"item", "tool"}, Notes: text("Not a spanner")},
+	}); err != nil {
+		t.Fatalf("BulkSaveTranslations() error = %v", err)
+	}
+	if err := repo.SaveTranslation(ctx, &models.Translation{SourceID: 999, TargetLang: "ja_jp", Status: models.StatusTranslated}); err == nil {
+		t.Error("SaveTranslation() with a missing source expected error")
+	}
+
+	if trans, err := repo.GetTranslation(ctx, v2.ID, "item.create.wrench"); err != nil || *trans.TargetText != "レンチツール" {
+		t.Errorf("GetTranslation(v2, wrench) = %v, %v; want レンチツール", trans, err)
+	}
+
+	// Default version v1: cogwheel (verified), old wrench (translated), gear (no translation)
+	withSource, err := repo.ListTranslationsWithSourceByMod(ctx, "create", interfaces.TranslationFilter{})
+	if err != nil {
+		t.Fatalf("ListTranslationsWithSourceByMod() error = %v", err)
+	}
+	keys := make(map[string]string)
+	for _, ts := range withSource {
+		keys[ts.Key] = ts.SourceText
+		checkWrenchNotes(t, "ListTranslationsWithSourceByMod()", ts)
+	}
+	if len(withSource) != 2 || keys["item.create.wrench"] != "Wrench" || keys["block.create.cogwheel"] != "Cogwheel" {
+		t.Errorf("ListTranslationsWithSourceByMod() = %v, want cogwheel and the old wrench", keys)
+	}
+	if verified, _ := repo.ListTranslationsWithSourceByMod(ctx, "create", interfaces.TranslationFilter{Status: models.StatusVerified}); len(verified) != 1 {
+		t.Errorf("ListTranslationsWithSourceByMod(verified) got %d, want 1", len(verified))
+	}
+	if byVersion, _ := repo.ListTranslationsWithSourceByVersion(ctx, v2.ID, interfaces.TranslationFilter{}); len(byVersion) != 2 {
+		t.Errorf("ListTranslationsWithSourceByVersion() got %d, want 2", len(byVersion))
+	}
+	if limited, _ := repo.ListTranslationsByMod(ctx, "create", interfaces.TranslationFilter{Limit: 2}); len(limited) != 2 {
+		t.Errorf("ListTranslationsByMod(limit 2) got %d, want 2", len(limited))
+	}
+
+	counts, err := repo.CountTranslationsByMod(ctx, "create")
+	if err != nil {
+		t.Fatalf("CountTranslationsByMod() error = %v", err)
+	}
+	want := map[string]int{models.StatusVerified: 1, models.StatusTranslated: 1, models.StatusPending: 1}
+	for status, n := range want {
+		if counts[status] != n {
+			t.Errorf("CountTranslationsByMod()[%s] = %d, want %d", status, counts[status], n)
+		}
+	}
+
+	// Key lookups follow the default version (old wrench) or the given version (new wrench)
+	lookup := []string{"item.create.wrench", "item.create.gear", "item.create.wrench", "item.create.missing"}
+	if byKeys, err := repo.ListTranslationsWithSourceByModKeys(ctx, "create", lookup, interfaces.TranslationFilter{TargetLang: "ja_jp"}); err != nil || len(byKeys) != 1 || byKeys[0].SourceText != "Wrench" {
+		t.Errorf("ListTranslationsWithSourceByModKeys() = %v, %v; want the old wrench only", byKeys, err)
+	} else {
+		checkWrenchNotes(t, "ListTranslationsWithSourceByModKeys()", byKeys[0])
+	}
+	if byKeys, err := repo.ListTranslationsWithSourceByVersionKeys(ctx, v2.ID, lookup, interfaces.TranslationFilter{}); err != nil || len(byKeys) != 1 || byKeys[0].SourceText != "Wrench Tool" {
+		t.Errorf("ListTranslationsWithSourceByVersionKeys() = %v, %v; want the new wrench only", byKeys, err)
+	} else {
+		checkWrenchNotes(t, "ListTranslationsWithSourceByVersionKeys()", byKeys[0])
+	}
+	var streamed []string
+	err = repo.StreamTranslationsWithSourceByMod(ctx, "create", interfaces.TranslationFilter{}, func(ts *models.TranslationWithSource) error {
+		streamed = append(streamed, ts.Key)
+		checkWrenchNotes(t, "StreamTranslationsWithSourceByMod()", ts)
+		return nil
+	})
+	if err != nil || len(streamed) != 2 || streamed[0] != "block.create.cogwheel" {
+		t.Errorf("StreamTranslationsWithSourceByMod() = %v, %v; want cogwheel then wrench", streamed, err)
+	}
+
+	var iterated []string
+	err = repo.IterateTranslations(ctx, interfaces.TranslationIterFilter{Statuses: []string{models.StatusTranslated}, TranslatedOnly: true, BatchSize: 1},
+		func(ts *models.TranslationWithSource) error {
+			iterated = append(iterated, ts.ModID+"/"+ts.Key)
+			checkWrenchNotes(t, "IterateTranslations()", ts)
+			return nil
+		})
+	if err != nil || len(iterated) != 1 || iterated[0] != "create/item.create.wrench" {
+		t.Errorf("IterateTranslations() = %v, %v; want create/item.create.wrench", iterated, err)
+	}
+	if err := repo.IterateTranslations(ctx, interfaces.TranslationIterFilter{VersionID: v2.ID}, func(*models.TranslationWithSource) error {
+		return errors.New("stop")
+	}); err == nil || err.Error() != "stop" {
+		t.Errorf("IterateTranslations() error = %v, want the callback error", err)
+	}
+
+	testArchiveContract(t, repo, v1, v2)
+
+	// Deleting a version removes its links but keeps shared sources; sources of only that
+	// version are deleted with their translations
+	if err := repo.DeleteVersion(ctx, v2.ID); err != nil {
+		t.Fatalf("DeleteVersion() error = %v", err)
+	}
+	if svs, _ := repo.GetSourceVersions(ctx, cog.ID); len(svs) != 1 {
+		t.Errorf("GetSourceVersions() after DeleteVersion() got %d links, want 1", len(svs))
+	}
+	if _, err := repo.GetSource(ctx, wrenchNew.ID); !errors.Is(err, interfaces.ErrSourceNotFound) {
+		t.Errorf("GetSource() of an unlinked source after DeleteVersion() error = %v, want ErrSourceNotFound", err)
+	}
+	if _, err := repo.GetTranslationBySource(ctx, wrenchNew.ID, "ja_jp"); !errors.Is(err, interfaces.ErrTranslationNotFound) {
+		t.Errorf("GetTranslationBySource() of an unlinked source after DeleteVersion() error = %v, want ErrTranslationNotFound", err)
+	}
+
+	// Deleting the mod cascades to its versions, sources and translations
+	if err := repo.DeleteMod(ctx, "create"); err != nil {
+		t.Fatalf("DeleteMod() error = %v", err)
+	}
+	if _, err := repo.GetVersion(ctx, v1.ID); !errors.Is(err, interfaces.ErrVersionNotFound) {
+		t.Errorf("GetVersion() after DeleteMod() error = %v, want ErrVersionNotFound", err)
+	}
+	if _, err := repo.GetSource(ctx, cog.ID); !errors.Is(err, interfaces.ErrSourceNotFound) {
+		t.Errorf("GetSource() after DeleteMod() error = %v, want ErrSourceNotFound", err)
+	}
+	if _, err := repo.GetTranslationBySource(ctx, cog.ID, "ja_jp"); !errors.Is(err, interfaces.ErrTranslationNotFound) {
+		t.Errorf("GetTranslationBySource() after DeleteMod() error = %v, want ErrTranslationNotFound", err)
+	}
+	if _, _, err := repo.GetOrCreateSource(ctx, "create", "block.create.cogwheel", "Cogwheel", "en_us"); err == nil {
+		t.Error("GetOrCreateSource() for a deleted mod expected error")
+	}
+}
+
+// checkWrenchNotes checks that a wrench translation was loaded with its notes and tags.
+func checkWrenchNotes(t *testing.T, call string, ts *models.TranslationWithSource) {
+	t.Helper()
+	if ts.Key != "item.create.wrench" {
+		return
+	}
+	if ts.Notes == nil || *ts.Notes != "Not a spanner" || len(ts.Tags) != 2 || ts.Tags[0] != "item" || ts.Tags[1] != "tool" {
+		t.Errorf("%s wrench notes = %v, tags = %v; want the saved notes and tags", call, ts.Notes, ts.Tags)
+	}
+}
+
+// testArchiveContract archives and restores the versions of the "create" mod. v1 is the
+// default version with the old wrench, v2 has the new wrench; both have the cogwheel.
+func testArchiveContract(t *testing.T, repo interfaces.SourceRepository, v1, v2 *models.ModVersion) {
+	ctx := context.Background()
+
+	wrench := func() string {
+		translations, err := repo.ListTranslationsWithSourceByMod(ctx, "create", interfaces.TranslationFilter{})
+		if err != nil {
+			t.Fatalf("ListTranslationsWithSourceByMod() error = %v", err)
+		}
+		for _, ts := range translations {
+			if ts.Key == "item.create.wrench" {
+				return ts.SourceText
+			}
+		}
+		return ""
+	}
+
+	if err := repo.ArchiveMod(ctx, "missing"); !errors.Is(err, interfaces.ErrModNotFound) {
+		t.Errorf("ArchiveMod() of a missing mod error = %v, want ErrModNotFound", err)
+	}
+	if err := repo.ArchiveVersion(ctx, 999); !errors.Is(err, interfaces.ErrVersionNotFound) {
+		t.Errorf("ArchiveVersion() of a missing version error = %v, want ErrVersionNotFound", err)
+	}
+
+	// Archiving the default version promotes the newest active version
+	if err := repo.ArchiveVersion(ctx, v1.ID); err != nil {
+		t.Fatalf("ArchiveVersion() error = %v", err)
+	}
+	if def, err := repo.GetDefaultVersion(ctx, "create"); err != nil || def.ID != v2.ID {
+		t.Errorf("GetDefaultVersion() after ArchiveVersion() = %v, %v; want version %d", def, err, v2.ID)
+	}
+	if got := wrench(); got != "Wrench Tool" {
+		t.Errorf("default wrench after ArchiveVersion() = %q, want Wrench Tool", got)
+	}
+	if versions, _ := repo.ListVersions(ctx, "create", interfaces.VersionFilter{}); len(versions) != 1 || versions[0].ID != v2.ID {
+		t.Errorf("ListVersions() after ArchiveVersion() got %d versions, want v2 only", len(versions))
+	}
+	if versions, _ := repo.ListVersions(ctx, "create", interfaces.VersionFilter{IncludeArchived: true}); len(versions) != 2 {
+		t.Errorf("ListVersions(IncludeArchived) got %d, want 2", len(versions))
+	}
+	if v, err := repo.GetVersion(ctx, v1.ID); err != nil || !v.IsArchived() {
+		t.Errorf("GetVersion() of an archived version = %v, %v; want it archived", v, err)
+	}
+	if byVersion, _ := repo.ListTranslationsWithSourceByVersion(ctx, v1.ID, interfaces.TranslationFilter{}); len(byVersion) != 2 {
+		t.Errorf("ListTranslationsWithSourceByVersion() of an archived version got %d, want 2", len(byVersion))
+	}
+
+	// Archiving the mod hides it with all its versions; v1 stays archived when it is restored
+	if err := repo.ArchiveMod(ctx, "create"); err != nil {
+		t.Fatalf("ArchiveMod() error = %v", err)
+	}
+	if mods, _ := repo.ListMods(ctx, interfaces.ModFilter{}); len(mods) != 0 {
+		t.Errorf("ListMods() after ArchiveMod() got %d, want 0", len(mods))
+	}
+	if mods, _ := repo.ListMods(ctx, interfaces.ModFilter{IncludeArchived: true}); len(mods) != 1 || !mods[0].IsArchived() {
+		t.Errorf("ListMods(IncludeArchived) = %v, want the archived mod", mods)
+	}
+	if _, err := repo.GetDefaultVersion(ctx, "create"); !errors.Is(err, interfaces.ErrVersionNotFound) {
+		t.Errorf("GetDefaultVersion() of an archived mod error = %v, want ErrVersionNotFound", err)
+	}
+	if got := wrench(); got != "" {
+		t.Errorf("default wrench of an archived mod = %q, want none", got)
+	}
+	counts, err := repo.CountTranslationsByMod(ctx, "create")
+	if err != nil {
+		t.Fatalf("CountTranslationsByMod() error = %v", err)
+	}
+	for status, n := range counts {
+		if n != 0 {
+			t.Errorf("CountTranslationsByMod()[%s] of an archived mod = %d, want 0", status, n)
+		}
+	}
+
+	var archived []string
+	visit := func(ts *models.TranslationWithSource) error {
+		archived = append(archived, ts.SourceText)
+		return nil
+	}
+	if err := repo.IterateTranslations(ctx, interfaces.TranslationIterFilter{ModID: "create"}, visit); err != nil || len(archived) != 0 {
+		t.Errorf("IterateTranslations() of an archived mod = %v, %v; want none", archived, err)
+	}
+	if err := repo.IterateTranslations(ctx, interfaces.TranslationIterFilter{ModID: "create", IncludeArchived: true}, visit); err != nil || len(archived) != 2 {
+		t.Errorf("IterateTranslations(IncludeArchived) = %v, %v; want the default version's 2 translations", archived, err)
+	}
+	if err := repo.UnarchiveVersion(ctx, v2.ID); !errors.Is(err, interfaces.ErrModArchived) {
+		t.Errorf("UnarchiveVersion() of an archived mod's version error = %v, want ErrModArchived", err)
+	}
+
+	if err := repo.UnarchiveMod(ctx, "create"); err != nil {
+		t.Fatalf("UnarchiveMod() error = %v", err)
+	}
+	if mod, err := repo.GetMod(ctx, "create"); err != nil || mod.IsArchived() {
+		t.Errorf("GetMod() after UnarchiveMod() = %v, %v; want it active", mod, err)
+	}
+	if versions, _ := repo.ListVersions(ctx, "create", interfaces.VersionFilter{}); len(versions) != 1 || versions[0].ID != v2.ID {
+		t.Errorf("ListVersions() after UnarchiveMod() got %d versions, want v2 only", len(versions))
+	}
+	if got := wrench(); got != "Wrench Tool" {
+		t.Errorf("default wrench after UnarchiveMod() = %q, want Wrench Tool", got)
+	}
+
+	// A restored version does not take the default back
+	if err := repo.UnarchiveVersion(ctx, v1.ID); err != nil {
+		t.Fatalf("UnarchiveVersion() error = %v", err)
+	}
+	if def, _ := repo.GetDefaultVersion(ctx, "create"); def == nil || def.ID != v2.ID {
+		t.Errorf("GetDefaultVersion() after UnarchiveVersion() = %v, want version %d", def, v2.ID)
+	}
+	if versions, _ := repo.ListVersions(ctx, "create", interfaces.VersionFilter{}); len(versions) != 2 {
+		t.Errorf("ListVersions() after UnarchiveVersion() got %d, want 2", len(versions))
+	}
+}
@@ -0,0 +1,756 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Migration is a numbered schema change with its reverse step.
+// Up steps are idempotent so that databases created by the former AutoMigrate-based
+// schema handling (which have no schema_migrations table) can be brought under version control.
+type Migration struct {
+	Version int
+	Name    string
+	Up      func(tx *gorm.DB) error
+	Down    func(tx *gorm.DB) error
+}
+
+// MigrationState is a migration together with whether it has been applied.
+type MigrationState struct {
+	Version   int
+	Name      string
+	Applied   bool
+	AppliedAt *time.Time
+}
+
+// schemaMigration is a row of the schema_migrations table.
+type schemaMigration struct {
+	Version   int       `gorm:"primaryKey;autoIncrement:false"`
+	Name      string    `gorm:"not null"`
+	AppliedAt time.Time `gorm:"not null"`
+}
+
+func (schemaMigration) TableName() string {
+	return "schema_migrations"
+}
+
+// migrations is the ordered list of schema versions.
+var migrations = []Migration{
+	{Version: 1, Name: "initial_schema", Up: migrateInitialSchemaUp, Down: migrateInitialSchemaDown},
+	{Version: 2, Name: "source_based_schema", Up: migrateSourceSchemaUp, Down: migrateSourceSchemaDown},
+	{Version: 3, Name: "translation_memory", Up: migrateTranslationMemoryUp, Down: migrateTranslationMemoryDown},
+	{Version: 4, Name: "drop_legacy_translation_columns", Up: migrateDropLegacyColumnsUp, Down: migrateDropLegacyColumnsDown},
+	{Version: ConstraintsSchemaVersion, Name: "source_constraints", Up: migrateSourceConstraintsUp, Down: migrateSourceConstraintsDown},
+	{Version: 6, Name: "archive", Up: migrateArchiveUp, Down: migrateArchiveDown},
+}
+
+// Migrations returns the known migrations in version order.
+func Migrations() []Migration {
+	result := make([]Migration, len(migrations))
+	copy(result, migrations)
+	return result
+}
+
+// LatestSchemaVersion returns the newest schema version.
+func LatestSchemaVersion() int {
+	return migrations[len(migrations)-1].Version
+}
+
+// Migrate brings the database schema to the latest version.
+func (r *Repository) Migrate() error {
+	_, err := r.MigrateTo(context.Background(), LatestSchemaVersion())
+	return err
+}
+
+// SchemaVersion returns the highest applied migration version (0 for an unversioned database).
+func (r *Repository) SchemaVersion(ctx context.Context) (int, error) {
+	applied, err := r.appliedMigrations(ctx)
+	if err != nil {
+		return 0, err
+	}
+	version := 0
+	for v := range applied {
+		if v > version {
+			version = v
+		}
+	}
+	return version, nil
+}
+
+// ErrSchemaOutdated is returned by CheckSchemaVersion when migrations are pending.
+var ErrSchemaOutdated = errors.New("database schema is outdated")
+
+// CheckSchemaVersion returns ErrSchemaOutdated if the database is not at the latest schema
+// version. Unlike SchemaVersion it never writes, so it can check read-only repositories,
+// which do not migrate.
+func (r *Repository) CheckSchemaVersion(ctx context.Context) error {
+	db := r.db.WithContext(ctx)
+	version := 0
+	if db.Migrator().HasTable(&schemaMigration{}) {
+		if err := db.Model(&schemaMigration{}).Select("COALESCE(MAX(version), 0)").Scan(&version).Error; err != nil {
+			return fmt.Errorf("failed to read schema_migrations: %w", err)
+		}
+	}
+
+	latest := LatestSchemaVersion()
+	if version < latest {
+		return fmt.Errorf("%w: version %d, latest %d (run \"moddict db migrate\")", ErrSchemaOutdated, version, latest)
+	}
+	if version > latest {
+		return fmt.Errorf("database schema version %d is newer than this moddict (latest %d)", version, latest)
+	}
+	return nil
+}
+
+// MigrationStatus lists all known migrations and whether they have been applied.
+func (r *Repository) MigrationStatus(ctx context.Context) ([]MigrationState, error) {
+	applied, err := r.appliedMigrations(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	states := make([]MigrationState, 0, len(migrations))
+	for _, m := range migrations {
+		state := MigrationState{Version: m.Version, Name: m.Name}
+		if row, ok := applied[m.Version]; ok {
+			state.Applied = true
+			appliedAt := row.AppliedAt
+			state.AppliedAt = &appliedAt
+		}
+		states = append(states, state)
+	}
+	return states, nil
+}
+
+// MigrateTo applies (or, for a lower target, reverts) migrations until the schema is at the
+// target version. Each step runs in its own transaction. Returns the steps that were run.
+func (r *Repository) MigrateTo(ctx context.Context, target int) ([]Migration, error) {
+	if target < 0 || target > LatestSchemaVersion() {
+		return nil, fmt.Errorf("unknown schema version %d (latest is %d)", target, LatestSchemaVersion())
+	}
+
+	applied, err := r.appliedMigrations(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for v := range applied {
+		if v > LatestSchemaVersion() {
+			return nil, fmt.Errorf("database schema version %d is newer than this moddict (latest %d)", v, LatestSchemaVersion())
+		}
+	}
+
+	var run []Migration
+
+	// Up: apply missing migrations in ascending order
+	for _, m := range migrations {
+		if m.Version > target || applied[m.Version] != nil {
+			continue
+		}
+		err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			if err := m.Up(tx); err != nil {
+				return err
+			}
+			return tx.Create(&schemaMigration{Version: m.Version, Name: m.Name, AppliedAt: time.Now()}).Error
+		})
+		if err != nil {
+			return run, fmt.Errorf("failed to apply migration %d (%s): %w", m.Version, m.Name, err)
+		}
+		run = append(run, m)
+	}
+
+	// Down: revert applied migrations above the target in descending order
+	down := make([]Migration, 0)
+	for _, m := range migrations {
+		if m.Version > target && applied[m.Version] != nil {
+			down = append(down, m)
+		}
+	}
+	sort.Slice(down, func(i, j int) bool { return down[i].Version > down[j].Version })
+	for _, m := range down {
+		err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			if err := m.Down(tx); err != nil {
+				return err
+			}
+			return tx.Delete(&schemaMigration{}, m.Version).Error
+		})
+		if err != nil {
+			return run, fmt.Errorf("failed to revert migration %d (%s): %w", m.Version, m.Name, err)
+		}
+		run = append(run, m)
+	}
+
+	return run, nil
+}
+
+// appliedMigrations returns the rows of schema_migrations, creating the table if needed.
+func (r *Repository) appliedMigrations(ctx context.Context) (map[int]*schemaMigration, error) {
+	db := r.db.WithContext(ctx)
+	if err := execAll(db, `CREATE TABLE IF NOT EXISTS schema_migrations (
+		version integer PRIMARY KEY,
+		name text NOT NULL,
+		applied_at datetime NOT NULL
+	)`); err != nil {
+		return nil, fmt.Errorf("failed to create schema_migrations: %w", err)
+	}
+
+	var rows []*schemaMigration
+	if err := db.Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to read schema_migrations: %w", err)
+	}
+
+	applied := make(map[int]*schemaMigration, len(rows))
+	for _, row := range rows {
+		applied[row.Version] = row
+	}
+	return applied, nil
+}
+
+// postgresTypes rewrites the SQLite column types used by the migrations for PostgreSQL.
+var postgresTypes = strings.NewReplacer(
+	"integer PRIMARY KEY AUTOINCREMENT", "bigserial PRIMARY KEY",
+	"numeric DEFAULT", "boolean DEFAULT",
+	" datetime", " timestamptz",
+)
+
+// execAll runs SQL statements in order. Statements are written for SQLite; on PostgreSQL
+// their column types are rewritten by postgresTypes.
+func execAll(tx *gorm.DB, statements ...string) error {
+	postgres := tx.Dialector.Name() == DialectPostgres
+	for _, stmt := range statements {
+		if postgres {
+			stmt = postgresTypes.Replace(stmt)
+		}
+		if err := tx.Exec(stmt).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// hasColumn reports whether a table has a column. Migrator().HasColumn is not used because
+// on SQLite it matches column names against the CREATE TABLE text ("key" matches "PRIMARY KEY").
+func hasColumn(tx *gorm.DB, table, column string) bool {
+	var count int64
+	if tx.Dialector.Name() == DialectPostgres {
+		tx.Raw(`SELECT count(*) FROM information_schema.columns
+			WHERE table_schema = current_schema() AND table_name = ? AND column_name = ?`, table, column).Scan(&count)
+		return count > 0
+	}
+	tx.Raw(`SELECT count(*) FROM pragma_table_info(?) WHERE name = ?`, table, column).Scan(&count)
+	return count > 0
+}
+
+// HasColumn reports whether a table of the database has a column, for commands that
+// support databases from before a migration.
+func (r *Repository) HasColumn(table, column string) bool {
+	return hasColumn(r.db, table, column)
+}
+
+// ==================== 1: initial schema ====================
+
+// migrateInitialSchemaUp creates the original version-based schema, where translations
+// carried their own key and source text per mod version.
+func migrateInitialSchemaUp(tx *gorm.DB) error {
+	return execAll(tx,
+		`CREATE TABLE IF NOT EXISTS mods (
+			id text,
+			display_name text,
+			author text,
+			description text,
+			tags text,
+			metadata text,
+			created_at datetime,
+			updated_at datetime,
+			PRIMARY KEY (id)
+		)`,
+		`CREATE TABLE IF NOT EXISTS mod_versions (
+			id integer PRIMARY KEY AUTOINCREMENT,
+			mod_id text NOT NULL,
+			version text NOT NULL,
+			mc_version text NOT NULL,
+			loader text,
+			is_default numeric DEFAULT false,
+			parent_version_id integer,
+			total_keys integer,
+			translated_keys integer,
+			verified_keys integer,
+			metadata text,
+			created_at datetime,
+			updated_at datetime
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_mod_versions_mod_id ON mod_versions(mod_id)`,
+		`CREATE TABLE IF NOT EXISTS terms (
+			id integer PRIMARY KEY AUTOINCREMENT,
+			scope text NOT NULL,
+			source_text text NOT NULL,
+			target_text text NOT NULL,
+			source_lang text DEFAULT 'en_us',
+			target_lang text DEFAULT 'ja_jp',
+			context text,
+			tags text,
+			priority integer DEFAULT 100,
+			source text,
+			notes text,
+			created_at datetime,
+			updated_at datetime
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_terms_scope ON terms(scope)`,
+		`CREATE INDEX IF NOT EXISTS idx_terms_target_lang ON terms(target_lang)`,
+		`CREATE TABLE IF NOT EXISTS translations (
+			id integer PRIMARY KEY AUTOINCREMENT,
+			mod_version_id integer,
+			key text,
+			source_text text,
+			source_lang text DEFAULT 'en_us',
+			target_text text,
+			target_lang text DEFAULT 'ja_jp',
+			status text DEFAULT 'pending',
+			translator text,
+			tags text,
+			notes text,
+			created_at datetime,
+			updated_at datetime
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_translations_mod_version_id ON translations(mod_version_id)`,
+		`CREATE INDEX IF NOT EXISTS idx_translations_status ON translations(status)`,
+		`CREATE INDEX IF NOT EXISTS idx_translations_target_lang ON translations(target_lang)`,
+		`CREATE TABLE IF NOT EXISTS file_patterns (
+			id integer PRIMARY KEY AUTOINCREMENT,
+			scope text NOT NULL,
+			pattern text NOT NULL,
+			type text NOT NULL,
+			parser text NOT NULL,
+			priority integer DEFAULT 100,
+			required numeric DEFAULT false,
+			description text
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_file_patterns_scope ON file_patterns(scope)`,
+		`CREATE TABLE IF NOT EXISTS version_diffs (
+			id integer PRIMARY KEY AUTOINCREMENT,
+			from_version_id integer NOT NULL,
+			to_version_id integer NOT NULL,
+			type text NOT NULL,
+			key text NOT NULL,
+			old_text text,
+			new_text text,
+			created_at datetime
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_version_diffs_from_version_id ON version_diffs(from_version_id)`,
+		`CREATE INDEX IF NOT EXISTS idx_version_diffs_to_version_id ON version_diffs(to_version_id)`,
+	)
+}
+
+func migrateInitialSchemaDown(tx *gorm.DB) error {
+	return execAll(tx,
+		`DROP TABLE IF EXISTS version_diffs`,
+		`DROP TABLE IF EXISTS file_patterns`,
+		`DROP TABLE IF EXISTS translations`,
+		`DROP TABLE IF EXISTS terms`,
+		`DROP TABLE IF EXISTS mod_versions`,
+		`DROP TABLE IF EXISTS mods`,
+	)
+}
+
+// ==================== 2: source-based schema ====================
+
+// migrateSourceSchemaUp introduces translation_sources and source_versions, so a source
+// string shared by several mod versions has one translation. Legacy translations are
+// linked to (newly created) sources by mod, key and source text.
+func migrateSourceSchemaUp(tx *gorm.DB) error {
+	if err := execAll(tx,
+		`CREATE TABLE IF NOT EXISTS translation_sources (
+			id integer PRIMARY KEY AUTOINCREMENT,
+			mod_id text NOT NULL,
+			key text NOT NULL,
+			source_text text NOT NULL,
+			source_lang text DEFAULT 'en_us',
+			is_current numeric DEFAULT true,
+			created_at datetime,
+			updated_at datetime
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_translation_sources_mod_id ON translation_sources(mod_id)`,
+		`CREATE INDEX IF NOT EXISTS idx_translation_sources_is_current ON translation_sources(is_current)`,
+		`CREATE TABLE IF NOT EXISTS source_versions (
+			id integer PRIMARY KEY AUTOINCREMENT,
+			source_id integer NOT NULL,
+			mod_version_id integer NOT NULL,
+			created_at datetime
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_source_versions_source_id ON source_versions(source_id)`,
+		`CREATE INDEX IF NOT EXISTS idx_source_versions_mod_version_id ON source_versions(mod_version_id)`,
+	); err != nil {
+		return err
+	}
+
+	if !hasColumn(tx, "translations", "source_id") {
+		if err := tx.Exec(`ALTER TABLE translations ADD COLUMN source_id integer`).Error; err != nil {
+			return err
+		}
+	}
+	if err := tx.Exec(`CREATE INDEX IF NOT EXISTS idx_translations_source_id ON translations(source_id)`).Error; err != nil {
+		return err
+	}
+
+	if !hasColumn(tx, "translations", "mod_version_id") {
+		return nil
+	}
+	return linkLegacyTranslations(tx)
+}
+
+// linkLegacyTranslations creates sources and version links for translations that only have
+// the legacy mod_version_id/key/source_text columns.
+func linkLegacyTranslations(tx *gorm.DB) error {
+	return execAll(tx,
+		`INSERT INTO translation_sources (mod_id, key, source_text, source_lang, is_current, created_at, updated_at)
+		SELECT mv.mod_id, t.key, t.source_text, COALESCE(MIN(NULLIF(t.source_lang, '')), 'en_us'), true, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP
+		FROM translations t
+		JOIN mod_versions mv ON mv.id = t.mod_version_id
+		WHERE (t.source_id IS NULL OR t.source_id = 0)
+			AND t.key IS NOT NULL AND t.key != '' AND t.source_text IS NOT NULL
+			AND NOT EXISTS (
+				SELECT 1 FROM translation_sources s
+				WHERE s.mod_id = mv.mod_id AND s.key = t.key AND s.source_text = t.source_text
+			)
+		GROUP BY mv.mod_id, t.key, t.source_text`,
+		`UPDATE translations SET source_id = (
+			SELECT MIN(s.id) FROM translation_sources s
+			JOIN mod_versions mv ON mv.mod_id = s.mod_id
+			WHERE mv.id = translations.mod_version_id
+				AND s.key = translations.key AND s.source_text = translations.source_text
+		)
+		WHERE (source_id IS NULL OR source_id = 0) AND mod_version_id IS NOT NULL`,
+		`INSERT INTO source_versions (source_id, mod_version_id, created_at)
+		SELECT DISTINCT t.source_id, t.mod_version_id, CURRENT_TIMESTAMP
+		FROM translations t
+		WHERE t.source_id > 0 AND t.mod_version_id > 0
+			AND NOT EXISTS (
+				SELECT 1 FROM source_versions sv
+				WHERE sv.source_id = t.source_id AND sv.mod_version_id = t.mod_version_id
+			)`,
+	)
+}
+
+func migrateSourceSchemaDown(tx *gorm.DB) error {
+	return execAll(tx,
+		`DROP INDEX IF EXISTS idx_translations_source_id`,
+		`ALTER TABLE translations DROP COLUMN source_id`,
+		`DROP TABLE IF EXISTS source_versions`,
+		`DROP TABLE IF EXISTS translation_sources`,
+	)
+}
+
+// ==================== 3: translation memory ====================
+
+func migrateTranslationMemoryUp(tx *gorm.DB) error {
+	return execAll(tx,
+		`CREATE TABLE IF NOT EXISTS translation_memory (
+			id integer PRIMARY KEY AUTOINCREMENT,
+			source_text text NOT NULL,
+			source_lang text DEFAULT 'en_us',
+			target_text text NOT NULL,
+			target_lang text DEFAULT 'ja_jp',
+			origin text,
+			mod_id text,
+			key text,
+			priority integer DEFAULT 10,
+			created_at datetime
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_translation_memory_source_text ON translation_memory(source_text)`,
+		`CREATE INDEX IF NOT EXISTS idx_translation_memory_target_lang ON translation_memory(target_lang)`,
+		`CREATE INDEX IF NOT EXISTS idx_translation_memory_origin ON translation_memory(origin)`,
+	)
+}
+
+func migrateTranslationMemoryDown(tx *gorm.DB) error {
+	return tx.Exec(`DROP TABLE IF EXISTS translation_memory`).Error
+}
+
+// ==================== 4: drop legacy translation columns ====================
+
+// legacyTranslationColumns are the version-based columns superseded by translation_sources.
+var legacyTranslationColumns = []string{"mod_version_id", "key", "source_text", "source_lang"}
+
+// migrateDropLegacyColumnsUp links any remaining legacy translations, deletes translations
+// that still have no source (they are invisible to every query), and drops the legacy columns.
+func migrateDropLegacyColumnsUp(tx *gorm.DB) error {
+	if hasColumn(tx, "translations", "mod_version_id") {
+		if err := linkLegacyTranslations(tx); err != nil {
+			return err
+		}
+	}
+
+	if err := execAll(tx,
+		`DELETE FROM translations WHERE source_id IS NULL OR source_id = 0`,
+		`DROP INDEX IF EXISTS idx_translations_mod_version_id`,
+	); err != nil {
+		return err
+	}
+
+	for _, column := range legacyTranslationColumns {
+		if !hasColumn(tx, "translations", column) {
+			continue
+		}
+		if err := tx.Exec(fmt.Sprintf(`ALTER TABLE translations DROP COLUMN %s`, column)).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// migrateDropLegacyColumnsDown restores the legacy columns from the linked sources.
+// mod_version_id is set to the oldest version linked to the source.
+func migrateDropLegacyColumnsDown(tx *gorm.DB) error {
+	return execAll(tx,
+		`ALTER TABLE translations ADD COLUMN mod_version_id integer`,
+		`ALTER TABLE translations ADD COLUMN key text`,
+		`ALTER TABLE translations ADD COLUMN source_text text`,
+		`ALTER TABLE translations ADD COLUMN source_lang text DEFAULT 'en_us'`,
+		`CREATE INDEX IF NOT EXISTS idx_translations_mod_version_id ON translations(mod_version_id)`,
+		`UPDATE translations SET
+			key = (SELECT s.key FROM translation_sources s WHERE s.id = translations.source_id),
+			source_text = (SELECT s.source_text FROM translation_sources s WHERE s.id = translations.source_id),
+			source_lang = (SELECT s.source_lang FROM translation_sources s WHERE s.id = translations.source_id),
+			mod_version_id = (SELECT MIN(sv.mod_version_id) FROM source_versions sv WHERE sv.source_id = translations.source_id)`,
+	)
+}
+
+// ==================== 5: source constraints ====================
+
+// tableDefinition describes a table of the source schema for rebuilding it.
+type tableDefinition struct {
+	Name        string
+	Columns     []string // column definitions
+	ForeignKeys []string // named table constraints added by migration 5
+	Indexes     []string
+}
+
+// sourceSchemaTables are the tables that gain foreign keys, parents before children.
+var sourceSchemaTables = []tableDefinition{
+	{
+		Name: "mod_versions",
+		Columns: []string{
+			"id integer PRIMARY KEY AUTOINCREMENT",
+			"mod_id text NOT NULL",
+			"version text NOT NULL",
+			"mc_version text NOT NULL",
+			"loader text",
+			"is_default numeric DEFAULT false",
+			"parent_version_id integer",
+			"total_keys integer",
+			"translated_keys integer",
+			"verified_keys integer",
+			"metadata text",
+			"created_at datetime",
+			"updated_at datetime",
+		},
+		ForeignKeys: []string{
+			"CONSTRAINT fk_mod_versions_mod FOREIGN KEY (mod_id) REFERENCES mods(id) ON DELETE CASCADE",
+		},
+		Indexes: []string{
+			`CREATE INDEX IF NOT EXISTS idx_mod_versions_mod_id ON mod_versions(mod_id)`,
+		},
+	},
+	{
+		Name: "translation_sources",
+		Columns: []string{
+			"id integer PRIMARY KEY AUTOINCREMENT",
+			"mod_id text NOT NULL",
+			"key text NOT NULL",
+			"source_text text NOT NULL",
+			"source_lang text DEFAULT 'en_us'",
+			"is_current numeric DEFAULT true",
+			"created_at datetime",
+			"updated_at datetime",
+		},
+		ForeignKeys: []string{
+			"CONSTRAINT fk_translation_sources_mod FOREIGN KEY (mod_id) REFERENCES mods(id) ON DELETE CASCADE",
+		},
+		Indexes: []string{
+			`CREATE INDEX IF NOT EXISTS idx_translation_sources_mod_id ON translation_sources(mod_id)`,
+			`CREATE INDEX IF NOT EXISTS idx_translation_sources_is_current ON translation_sources(is_current)`,
+		},
+	},
+	{
+		Name: "source_versions",
+		Columns: []string{
+			"id integer PRIMARY KEY AUTOINCREMENT",
+			"source_id integer NOT NULL",
+			"mod_version_id integer NOT NULL",
+			"created_at datetime",
+		},
+		ForeignKeys: []string{
+			"CONSTRAINT fk_source_versions_source FOREIGN KEY (source_id) REFERENCES translation_sources(id) ON DELETE CASCADE",
+			"CONSTRAINT fk_source_versions_mod_version FOREIGN KEY (mod_version_id) REFERENCES mod_versions(id) ON DELETE CASCADE",
+		},
+		Indexes: []string{
+			`CREATE INDEX IF NOT EXISTS idx_source_versions_source_id ON source_versions(source_id)`,
+			`CREATE INDEX IF NOT EXISTS idx_source_versions_mod_version_id ON source_versions(mod_version_id)`,
+		},
+	},
+	{
+		Name: "translations",
+		Columns: []string{
+			"id integer PRIMARY KEY AUTOINCREMENT",
+			"source_id integer",
+			"target_text text",
+			"target_lang text DEFAULT 'ja_jp'",
+			"status text DEFAULT 'pending'",
+			"translator text",
+			"tags text",
+			"notes text",
+			"created_at datetime",
+			"updated_at datetime",
+		},
+		ForeignKeys: []string{
+			"CONSTRAINT fk_translations_source FOREIGN KEY (source_id) REFERENCES translation_sources(id) ON DELETE CASCADE",
+		},
+		Indexes: []string{
+			`CREATE INDEX IF NOT EXISTS idx_translations_source_id ON translations(source_id)`,
+			`CREATE INDEX IF NOT EXISTS idx_translations_status ON translations(status)`,
+			`CREATE INDEX IF NOT EXISTS idx_translations_target_lang ON translations(target_lang)`,
+		},
+	},
+}
+
+// sourceUniqueIndexes enforce what GetOrCreateSource, LinkSourceToVersion and
+// SaveTranslation used to check in application code.
+func sourceUniqueIndexes(tx *gorm.DB) []string {
+	sources := `CREATE UNIQUE INDEX IF NOT EXISTS idx_translation_sources_unique ON translation_sources(mod_id, key, source_text)`
+	if tx.Dialector.Name() == DialectPostgres {
+		// B-tree entries are limited to about 2.7kB, which long book pages exceed
+		sources = `CREATE UNIQUE INDEX IF NOT EXISTS idx_translation_sources_unique ON translation_sources(mod_id, key, md5(source_text))`
+	}
+	return []string{
+		sources,
+		`CREATE UNIQUE INDEX IF NOT EXISTS idx_source_versions_unique ON source_versions(source_id, mod_version_id)`,
+		`CREATE UNIQUE INDEX IF NOT EXISTS idx_translations_unique ON translations(source_id, target_lang)`,
+	}
+}
+
+// rebuildTable recreates a table from its definition and copies all rows, since SQLite
+// cannot add or drop foreign keys on an existing table. The old table must not be
+// referenced by foreign keys of other tables, otherwise dropping it deletes their rows.
+func rebuildTable(tx *gorm.DB, def tableDefinition, withForeignKeys bool) error {
+	definitions := def.Columns
+	if withForeignKeys {
+		definitions = append(append([]string{}, def.Columns...), def.ForeignKeys...)
+	}
+
+	columns := make([]string, len(def.Columns))
+	for i, column := range def.Columns {
+		columns[i] = strings.Fields(column)[0]
+	}
+	columnList := strings.Join(columns, ", ")
+
+	tmp := def.Name + "_rebuild"
+	statements := []string{
+		fmt.Sprintf("CREATE TABLE %s (\n\t%s\n)", tmp, strings.Join(definitions, ",\n\t")),
+		fmt.Sprintf("INSERT INTO %s (%s) SELECT %s FROM %s", tmp, columnList, columnList, def.Name),
+		fmt.Sprintf("DROP TABLE %s", def.Name),
+		fmt.Sprintf("ALTER TABLE %s RENAME TO %s", tmp, def.Name),
+	}
+	return execAll(tx, append(statements, def.Indexes...)...)
+}
+
+// alterForeignKeys adds or drops the foreign keys of a table in place, which PostgreSQL
+// supports without rebuilding the table.
+func alterForeignKeys(tx *gorm.DB, def tableDefinition, add bool) error {
+	statements := make([]string, len(def.ForeignKeys))
+	for i, fk := range def.ForeignKeys {
+		if add {
+			statements[i] = fmt.Sprintf("ALTER TABLE %s ADD %s", def.Name, fk)
+		} else {
+			// "CONSTRAINT <name> FOREIGN KEY ..."
+			statements[i] = fmt.Sprintf("ALTER TABLE %s DROP CONSTRAINT IF EXISTS %s", def.Name, strings.Fields(fk)[1])
+		}
+	}
+	return execAll(tx, statements...)
+}
+
+// migrateSourceConstraintsUp resolves existing violations, then adds unique indexes and
+// foreign keys (ON DELETE CASCADE) to the source schema.
+func migrateSourceConstraintsUp(tx *gorm.DB) error {
+	if err := resolveConstraintViolations(tx); err != nil {
+		return fmt.Errorf("failed to resolve constraint violations: %w", err)
+	}
+
+	// Parents first: a rebuilt table is not yet referenced by any foreign key
+	for _, def := range sourceSchemaTables {
+		var err error
+		if tx.Dialector.Name() == DialectPostgres {
+			err = alterForeignKeys(tx, def, true)
+		} else {
+			err = rebuildTable(tx, def, true)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to add foreign keys to %s: %w", def.Name, err)
+		}
+	}
+
+	return execAll(tx, sourceUniqueIndexes(tx)...)
+}
+
+func migrateSourceConstraintsDown(tx *gorm.DB) error {
+	if err := execAll(tx,
+		`DROP INDEX IF EXISTS idx_translations_unique`,
+		`DROP INDEX IF EXISTS idx_source_versions_unique`,
+		`DROP INDEX IF EXISTS idx_translation_sources_unique`,
+	); err != nil {
+		return err
+	}
+
+	// Children first, so no foreign key references a table while it is dropped
+	for i := len(sourceSchemaTables) - 1; i >= 0; i-- {
+		def := sourceSchemaTables[i]
+		var err error
+		if tx.Dialector.Name() == DialectPostgres {
+			err = alterForeignKeys(tx, def, false)
+		} else {
+			err = rebuildTable(tx, def, false)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to drop foreign keys of %s: %w", def.Name, err)
+		}
+	}
+	return nil
+}
+
+// ==================== 6: archive ====================
+
+// archivableTables have an archived_at column. Archived rows are hidden from default
+// queries but keep their sources and translations.
+var archivableTables = []string{"mods", "mod_versions"}
+
+func migrateArchiveUp(tx *gorm.DB) error {
+	for _, table := range archivableTables {
+		if !hasColumn(tx, table, "archived_at") {
+			if err := execAll(tx, fmt.Sprintf(`ALTER TABLE %s ADD COLUMN archived_at datetime`, table)); err != nil {
+				return err
+			}
+		}
+		if err := execAll(tx, fmt.Sprintf(`CREATE INDEX IF NOT EXISTS idx_%s_archived_at ON %s(archived_at)`, table, table)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// migrateArchiveDown drops the archive columns; archived mods and versions become active.
+func migrateArchiveDown(tx *gorm.DB) error {
+	for _, table := range archivableTables {
+		// SQLite cannot drop an indexed column
+		if err := execAll(tx,
+			fmt.Sprintf(`DROP INDEX IF EXISTS idx_%s_archived_at`, table),
+			fmt.Sprintf(`ALTER TABLE %s DROP COLUMN archived_at`, table),
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
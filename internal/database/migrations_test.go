@@ -0,0 +1,320 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"gorm.io/gorm"
+
+	"github.com/iuif/minecraft-mod-dictionary/pkg/interfaces"
+)
+
+// fixtureVersion builds the schema of a historical version under version control.
+func fixtureVersion(t *testing.T, repo *Repository, version int) {
+	t.Helper()
+
+	if _, err := repo.MigrateTo(context.Background(), version); err != nil {
+		t.Fatalf("MigrateTo(%d) error = %v", version, err)
+	}
+}
+
+// seedLegacyTranslations inserts translations that only use the version-based columns.
+func seedLegacyTranslations(t *testing.T, db *gorm.DB) {
+	t.Helper()
+
+	if err := execAll(db,
+		`INSERT INTO mods (id, display_name) VALUES ('testmod', 'Test Mod')`,
+		`INSERT INTO mod_versions (id, mod_id, version, mc_version, is_default) VALUES (1, 'testmod', '1.0.0', '1.20.1', true)`,
+		`INSERT INTO translations (mod_version_id, key, source_text, source_lang, target_text, target_lang, status)
+		VALUES (1, 'item.testmod.wrench', 'Wrench', 'en_us', 'レンチ', 'ja_jp', 'translated')`,
+		`INSERT INTO translations (mod_version_id, key, source_text, source_lang, target_lang, status)
+		VALUES (1, 'item.testmod.gear', 'Gear', 'en_us', 'ja_jp', 'pending')`,
+	); err != nil {
+		t.Fatalf("failed to seed legacy translations: %v", err)
+	}
+}
+
+// seedSourceTranslations inserts a translation linked to a source. With legacy columns the
+// row also carries them, as written by the AutoMigrate-era import.
+func seedSourceTranslations(t *testing.T, db *gorm.DB, legacyColumns bool) {
+	t.Helper()
+
+	statements := []string{
+		`INSERT INTO mods (id, display_name) VALUES ('testmod', 'Test Mod')`,
+		`INSERT INTO mod_versions (id, mod_id, version, mc_version, is_default) VALUES (1, 'testmod', '1.0.0', '1.20.1', true)`,
+		`INSERT INTO translation_sources (id, mod_id, key, source_text, source_lang, is_current)
+		VALUES (10, 'testmod', 'item.testmod.wrench', 'Wrench', 'en_us', true)`,
+		`INSERT INTO source_versions (source_id, mod_version_id) VALUES (10, 1)`,
+	}
+	if legacyColumns {
+		statements = append(statements,
+			`INSERT INTO translations (source_id, mod_version_id, key, source_text, target_text, target_lang, status)
+			VALUES (10, 1, 'item.testmod.wrench', 'Wrench', 'レンチ', 'ja_jp', 'translated')`,
+			// Imported before the source-based schema and never linked
+			`INSERT INTO translations (mod_version_id, key, source_text, target_lang, status)
+			VALUES (1, 'item.testmod.gear', 'Gear', 'ja_jp', 'pending')`,
+		)
+	} else {
+		statements = append(statements,
+			`INSERT INTO translation_sources (id, mod_id, key, source_text, source_lang, is_current)
+			VALUES (11, 'testmod', 'item.testmod.gear', 'Gear', 'en_us', true)`,
+			`INSERT INTO source_versions (source_id, mod_version_id) VALUES (11, 1)`,
+			`INSERT INTO translations (source_id, target_text, target_lang, status) VALUES (10, 'レンチ', 'ja_jp', 'translated')`,
+			`INSERT INTO translations (source_id, target_lang, status) VALUES (11, 'ja_jp', 'pending')`,
+		)
+	}
+	if err := execAll(db, statements...); err != nil {
+		t.Fatalf("failed to seed source translations: %v", err)
+	}
+}
+
+func TestMigrateTo_FromHistoricalVersions(t *testing.T) {
+	tests := []struct {
+		name     string
+		version  int  // schema version of a versioned fixture
+		baseline bool // unversioned schema created by the former AutoMigrate
+		seed     func(t *testing.T, db *gorm.DB)
+	}{
+		{
+			name:     "unversioned schema with legacy translations",
+			baseline: true,
+			seed:     seedLegacyTranslations,
+		},
+		{
+			name:     "unversioned schema with source translations",
+			baseline: true,
+			seed:     func(t *testing.T, db *gorm.DB) { seedSourceTranslations(t, db, true) },
+		},
+		{
+			name:    "version 1",
+			version: 1,
+			seed:    seedLegacyTranslations,
+		},
+		{
+			name:    "version 2",
+			version: 2,
+			seed:    func(t *testing.T, db *gorm.DB) { seedSourceTranslations(t, db, true) },
+		},
+		{
+			name:    "version 3",
+			version: 3,
+			seed:    func(t *testing.T, db *gorm.DB) { seedSourceTranslations(t, db, true) },
+		},
+		{
+			name:    "version 4",
+			version: 4,
+			seed:    func(t *testing.T, db *gorm.DB) { seedSourceTranslations(t, db, false) },
+		},
+		{
+			name:    "version 5",
+			version: ConstraintsSchemaVersion,
+			seed:    func(t *testing.T, db *gorm.DB) { seedSourceTranslations(t, db, false) },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			path := testDSN(t)
+
+			fixture, err := NewRepository(path)
+			if err != nil {
+				t.Fatalf("NewRepository() error = %v", err)
+			}
+			if tt.baseline {
+				fixtureBaselineSchema(t, fixture)
+			} else {
+				fixtureVersion(t, fixture, tt.version)
+			}
+			tt.seed(t, fixture.GetDB())
+			fixture.Close()
+
+			repo, err := NewRepository(path)
+			if err != nil {
+				t.Fatalf("NewRepository() error = %v", err)
+			}
+			defer repo.Close()
+
+			if err := repo.Migrate(); err != nil {
+				t.Fatalf("Migrate() error = %v", err)
+			}
+
+			version, err := repo.SchemaVersion(ctx)
+			if err != nil {
+				t.Fatalf("SchemaVersion() error = %v", err)
+			}
+			if version != LatestSchemaVersion() {
+				t.Errorf("SchemaVersion() = %d, want %d", version, LatestSchemaVersion())
+			}
+
+			for _, column := range legacyTranslationColumns {
+				if hasColumn(repo.GetDB(), "translations", column) {
+					t.Errorf("translations.%s still exists after Migrate()", column)
+				}
+			}
+			for _, table := range archivableTables {
+				if !hasColumn(repo.GetDB(), table, "archived_at") {
+					t.Errorf("%s.archived_at missing after Migrate()", table)
+				}
+			}
+
+			got, err := repo.GetTranslation(ctx, 1, "item.testmod.wrench")
+			if err != nil {
+				t.Fatalf("GetTranslation() error = %v", err)
+			}
+			if got.TargetText == nil || *got.TargetText != "レンチ" {
+				t.Errorf("GetTranslation() TargetText = %v, want レンチ", got.TargetText)
+			}
+
+			translations, err := repo.ListTranslationsWithSourceByMod(ctx, "testmod", interfaces.TranslationFilter{TargetLang: "ja_jp"})
+			if err != nil {
+				t.Fatalf("ListTranslationsWithSourceByMod() error = %v", err)
+			}
+			if len(translations) != 2 {
+				t.Errorf("ListTranslationsWithSourceByMod() got %d, want 2", len(translations))
+			}
+
+			// Migrating again is a no-op
+			run, err := repo.MigrateTo(ctx, LatestSchemaVersion())
+			if err != nil {
+				t.Fatalf("MigrateTo() error = %v", err)
+			}
+			if len(run) != 0 {
+				t.Errorf("MigrateTo() on an up-to-date database ran %d migrations", len(run))
+			}
+		})
+	}
+}
+
+func TestMigrateTo_DownAndUp(t *testing.T) {
+	repo := setupTestRepository(t)
+	ctx := context.Background()
+	seedSourceTranslations(t, repo.GetDB(), false)
+
+	run, err := repo.MigrateTo(ctx, 2)
+	if err != nil {
+		t.Fatalf("MigrateTo(2) error = %v", err)
+	}
+	if len(run) != LatestSchemaVersion()-2 {
+		t.Fatalf("MigrateTo(2) ran %d migrations, want %d", len(run), LatestSchemaVersion()-2)
+	}
+	for i, m := range run {
+		if want := LatestSchemaVersion() - i; m.Version != want {
+			t.Errorf("MigrateTo(2) step %d reverted %d, want %d", i, m.Version, want)
+		}
+	}
+	if !hasColumn(repo.GetDB(), "translations", "key") {
+		t.Error("MigrateTo(2) did not restore translations.key")
+	}
+	if repo.GetDB().Migrator().HasTable("translation_memory") {
+		t.Error("MigrateTo(2) did not drop translation_memory")
+	}
+	if repo.HasColumn("mod_versions", "archived_at") {
+		t.Error("MigrateTo(2) did not drop mod_versions.archived_at")
+	}
+
+	var key string
+	repo.GetDB().Raw(`SELECT key FROM translations WHERE source_id = 10`).Scan(&key)
+	if key != "item.testmod.wrench" {
+		t.Errorf("restored key = %q, want item.testmod.wrench", key)
+	}
+
+	if err := repo.Migrate(); err != nil {
+		t.Fatalf("Migrate() error = %v", err)
+	}
+	if !repo.HasColumn("mod_versions", "archived_at") {
+		t.Error("Migrate() did not add mod_versions.archived_at")
+	}
+	states, err := repo.MigrationStatus(ctx)
+	if err != nil {
+		t.Fatalf("MigrationStatus() error = %v", err)
+	}
+	for _, s := range states {
+		if !s.Applied || s.AppliedAt == nil {
+			t.Errorf("migration %d (%s) not applied after Migrate()", s.Version, s.Name)
+		}
+	}
+
+	if _, err := repo.GetTranslation(ctx, 1, "item.testmod.gear"); err != nil {
+		t.Errorf("GetTranslation() after down/up error = %v", err)
+	}
+}
+
+func TestMigrateTo_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		setup  func(t *testing.T, repo *Repository)
+		target int
+	}{
+		{
+			name:   "unknown target version",
+			target: LatestSchemaVersion() + 1,
+		},
+		{
+			name:   "negative target version",
+			target: -1,
+		},
+		{
+			name: "database newer than this build",
+			setup: func(t *testing.T, repo *Repository) {
+				if err := repo.GetDB().Exec(`INSERT INTO schema_migrations (version, name, applied_at) VALUES (?, 'future', CURRENT_TIMESTAMP)`,
+					LatestSchemaVersion()+1).Error; err != nil {
+					t.Fatalf("failed to insert future migration: %v", err)
+				}
+			},
+			target: LatestSchemaVersion(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := setupTestRepository(t)
+			if tt.setup != nil {
+				tt.setup(t, repo)
+			}
+			if _, err := repo.MigrateTo(context.Background(), tt.target); err == nil {
+				t.Errorf("MigrateTo(%d) expected error", tt.target)
+			}
+		})
+	}
+}
+
+func TestCheckSchemaVersion(t *testing.T) {
+	ctx := context.Background()
+	dsn := testDSN(t)
+
+	rw, err := NewRepository(dsn)
+	if err != nil {
+		t.Fatalf("NewRepository() error = %v", err)
+	}
+	defer rw.Close()
+
+	ro, err := NewReadOnlyRepository(dsn)
+	if err != nil {
+		t.Fatalf("NewReadOnlyRepository() error = %v", err)
+	}
+	defer ro.Close()
+
+	if err := ro.CheckSchemaVersion(ctx); !errors.Is(err, ErrSchemaOutdated) {
+		t.Errorf("CheckSchemaVersion() on an empty database error = %v, want ErrSchemaOutdated", err)
+	}
+
+	if _, err := rw.MigrateTo(ctx, ConstraintsSchemaVersion); err != nil {
+		t.Fatalf("MigrateTo(%d) error = %v", ConstraintsSchemaVersion, err)
+	}
+	err = ro.CheckSchemaVersion(ctx)
+	if !errors.Is(err, ErrSchemaOutdated) {
+		t.Errorf("CheckSchemaVersion() at version %d error = %v, want ErrSchemaOutdated", ConstraintsSchemaVersion, err)
+	} else if !strings.Contains(err.Error(), "moddict db migrate") {
+		t.Errorf("CheckSchemaVersion() error = %q, want a hint to run moddict db migrate", err)
+	}
+
+	if err := rw.Migrate(); err != nil {
+		t.Fatalf("Migrate() error = %v", err)
+	}
+	if err := ro.CheckSchemaVersion(ctx); err != nil {
+		t.Errorf("CheckSchemaVersion() after Migrate() error = %v", err)
+	}
+}
@@ -1,13 +1,20 @@
-// Package database provides SQLite implementation of the Repository interface.
+// Package database provides the SQLite and PostgreSQL implementation of the Repository interface.
 package database
 
 import (
 	"context"
 	"errors"
 	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
 
+	"gorm.io/driver/postgres"
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 	"gorm.io/gorm/logger"
 
 	"github.com/iuif/minecraft-mod-dictionary/pkg/interfaces"
@@ -16,39 +23,161 @@ import (
 
 // Errors
 var (
-	ErrModNotFound         = errors.New("mod not found")
-	ErrTermNotFound        = errors.New("term not found")
-	ErrVersionNotFound     = errors.New("version not found")
-	ErrTranslationNotFound = errors.New("translation not found")
-	ErrPatternNotFound     = errors.New("pattern not found")
+	ErrModNotFound         = interfaces.ErrModNotFound
+	ErrTermNotFound        = interfaces.ErrTermNotFound
+	ErrVersionNotFound     = interfaces.ErrVersionNotFound
+	ErrTranslationNotFound = interfaces.ErrTranslationNotFound
+	ErrPatternNotFound     = interfaces.ErrPatternNotFound
+	ErrSourceNotFound      = interfaces.ErrSourceNotFound
+	ErrModArchived         = interfaces.ErrModArchived
+
+	// ErrRollback can be returned from a WithTx callback to discard its changes.
+	// WithTx then returns nil, which makes dry runs look like successful runs.
+	ErrRollback = errors.New("transaction rolled back")
 )
 
-// Repository implements interfaces.Repository using SQLite.
+// Repository implements interfaces.Repository using SQLite or PostgreSQL.
 type Repository struct {
 	db *gorm.DB
 }
 
-// Compile-time check that Repository implements interfaces.Repository.
-var _ interfaces.Repository = (*Repository)(nil)
+// Compile-time check that Repository implements interfaces.SourceRepository.
+var _ interfaces.SourceRepository = (*Repository)(nil)
 
-// NewRepository creates a new SQLite repository.
-// Use ":memory:" for in-memory database or a file path for persistent storage.
+// Dialect names as reported by Repository.Dialect.
+const (
+	DialectSQLite   = "sqlite"
+	DialectPostgres = "postgres"
+)
+
+// Connection pool and SQLite locking settings. SQLite runs in WAL mode, so readers never
+// block the writer; concurrent writers wait up to sqliteBusyTimeout for the write lock.
+const (
+	maxOpenConns      = 8
+	maxIdleConns      = 4
+	connMaxLifetime   = time.Hour
+	sqliteBusyTimeout = 10 * time.Second
+)
+
+// NewRepository creates a new repository. The backend is chosen by the DSN scheme:
+// "postgres://" or "postgresql://" URLs open PostgreSQL, anything else is a SQLite
+// file path (use ":memory:" for an in-memory database).
 func NewRepository(dsn string) (*Repository, error) {
-	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{
+	return openRepository(dsn, false)
+}
+
+// NewReadOnlyRepository opens an existing database for reading only, so commands such as
+// view and export can run while another process is importing. Writes return an error.
+func NewReadOnlyRepository(dsn string) (*Repository, error) {
+	return openRepository(dsn, true)
+}
+
+func openRepository(dsn string, readOnly bool) (*Repository, error) {
+	var dialector gorm.Dialector
+	if IsPostgresDSN(dsn) {
+		if readOnly {
+			dsn = withQueryParam(dsn, "default_transaction_read_only", "on")
+		}
+		dialector = postgres.Open(dsn)
+	} else {
+		if readOnly && !isMemoryDSN(dsn) {
+			// The SQLite driver creates missing files even with query_only set
+			if _, err := os.Stat(sqlitePath(dsn)); err != nil {
+				return nil, fmt.Errorf("database not found: %w", err)
+			}
+		}
+		dialector = sqlite.Open(sqliteDSN(dsn, readOnly))
+	}
+
+	db, err := gorm.Open(dialector, &gorm.Config{
 		Logger: logger.Default.LogMode(logger.Silent),
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to open database: %w", err)
 	}
 
-	// Configure SQLite for better performance and compatibility (especially WSL2)
-	db.Exec("PRAGMA journal_mode=MEMORY")
-	db.Exec("PRAGMA synchronous=NORMAL")
-	db.Exec("PRAGMA cache_size=10000")
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sql.DB: %w", err)
+	}
+	if isMemoryDSN(dsn) {
+		// Every connection to ":memory:" is a separate database
+		sqlDB.SetMaxOpenConns(1)
+	} else {
+		sqlDB.SetMaxOpenConns(maxOpenConns)
+		sqlDB.SetMaxIdleConns(maxIdleConns)
+		sqlDB.SetConnMaxLifetime(connMaxLifetime)
+	}
 
 	return &Repository{db: db}, nil
 }
 
+// IsPostgresDSN reports whether a DSN selects the PostgreSQL backend.
+func IsPostgresDSN(dsn string) bool {
+	return strings.HasPrefix(dsn, "postgres://") || strings.HasPrefix(dsn, "postgresql://")
+}
+
+// isMemoryDSN reports whether a SQLite DSN opens an in-memory database.
+func isMemoryDSN(dsn string) bool {
+	return sqlitePath(dsn) == ":memory:" || strings.Contains(dsn, "mode=memory")
+}
+
+// sqlitePath returns the file path of a SQLite DSN without its query parameters.
+func sqlitePath(dsn string) string {
+	path, _, _ := strings.Cut(dsn, "?")
+	return strings.TrimPrefix(path, "file:")
+}
+
+// sqliteDSN adds the connection settings to a SQLite DSN. They are applied by the driver to
+// every connection of the pool, unlike PRAGMA statements run once after opening. Settings
+// already present in the DSN are kept, e.g. "moddict.db?_journal_mode=DELETE" for file
+// systems without shared memory support.
+func sqliteDSN(dsn string, readOnly bool) string {
+	path, query, _ := strings.Cut(dsn, "?")
+	params, err := url.ParseQuery(query)
+	if err != nil {
+		params = url.Values{}
+	}
+
+	defaults := map[string]string{
+		"_foreign_keys": "on",
+		"_busy_timeout": strconv.FormatInt(sqliteBusyTimeout.Milliseconds(), 10),
+		"_synchronous":  "NORMAL",
+		"_cache_size":   "10000",
+	}
+	if readOnly {
+		defaults["_query_only"] = "true"
+	} else {
+		defaults["_journal_mode"] = "WAL"
+		// Take the write lock when the transaction starts, so a transaction that reads
+		// first waits for the busy timeout instead of failing on lock upgrade
+		defaults["_txlock"] = "immediate"
+	}
+	for key, value := range defaults {
+		if !params.Has(key) {
+			params.Set(key, value)
+		}
+	}
+	return path + "?" + params.Encode()
+}
+
+// withQueryParam sets a query parameter of a URL DSN.
+func withQueryParam(dsn, key, value string) string {
+	u, err := url.Parse(dsn)
+	if err != nil {
+		return dsn
+	}
+	query := u.Query()
+	query.Set(key, value)
+	u.RawQuery = query.Encode()
+	return u.String()
+}
+
+// Dialect returns the backend name (DialectSQLite or DialectPostgres).
+func (r *Repository) Dialect() string {
+	return r.db.Dialector.Name()
+}
+
 // Close closes the database connection.
 func (r *Repository) Close() error {
 	sqlDB, err := r.db.DB()
@@ -63,18 +192,17 @@ func (r *Repository) DB() *gorm.DB {
 	return r.db
 }
 
-// Migrate runs database migrations.
-func (r *Repository) Migrate() error {
-	return r.db.AutoMigrate(
-		&models.Mod{},
-		&models.ModVersion{},
-		&models.Term{},
-		&models.TranslationSource{},
-		&models.SourceVersion{},
-		&models.Translation{},
-		&models.FilePattern{},
-		&models.VersionDiff{},
-	)
+// WithTx runs fn with a repository bound to a single transaction. The transaction commits
+// if fn returns nil and rolls back otherwise, so all changes made through txRepo are applied
+// as a unit. fn must not use the outer repository, whose connection would wait on the lock.
+func (r *Repository) WithTx(ctx context.Context, fn func(txRepo *Repository) error) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(&Repository{db: tx})
+	})
+	if errors.Is(err, ErrRollback) {
+		return nil
+	}
+	return err
 }
 
 // GetMod retrieves a mod by ID.
@@ -94,6 +222,9 @@ func (r *Repository) ListMods(ctx context.Context, filter interfaces.ModFilter)
 	var mods []*models.Mod
 	query := r.db.WithContext(ctx)
 
+	if !filter.IncludeArchived {
+		query = query.Where("archived_at IS NULL")
+	}
 	if filter.Limit > 0 {
 		query = query.Limit(filter.Limit)
 	}
@@ -165,6 +296,9 @@ func (r *Repository) ListVersions(ctx context.Context, modID string, filter inte
 	if filter.Loader != "" {
 		query = query.Where("loader = ?", filter.Loader)
 	}
+	if !filter.IncludeArchived {
+		query = query.Where("archived_at IS NULL")
+	}
 	if filter.Limit > 0 {
 		query = query.Limit(filter.Limit)
 	}
@@ -187,11 +321,32 @@ func (r *Repository) SaveVersion(ctx context.Context, version *models.ModVersion
 	return nil
 }
 
-// DeleteVersion deletes a version by ID.
+// DeleteVersion deletes a version by ID. Sources left without any version are deleted with
+// their translations.
 func (r *Repository) DeleteVersion(ctx context.Context, id int64) error {
-	result := r.db.WithContext(ctx).Delete(&models.ModVersion{}, id)
-	if result.Error != nil {
-		return fmt.Errorf("failed to delete version %d: %w", id, result.Error)
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var sourceIDs []int64
+		if err := tx.Model(&models.SourceVersion{}).
+			Where("mod_version_id = ?", id).
+			Pluck("source_id", &sourceIDs).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(&models.ModVersion{}, id).Error; err != nil {
+			return err
+		}
+		// Links of the version are removed by ON DELETE CASCADE
+		for start := 0; start < len(sourceIDs); start += keyBatchSize {
+			end := min(start+keyBatchSize, len(sourceIDs))
+			if err := tx.
+				Where("id IN ? AND NOT EXISTS (SELECT 1 FROM source_versions WHERE source_versions.source_id = translation_sources.id)", sourceIDs[start:end]).
+				Delete(&models.TranslationSource{}).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete version %d: %w", id, err)
 	}
 	return nil
 }
@@ -282,8 +437,8 @@ func (r *Repository) BulkSaveTerms(ctx context.Context, terms []*models.Term) er
 // GetTranslation retrieves a translation by version ID and key.
 func (r *Repository) GetTranslation(ctx context.Context, versionID int64, key string) (*models.Translation, error) {
 	var trans models.Translation
-	err := r.db.WithContext(ctx).
-		Where("mod_version_id = ? AND key = ?", versionID, key).
+	err := r.translationsByVersionQuery(ctx, versionID).
+		Where("translation_sources.key = ?", key).
 		First(&trans).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -297,13 +452,13 @@ func (r *Repository) GetTranslation(ctx context.Context, versionID int64, key st
 // ListTranslations retrieves translations for a version matching the filter.
 func (r *Repository) ListTranslations(ctx context.Context, versionID int64, filter interfaces.TranslationFilter) ([]*models.Translation, error) {
 	var translations []*models.Translation
-	query := r.db.WithContext(ctx).Where("mod_version_id = ?", versionID)
+	query := r.translationsByVersionQuery(ctx, versionID)
 
 	if filter.TargetLang != "" {
-		query = query.Where("target_lang = ?", filter.TargetLang)
+		query = query.Where("translations.target_lang = ?", filter.TargetLang)
 	}
 	if filter.Status != "" {
-		query = query.Where("status = ?", filter.Status)
+		query = query.Where("translations.status = ?", filter.Status)
 	}
 	if filter.Limit > 0 {
 		query = query.Limit(filter.Limit)
@@ -319,9 +474,19 @@ func (r *Repository) ListTranslations(ctx context.Context, versionID int64, filt
 	return translations, nil
 }
 
-// SaveTranslation creates or updates a translation.
+// translationsByVersionQuery selects the translations of the sources linked to a version.
+func (r *Repository) translationsByVersionQuery(ctx context.Context, versionID int64) *gorm.DB {
+	return r.db.WithContext(ctx).
+		Select("translations.*").
+		Joins("JOIN translation_sources ON translation_sources.id = translations.source_id").
+		Joins("JOIN source_versions ON source_versions.source_id = translation_sources.id").
+		Where("source_versions.mod_version_id = ?", versionID)
+}
+
+// SaveTranslation creates or updates a translation. A new translation for a source and
+// language that already has one updates the existing row and takes its ID.
 func (r *Repository) SaveTranslation(ctx context.Context, translation *models.Translation) error {
-	if err := r.db.WithContext(ctx).Save(translation).Error; err != nil {
+	if err := saveTranslation(r.db.WithContext(ctx), translation); err != nil {
 		return fmt.Errorf("failed to save translation: %w", err)
 	}
 	return nil
@@ -331,7 +496,7 @@ func (r *Repository) SaveTranslation(ctx context.Context, translation *models.Tr
 func (r *Repository) BulkSaveTranslations(ctx context.Context, translations []*models.Translation) error {
 	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		for _, trans := range translations {
-			if err := tx.Save(trans).Error; err != nil {
+			if err := saveTranslation(tx, trans); err != nil {
 				return fmt.Errorf("failed to save translation: %w", err)
 			}
 		}
@@ -339,6 +504,17 @@ func (r *Repository) BulkSaveTranslations(ctx context.Context, translations []*m
 	})
 }
 
+// saveTranslation updates a translation by ID, or upserts it on source_id + target_lang.
+func saveTranslation(tx *gorm.DB, translation *models.Translation) error {
+	if translation.ID != 0 {
+		return tx.Save(translation).Error
+	}
+	return tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "source_id"}, {Name: "target_lang"}},
+		DoUpdates: clause.AssignmentColumns([]string{"target_text", "status", "translator", "tags", "notes", "updated_at"}),
+	}).Create(translation).Error
+}
+
 // DeleteTranslation deletes a translation by ID.
 func (r *Repository) DeleteTranslation(ctx context.Context, id int64) error {
 	result := r.db.WithContext(ctx).Delete(&models.Translation{}, id)
@@ -438,7 +614,7 @@ func (r *Repository) GetSource(ctx context.Context, id int64) (*models.Translati
 	var source models.TranslationSource
 	if err := r.db.WithContext(ctx).First(&source, id).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, fmt.Errorf("source not found")
+			return nil, ErrSourceNotFound
 		}
 		return nil, fmt.Errorf("failed to get source %d: %w", id, err)
 	}
@@ -514,10 +690,16 @@ func (r *Repository) LinkSourceToVersion(ctx context.Context, sourceID, versionI
 		SourceID:     sourceID,
 		ModVersionID: versionID,
 	}
-	// Use FirstOrCreate to avoid duplicates
-	return r.db.WithContext(ctx).
-		Where("source_id = ? AND mod_version_id = ?", sourceID, versionID).
-		FirstOrCreate(sv).Error
+	err := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "source_id"}, {Name: "mod_version_id"}},
+			DoNothing: true,
+		}).
+		Create(sv).Error
+	if err != nil {
+		return fmt.Errorf("failed to link source %d to version %d: %w", sourceID, versionID, err)
+	}
+	return nil
 }
 
 // GetSourceVersions retrieves all version links for a source.
@@ -580,9 +762,24 @@ func (r *Repository) GetDB() *gorm.DB {
 // ListTranslationsWithSourceByMod retrieves translations with source info for a mod's default version.
 // This is the primary query method for the new schema.
 func (r *Repository) ListTranslationsWithSourceByMod(ctx context.Context, modID string, filter interfaces.TranslationFilter) ([]*models.TranslationWithSource, error) {
-	var results []*models.TranslationWithSource
+	query := r.translationsWithSourceQuery(ctx).
+		Where("translation_sources.mod_id = ? AND mod_versions.is_default = ? AND mod_versions.archived_at IS NULL", modID, true)
 
-	query := r.db.WithContext(ctx).
+	return r.scanTranslationsWithSource(query, filter)
+}
+
+// ListTranslationsWithSourceByVersion retrieves translations with source info for the sources
+// linked to a specific mod version (via source_versions), regardless of the default version.
+func (r *Repository) ListTranslationsWithSourceByVersion(ctx context.Context, versionID int64, filter interfaces.TranslationFilter) ([]*models.TranslationWithSource, error) {
+	query := r.translationsWithSourceQuery(ctx).
+		Where("mod_versions.id = ?", versionID)
+
+	return r.scanTranslationsWithSource(query, filter)
+}
+
+// translationsWithSourceQuery builds the base translation + source + version join.
+func (r *Repository) translationsWithSourceQuery(ctx context.Context) *gorm.DB {
+	return r.db.WithContext(ctx).
 		Table("translations").
 		Select(`
 			DISTINCT translations.id,
@@ -591,8 +788,11 @@ func (r *Repository) ListTranslationsWithSourceByMod(ctx context.Context, modID
 			translations.target_lang,
 			translations.status,
 			translations.translator,
+			translations.tags,
+			translations.notes,
 			translations.created_at,
 			translations.updated_at,
+			translation_sources.mod_id as mod_id,
 			translation_sources.key as key,
 			translation_sources.source_text as source_text,
 			translation_sources.source_lang as source_lang,
@@ -600,8 +800,12 @@ func (r *Repository) ListTranslationsWithSourceByMod(ctx context.Context, modID
 		`).
 		Joins("JOIN translation_sources ON translation_sources.id = translations.source_id").
 		Joins("JOIN source_versions ON source_versions.source_id = translation_sources.id").
-		Joins("JOIN mod_versions ON mod_versions.id = source_versions.mod_version_id").
-		Where("translation_sources.mod_id = ? AND mod_versions.is_default = ?", modID, true)
+		Joins("JOIN mod_versions ON mod_versions.id = source_versions.mod_version_id")
+}
+
+// scanTranslationsWithSource applies the filter to a translationsWithSourceQuery and scans the results.
+func (r *Repository) scanTranslationsWithSource(query *gorm.DB, filter interfaces.TranslationFilter) ([]*models.TranslationWithSource, error) {
+	var results []*models.TranslationWithSource
 
 	if filter.TargetLang != "" {
 		query = query.Where("translations.target_lang = ?", filter.TargetLang)
@@ -623,6 +827,143 @@ func (r *Repository) ListTranslationsWithSourceByMod(ctx context.Context, modID
 	return results, nil
 }
 
+// keyBatchSize is the number of keys per IN (...) query. SQLite builds before 3.32 allow
+// at most 999 bound parameters per statement.
+const keyBatchSize = 500
+
+// ListTranslationsWithSourceByModKeys retrieves the translations of the given keys in a
+// mod's default version. Keys are queried in batches of keyBatchSize; keys without a
+// translation are left out. filter.Limit and filter.Offset are ignored.
+func (r *Repository) ListTranslationsWithSourceByModKeys(ctx context.Context, modID string, keys []string, filter interfaces.TranslationFilter) ([]*models.TranslationWithSource, error) {
+	return r.listTranslationsWithSourceByKeys(keys, filter, func() *gorm.DB {
+		return r.translationsWithSourceQuery(ctx).
+			Where("translation_sources.mod_id = ? AND mod_versions.is_default = ? AND mod_versions.archived_at IS NULL", modID, true)
+	})
+}
+
+// ListTranslationsWithSourceByVersionKeys retrieves the translations of the given keys in a
+// mod version, batched like ListTranslationsWithSourceByModKeys.
+func (r *Repository) ListTranslationsWithSourceByVersionKeys(ctx context.Context, versionID int64, keys []string, filter interfaces.TranslationFilter) ([]*models.TranslationWithSource, error) {
+	return r.listTranslationsWithSourceByKeys(keys, filter, func() *gorm.DB {
+		return r.translationsWithSourceQuery(ctx).
+			Where("mod_versions.id = ?", versionID)
+	})
+}
+
+// listTranslationsWithSourceByKeys runs base() once per batch of distinct keys.
+func (r *Repository) listTranslationsWithSourceByKeys(keys []string, filter interfaces.TranslationFilter, base func() *gorm.DB) ([]*models.TranslationWithSource, error) {
+	filter.Limit, filter.Offset = 0, 0
+
+	seen := make(map[string]bool, len(keys))
+	unique := make([]string, 0, len(keys))
+	for _, k := range keys {
+		if !seen[k] {
+			seen[k] = true
+			unique = append(unique, k)
+		}
+	}
+
+	var results []*models.TranslationWithSource
+	for start := 0; start < len(unique); start += keyBatchSize {
+		end := min(start+keyBatchSize, len(unique))
+		batch, err := r.scanTranslationsWithSource(
+			base().Where("translation_sources.key IN ?", unique[start:end]), filter)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, batch...)
+	}
+	return results, nil
+}
+
+// StreamTranslationsWithSourceByMod calls fn for each translation of a mod's default
+// version in ID order, reading them page by page with IterateTranslations.
+// An error from fn stops the iteration and is returned.
+func (r *Repository) StreamTranslationsWithSourceByMod(ctx context.Context, modID string, filter interfaces.TranslationFilter, fn func(*models.TranslationWithSource) error) error {
+	iter := interfaces.TranslationIterFilter{ModID: modID, TargetLang: filter.TargetLang}
+	if filter.Status != "" {
+		iter.Statuses = []string{filter.Status}
+	}
+
+	var seen int
+	err := r.IterateTranslations(ctx, iter, func(t *models.TranslationWithSource) error {
+		seen++
+		if seen <= filter.Offset {
+			return nil
+		}
+		if filter.Limit > 0 && seen > filter.Offset+filter.Limit {
+			return errStopIteration
+		}
+		return fn(t)
+	})
+	if errors.Is(err, errStopIteration) {
+		return nil
+	}
+	return err
+}
+
+// errStopIteration ends an IterateTranslations call early without an error.
+var errStopIteration = errors.New("stop iteration")
+
+// defaultIterBatchSize is the page size of IterateTranslations when the filter sets none.
+const defaultIterBatchSize = 1000
+
+// IterateTranslations calls fn for each translation matching the filter in ID order. Rows
+// are read in pages of filter.BatchSize using keyset pagination (translations.id > last),
+// so memory stays bounded by one page and no query stays open while fn runs; fn may use
+// the repository, including writes. Rows changed during the iteration may or may not be
+// seen. An error from fn stops the iteration and is returned.
+func (r *Repository) IterateTranslations(ctx context.Context, filter interfaces.TranslationIterFilter, fn func(*models.TranslationWithSource) error) error {
+	batchSize := filter.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultIterBatchSize
+	}
+
+	var lastID int64
+	for {
+		query := r.translationsWithSourceQuery(ctx).
+			Where("translations.id > ?", lastID).
+			Order("translations.id").
+			Limit(batchSize)
+
+		if filter.VersionID != 0 {
+			query = query.Where("mod_versions.id = ?", filter.VersionID)
+		} else {
+			query = query.Where("mod_versions.is_default = ?", true)
+			if !filter.IncludeArchived {
+				query = query.Where("mod_versions.archived_at IS NULL")
+			}
+		}
+		if filter.ModID != "" {
+			query = query.Where("translation_sources.mod_id = ?", filter.ModID)
+		}
+		if filter.TargetLang != "" {
+			query = query.Where("translations.target_lang = ?", filter.TargetLang)
+		}
+		if len(filter.Statuses) > 0 {
+			query = query.Where("translations.status IN ?", filter.Statuses)
+		}
+		if filter.TranslatedOnly {
+			query = query.Where("translations.target_text IS NOT NULL AND translations.target_text != ''")
+		}
+
+		var page []*models.TranslationWithSource
+		if err := query.Scan(&page).Error; err != nil {
+			return fmt.Errorf("failed to iterate translations: %w", err)
+		}
+
+		for _, t := range page {
+			if err := fn(t); err != nil {
+				return err
+			}
+		}
+		if len(page) < batchSize {
+			return nil
+		}
+		lastID = page[len(page)-1].ID
+	}
+}
+
 // GetTranslationBySourceID retrieves a translation by source ID for ja_jp language.
 func (r *Repository) GetTranslationBySourceID(ctx context.Context, sourceID int64) (*models.Translation, error) {
 	var trans models.Translation
@@ -639,14 +980,14 @@ func (r *Repository) GetTranslationBySourceID(ctx context.Context, sourceID int6
 }
 
 // GetSourceByModAndKey retrieves a source by mod ID and key.
-// Prioritizes sources linked to the default (is_default=true) version.
+// Prioritizes sources linked to the default (is_default=true) version unless it is archived.
 func (r *Repository) GetSourceByModAndKey(ctx context.Context, modID, key string) (*models.TranslationSource, error) {
 	var source models.TranslationSource
 	err := r.db.WithContext(ctx).
 		Joins("LEFT JOIN source_versions sv ON translation_sources.id = sv.source_id").
 		Joins("LEFT JOIN mod_versions mv ON sv.mod_version_id = mv.id").
 		Where("translation_sources.mod_id = ? AND translation_sources.key = ?", modID, key).
-		Order("CASE WHEN mv.is_default = 1 THEN 0 ELSE 1 END, translation_sources.id DESC").
+		Order("CASE WHEN mv.is_default AND mv.archived_at IS NULL THEN 0 ELSE 1 END, translation_sources.id DESC").
 		First(&source).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -673,7 +1014,7 @@ func (r *Repository) CountTranslationsByMod(ctx context.Context, modID string) (
 		Joins("JOIN translation_sources ON translation_sources.id = translations.source_id").
 		Joins("JOIN source_versions ON source_versions.source_id = translation_sources.id").
 		Joins("JOIN mod_versions ON mod_versions.id = source_versions.mod_version_id").
-		Where("translation_sources.mod_id = ? AND mod_versions.is_default = ?", modID, true).
+		Where("translation_sources.mod_id = ? AND mod_versions.is_default = ? AND mod_versions.archived_at IS NULL", modID, true).
 		Group("translations.status").
 		Scan(&counts).Error
 	if err != nil {
@@ -692,7 +1033,7 @@ func (r *Repository) CountTranslationsByMod(ctx context.Context, modID string) (
 		Joins("JOIN source_versions ON source_versions.source_id = translation_sources.id").
 		Joins("JOIN mod_versions ON mod_versions.id = source_versions.mod_version_id").
 		Joins("LEFT JOIN translations ON translations.source_id = translation_sources.id AND translations.target_lang = 'ja_jp'").
-		Where("translation_sources.mod_id = ? AND mod_versions.is_default = ? AND translations.id IS NULL", modID, true).
+		Where("translation_sources.mod_id = ? AND mod_versions.is_default = ? AND mod_versions.archived_at IS NULL AND translations.id IS NULL", modID, true).
 		Count(&pendingCount).Error
 	if err != nil {
 		return nil, fmt.Errorf("failed to count pending sources: %w", err)
@@ -702,17 +1043,17 @@ func (r *Repository) CountTranslationsByMod(ctx context.Context, modID string) (
 	return result, nil
 }
 
-// GetDefaultVersion returns the default version for a mod.
+// GetDefaultVersion returns the default version for a mod. Archived versions are skipped.
 func (r *Repository) GetDefaultVersion(ctx context.Context, modID string) (*models.ModVersion, error) {
 	var version models.ModVersion
 	err := r.db.WithContext(ctx).
-		Where("mod_id = ? AND is_default = ?", modID, true).
+		Where("mod_id = ? AND is_default = ? AND archived_at IS NULL", modID, true).
 		First(&version).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			// Fallback to first version if no default set
 			err = r.db.WithContext(ctx).
-				Where("mod_id = ?", modID).
+				Where("mod_id = ? AND archived_at IS NULL", modID).
 				Order("id DESC").
 				First(&version).Error
 			if err != nil {
@@ -750,19 +1091,135 @@ func (r *Repository) SetDefaultVersion(ctx context.Context, versionID int64) err
 	})
 }
 
-// GetOrCreateSource finds an existing source or creates a new one.
-// Returns (source, created, error) where created is true if a new source was created.
-func (r *Repository) GetOrCreateSource(ctx context.Context, modID, key, sourceText, sourceLang string) (*models.TranslationSource, bool, error) {
-	// Try to find existing source with same mod_id + key + source_text
-	existing, err := r.GetSourceByKey(ctx, modID, key, sourceText)
+// ArchiveMod archives a mod and its active versions with the same timestamp. Archiving an
+// archived mod does nothing.
+func (r *Repository) ArchiveMod(ctx context.Context, modID string) error {
+	now := time.Now()
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var mod models.Mod
+		if err := tx.First(&mod, "id = ?", modID).Error; err != nil {
+			return err
+		}
+		if mod.IsArchived() {
+			return nil
+		}
+		if err := tx.Model(&mod).Update("archived_at", now).Error; err != nil {
+			return err
+		}
+		return tx.Model(&models.ModVersion{}).
+			Where("mod_id = ? AND archived_at IS NULL", modID).
+			Update("archived_at", now).Error
+	})
 	if err != nil {
-		return nil, false, err
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrModNotFound
+		}
+		return fmt.Errorf("failed to archive mod %s: %w", modID, err)
+	}
+	return nil
+}
+
+// UnarchiveMod restores an archived mod together with the versions archived with it.
+// Versions archived on their own before the mod stay archived.
+func (r *Repository) UnarchiveMod(ctx context.Context, modID string) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var mod models.Mod
+		if err := tx.First(&mod, "id = ?", modID).Error; err != nil {
+			return err
+		}
+		if !mod.IsArchived() {
+			return nil
+		}
+		if err := tx.Model(&models.ModVersion{}).
+			Where("mod_id = ? AND archived_at >= ?", modID, *mod.ArchivedAt).
+			Update("archived_at", nil).Error; err != nil {
+			return err
+		}
+		return tx.Model(&mod).Update("archived_at", nil).Error
+	})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrModNotFound
+		}
+		return fmt.Errorf("failed to unarchive mod %s: %w", modID, err)
+	}
+	return nil
+}
+
+// ArchiveVersion archives a version. If it was the default version, the newest active
+// version of the mod becomes the default; a mod without other active versions keeps it.
+func (r *Repository) ArchiveVersion(ctx context.Context, id int64) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var version models.ModVersion
+		if err := tx.First(&version, id).Error; err != nil {
+			return err
+		}
+		if version.IsArchived() {
+			return nil
+		}
+		if err := tx.Model(&version).Update("archived_at", time.Now()).Error; err != nil {
+			return err
+		}
+		if !version.IsDefault {
+			return nil
+		}
+
+		var next models.ModVersion
+		err := tx.Where("mod_id = ? AND archived_at IS NULL", version.ModID).
+			Order("id DESC").
+			First(&next).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := tx.Model(&version).Update("is_default", false).Error; err != nil {
+			return err
+		}
+		return tx.Model(&next).Update("is_default", true).Error
+	})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrVersionNotFound
+		}
+		return fmt.Errorf("failed to archive version %d: %w", id, err)
 	}
-	if existing != nil {
-		return existing, false, nil
+	return nil
+}
+
+// UnarchiveVersion restores an archived version. The default version is left unchanged.
+// Versions of an archived mod are restored with UnarchiveMod and return ErrModArchived.
+func (r *Repository) UnarchiveVersion(ctx context.Context, id int64) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var version models.ModVersion
+		if err := tx.First(&version, id).Error; err != nil {
+			return err
+		}
+		var mod models.Mod
+		if err := tx.First(&mod, "id = ?", version.ModID).Error; err != nil {
+			return err
+		}
+		if mod.IsArchived() {
+			return ErrModArchived
+		}
+		return tx.Model(&version).Update("archived_at", nil).Error
+	})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrVersionNotFound
+		}
+		if errors.Is(err, ErrModArchived) {
+			return err
+		}
+		return fmt.Errorf("failed to unarchive version %d: %w", id, err)
 	}
+	return nil
+}
 
-	// Create new source
+// GetOrCreateSource finds an existing source or creates a new one.
+// Returns (source, created, error) where created is true if a new source was created.
+func (r *Repository) GetOrCreateSource(ctx context.Context, modID, key, sourceText, sourceLang string) (*models.TranslationSource, bool, error) {
 	source := &models.TranslationSource{
 		ModID:      modID,
 		Key:        key,
@@ -770,10 +1227,27 @@ func (r *Repository) GetOrCreateSource(ctx context.Context, modID, key, sourceTe
 		SourceLang: sourceLang,
 		IsCurrent:  true, // Deprecated but keep for compatibility
 	}
-	if err := r.db.WithContext(ctx).Create(source).Error; err != nil {
-		return nil, false, fmt.Errorf("failed to create source: %w", err)
+
+	// Insert unless a source with the same mod_id + key + source_text exists. The conflict
+	// target is left out because PostgreSQL indexes md5(source_text) instead of the column.
+	result := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(source)
+	if result.Error != nil {
+		return nil, false, fmt.Errorf("failed to create source: %w", result.Error)
+	}
+	if result.RowsAffected == 1 {
+		return source, true, nil
+	}
+
+	existing, err := r.GetSourceByKey(ctx, modID, key, sourceText)
+	if err != nil {
+		return nil, false, err
 	}
-	return source, true, nil
+	if existing == nil {
+		return nil, false, fmt.Errorf("source %s/%s not found after conflict", modID, key)
+	}
+	return existing, false, nil
 }
 
 // GetOrCreateVersion finds an existing version or creates a new one.
@@ -885,7 +1359,7 @@ func (r *Repository) GetPendingSourcesBySameText(ctx context.Context, modID, sou
 		Where("translation_sources.source_text = ?", sourceText).
 		Where("translation_sources.id != ?", excludeSourceID).
 		Where("translations.status = ?", models.StatusPending).
-		Where("mod_versions.is_default = ?", true).
+		Where("mod_versions.is_default = ? AND mod_versions.archived_at IS NULL", true).
 		Find(&sources).Error
 
 	if err != nil {
@@ -894,3 +1368,63 @@ func (r *Repository) GetPendingSourcesBySameText(ctx context.Context, modID, sou
 
 	return sources, nil
 }
+
+// ==================== Translation Memory ====================
+
+// SaveMemoryEntries stores translation memory entries, skipping entries whose
+// source, target, target language and origin already exist. Returns the number saved.
+func (r *Repository) SaveMemoryEntries(ctx context.Context, entries []*models.MemoryEntry) (int, error) {
+	saved := 0
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, entry := range entries {
+			var count int64
+			if err := tx.Model(&models.MemoryEntry{}).
+				Where("source_text = ? AND target_text = ? AND target_lang = ? AND origin = ?",
+					entry.SourceText, entry.TargetText, entry.TargetLang, entry.Origin).
+				Count(&count).Error; err != nil {
+				return err
+			}
+			if count > 0 {
+				continue
+			}
+			if err := tx.Create(entry).Error; err != nil {
+				return err
+			}
+			saved++
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to save memory entries: %w", err)
+	}
+	return saved, nil
+}
+
+// FindMemorySuggestions returns translation memory entries for an exact source text,
+// highest priority first.
+func (r *Repository) FindMemorySuggestions(ctx context.Context, sourceText, targetLang string) ([]*models.MemoryEntry, error) {
+	var entries []*models.MemoryEntry
+	err := r.db.WithContext(ctx).
+		Where("source_text = ? AND target_lang = ?", sourceText, targetLang).
+		Order("priority DESC, id").
+		Find(&entries).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to find memory suggestions: %w", err)
+	}
+	return entries, nil
+}
+
+// CountPendingWithMemory counts pending translations whose source text has a translation memory entry.
+func (r *Repository) CountPendingWithMemory(ctx context.Context, targetLang string) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Table("translations").
+		Joins("JOIN translation_sources ON translation_sources.id = translations.source_id").
+		Where("translations.status = ? AND translations.target_lang = ?", models.StatusPending, targetLang).
+		Where("EXISTS (SELECT 1 FROM translation_memory WHERE translation_memory.source_text = translation_sources.source_text AND translation_memory.target_lang = translations.target_lang)").
+		Count(&count).Error
+	if err != nil {
+		return 0, fmt.Errorf("failed to count pending translations with memory: %w", err)
+	}
+	return count, nil
+}
@@ -0,0 +1,142 @@
+package export
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/iuif/minecraft-mod-dictionary/internal/parser"
+	"github.com/iuif/minecraft-mod-dictionary/pkg/models"
+)
+
+// BookKeyPrefix marks translation keys of guidebook files (Patchouli, Mantle) rather than lang files.
+// Book keys have the form "book:<file path in JAR>#<parser key>", e.g.
+// "book:assets/botania/patchouli_books/lexicon/en_us/entries/basics/intro.json#pages[0].text".
+const BookKeyPrefix = "book:"
+
+// bookFileMetadataPrefix marks ModVersion metadata entries holding an original book file,
+// keyed by its path in the JAR, so that book files can be rebuilt without the JAR.
+const bookFileMetadataPrefix = "book_file:"
+
+// bookPathRegex matches per-language book files inside assets/:
+//
+//	assets/<ns>/patchouli_books/<book>/<lang>/...json  (Patchouli)
+//	assets/<ns>/book/<book>/<lang>/...json             (Mantle)
+//	assets/<ns>/mantle/book/<book>/<lang>/...json      (Mantle)
+var bookPathRegex = regexp.MustCompile(`^(assets/[^/]+/(?:patchouli_books|book|mantle/book)/[^/]+/)([^/]+)(/.+\.json)$`)
+
+// BookKey builds the translation key of an entry in a book file.
+func BookKey(filePath, entryKey string) string {
+	return BookKeyPrefix + filePath + "#" + entryKey
+}
+
+// SplitBookKey splits a book key into the file path and the parser key.
+func SplitBookKey(key string) (filePath, entryKey string, ok bool) {
+	if !strings.HasPrefix(key, BookKeyPrefix) {
+		return "", "", false
+	}
+	filePath, entryKey, ok = strings.Cut(strings.TrimPrefix(key, BookKeyPrefix), "#")
+	if !ok || filePath == "" || entryKey == "" {
+		return "", "", false
+	}
+	return filePath, entryKey, true
+}
+
+// IsBookKey reports whether key belongs to a book file.
+func IsBookKey(key string) bool {
+	_, _, ok := SplitBookKey(key)
+	return ok
+}
+
+// BookFileMetadataKey returns the ModVersion metadata key of the original book file at path.
+func BookFileMetadataKey(path string) string {
+	return bookFileMetadataPrefix + path
+}
+
+// IsBookFileMetadataKey reports whether a ModVersion metadata key holds an original book file.
+func IsBookFileMetadataKey(key string) bool {
+	return strings.HasPrefix(key, bookFileMetadataPrefix)
+}
+
+// LangTranslations returns the translations that are not book entries. Book entries
+// are only exported as book files (see LocalizeBooks), never as lang keys.
+func LangTranslations(translations []*models.TranslationWithSource) []*models.TranslationWithSource {
+	result := make([]*models.TranslationWithSource, 0, len(translations))
+	for _, t := range translations {
+		if !IsBookKey(t.Key) {
+			result = append(result, t)
+		}
+	}
+	return result
+}
+
+// BookFileLang returns the parser name and language of a book file path,
+// or ok=false if path is not a per-language Patchouli or Mantle book file.
+func BookFileLang(path string) (parserName, lang string, ok bool) {
+	m := bookPathRegex.FindStringSubmatch(path)
+	if m == nil {
+		return "", "", false
+	}
+	if strings.Contains(m[1], "/patchouli_books/") {
+		return "patchouli", m[2], true
+	}
+	return "mantle_book", m[2], true
+}
+
+// LocalizeBookPath replaces the language directory of a book file path.
+func LocalizeBookPath(path, lang string) string {
+	m := bookPathRegex.FindStringSubmatch(path)
+	if m == nil {
+		return path
+	}
+	return m[1] + lang + m[3]
+}
+
+// LocalizeBooks applies book translations (book key -> text) to the original book files
+// (source file path -> content) with each file's parser, and returns the translated files
+// keyed by their targetLang path.
+func LocalizeBooks(originals map[string][]byte, translations map[string]string, targetLang string) (map[string][]byte, error) {
+	byFile := make(map[string]map[string]string)
+	for key, text := range translations {
+		filePath, entryKey, ok := SplitBookKey(key)
+		if !ok {
+			continue
+		}
+		if byFile[filePath] == nil {
+			byFile[filePath] = make(map[string]string)
+		}
+		byFile[filePath][entryKey] = text
+	}
+
+	paths := make([]string, 0, len(byFile))
+	for path := range byFile {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	registry := parser.NewDefaultRegistry()
+	result := make(map[string][]byte, len(paths))
+	for _, path := range paths {
+		parserName, _, ok := BookFileLang(path)
+		if !ok {
+			return nil, fmt.Errorf("not a book file: %s", path)
+		}
+		content, ok := originals[path]
+		if !ok {
+			return nil, fmt.Errorf("original book file not found: %s", path)
+		}
+
+		p, found := registry.Get(parserName)
+		if !found {
+			return nil, fmt.Errorf("parser not registered: %s", parserName)
+		}
+		translated, err := p.Apply(content, byFile[path])
+		if err != nil {
+			return nil, fmt.Errorf("failed to apply translations to %s: %w", path, err)
+		}
+		result[LocalizeBookPath(path, targetLang)] = translated
+	}
+
+	return result, nil
+}
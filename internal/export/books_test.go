@@ -0,0 +1,110 @@
+package export
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestBookFileLang(t *testing.T) {
+	tests := []struct {
+		path       string
+		wantParser string
+		wantLang   string
+		wantOK     bool
+	}{
+		{"assets/botania/patchouli_books/lexicon/en_us/entries/basics/intro.json", "patchouli", "en_us", true},
+		{"assets/botania/patchouli_books/lexicon/en_us/categories/basics.json", "patchouli", "en_us", true},
+		{"assets/tconstruct/book/materials_and_you/en_us/intro/welcome.json", "mantle_book", "en_us", true},
+		{"assets/tconstruct/mantle/book/encyclopedia/ja_jp/index.json", "mantle_book", "ja_jp", true},
+		{"data/botania/patchouli_books/lexicon/book.json", "", "", false},
+		{"assets/botania/patchouli_books/lexicon/book.json", "", "", false},
+		{"assets/botania/lang/en_us.json", "", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			parserName, lang, ok := BookFileLang(tt.path)
+			if parserName != tt.wantParser || lang != tt.wantLang || ok != tt.wantOK {
+				t.Errorf("BookFileLang() = (%q, %q, %v), want (%q, %q, %v)",
+					parserName, lang, ok, tt.wantParser, tt.wantLang, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestSplitBookKey(t *testing.T) {
+	tests := []struct {
+		key       string
+		wantPath  string
+		wantEntry string
+		wantOK    bool
+	}{
+		{"book:assets/a/book/b/en_us/p.json#text[0].text", "assets/a/book/b/en_us/p.json", "text[0].text", true},
+		{BookKey("assets/a/patchouli_books/b/en_us/e.json", "name"), "assets/a/patchouli_books/b/en_us/e.json", "name", true},
+		{"item.botania.lexicon", "", "", false},
+		{"book:assets/a/book/b/en_us/p.json", "", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			path, entry, ok := SplitBookKey(tt.key)
+			if path != tt.wantPath || entry != tt.wantEntry || ok != tt.wantOK {
+				t.Errorf("SplitBookKey() = (%q, %q, %v), want (%q, %q, %v)",
+					path, entry, ok, tt.wantPath, tt.wantEntry, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestLocalizeBooks(t *testing.T) {
+	patchouliPath := "assets/botania/patchouli_books/lexicon/en_us/entries/intro.json"
+	mantlePath := "assets/tconstruct/book/materials_and_you/en_us/intro/welcome.json"
+
+	originals := map[string][]byte{
+		patchouliPath: []byte(`{"name": "Introduction", "icon": "botania:lexicon", "pages": [{"type": "text", "text": "Welcome"}]}`),
+		mantlePath:    []byte(`{"title": "Welcome", "text": [{"text": "Hello"}, {"text": "World"}]}`),
+	}
+	translations := map[string]string{
+		BookKey(patchouliPath, "name"):          "はじめに",
+		BookKey(patchouliPath, "pages[0].text"): "ようこそ",
+		BookKey(mantlePath, "text[1].text"):     "世界",
+		"item.botania.lexicon":                  "ignored",
+	}
+
+	files, err := LocalizeBooks(originals, translations, "ja_jp")
+	if err != nil {
+		t.Fatalf("LocalizeBooks() error = %v", err)
+	}
+	if len(files) != 2 {
+		t.Fatalf("LocalizeBooks() returned %d files, want 2", len(files))
+	}
+
+	var entry map[string]interface{}
+	if err := json.Unmarshal(files["assets/botania/patchouli_books/lexicon/ja_jp/entries/intro.json"], &entry); err != nil {
+		t.Fatalf("Failed to parse Patchouli output: %v", err)
+	}
+	if entry["name"] != "はじめに" || entry["icon"] != "botania:lexicon" {
+		t.Errorf("Patchouli entry = %v", entry)
+	}
+	if page := entry["pages"].([]interface{})[0].(map[string]interface{}); page["text"] != "ようこそ" || page["type"] != "text" {
+		t.Errorf("Patchouli page = %v", page)
+	}
+
+	var page map[string]interface{}
+	if err := json.Unmarshal(files["assets/tconstruct/book/materials_and_you/ja_jp/intro/welcome.json"], &page); err != nil {
+		t.Fatalf("Failed to parse Mantle output: %v", err)
+	}
+	text := page["text"].([]interface{})
+	if page["title"] != "Welcome" || text[0].(map[string]interface{})["text"] != "Hello" || text[1].(map[string]interface{})["text"] != "世界" {
+		t.Errorf("Mantle page = %v", page)
+	}
+}
+
+func TestLocalizeBooks_MissingOriginal(t *testing.T) {
+	translations := map[string]string{
+		BookKey("assets/a/patchouli_books/b/en_us/entries/x.json", "name"): "名前",
+	}
+	if _, err := LocalizeBooks(map[string][]byte{}, translations, "ja_jp"); err == nil {
+		t.Error("LocalizeBooks() expected error for missing original file")
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"path/filepath"
 	"sort"
 
+	"github.com/iuif/minecraft-mod-dictionary/internal/parser"
 	"github.com/iuif/minecraft-mod-dictionary/pkg/models"
 )
 
@@ -21,10 +22,11 @@ func NewExporter() *Exporter {
 
 // ExportJSON exports translations to a JSON lang file.
 // Uses TranslationWithSource which contains Key from translation_sources.
+// Book entries are skipped.
 func (e *Exporter) ExportJSON(translations []*models.TranslationWithSource, destPath string) error {
 	result := make(map[string]string)
 
-	for _, trans := range translations {
+	for _, trans := range LangTranslations(translations) {
 		if trans.TargetText != nil && trans.Key != "" {
 			result[trans.Key] = *trans.TargetText
 		}
@@ -35,32 +37,26 @@ func (e *Exporter) ExportJSON(translations []*models.TranslationWithSource, dest
 
 // ExportMerged exports translations merged with original content.
 // Translated entries use TargetText, untranslated entries keep original.
-func (e *Exporter) ExportMerged(originalContent []byte, translations []*models.TranslationWithSource, destPath string) error {
-	// Parse original content
-	var original map[string]interface{}
-	if err := json.Unmarshal(originalContent, &original); err != nil {
-		return fmt.Errorf("failed to parse original content: %w", err)
-	}
-
+// The original file's key order, formatting and comments are preserved; translated
+// keys missing from the original are appended in sourceKeys order (the key order of
+// the source lang file, see parser.JSONLangKeys), then in key order.
+// Book entries are skipped.
+func (e *Exporter) ExportMerged(originalContent []byte, translations []*models.TranslationWithSource, sourceKeys []string, destPath string) error {
 	// Create translation lookup map
 	translationMap := make(map[string]string)
-	for _, trans := range translations {
+	for _, trans := range LangTranslations(translations) {
 		if trans.TargetText != nil && trans.Key != "" {
 			translationMap[trans.Key] = *trans.TargetText
 		}
 	}
 
-	// Build result with translations applied
-	result := make(map[string]string)
-	for key, value := range original {
-		if translated, ok := translationMap[key]; ok {
-			result[key] = translated
-		} else if str, ok := value.(string); ok {
-			result[key] = str
-		}
+	order := parser.AppendOrder(sourceKeys, translationMap)
+	content, err := parser.RewriteJSONLang(originalContent, translationMap, order)
+	if err != nil {
+		return fmt.Errorf("failed to parse original content: %w", err)
 	}
 
-	return e.writeJSON(result, destPath)
+	return e.writeFile(content, destPath)
 }
 
 // ExportTerms exports terms to a JSON file.
@@ -124,6 +120,19 @@ func (e *Exporter) writeJSON(data map[string]string, destPath string) error {
 	return nil
 }
 
+// writeFile writes raw content, creating the parent directory.
+func (e *Exporter) writeFile(content []byte, destPath string) error {
+	if err := os.MkdirAll(filepath.Dir(destPath), 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	if err := os.WriteFile(destPath, content, 0644); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+
+	return nil
+}
+
 // writeJSONArray writes an array to a JSON file.
 func (e *Exporter) writeJSONArray(data []map[string]interface{}, destPath string) error {
 	// Ensure directory exists
@@ -77,6 +77,35 @@ func TestExporter_ExportJSON_SkipUntranslated(t *testing.T) {
 	}
 }
 
+func TestExporter_ExportJSON_SkipBookKeys(t *testing.T) {
+	exp := NewExporter()
+	destDir := t.TempDir()
+
+	translations := []*models.TranslationWithSource{
+		{Key: "item.create.wrench", SourceText: "Wrench", Translation: models.Translation{TargetText: strPtr("レンチ")}},
+		{Key: BookKey("assets/create/patchouli_books/guide/en_us/entries/a.json", "name"), SourceText: "Intro", Translation: models.Translation{TargetText: strPtr("はじめに")}},
+	}
+
+	destPath := filepath.Join(destDir, "ja_jp.json")
+	if err := exp.ExportJSON(translations, destPath); err != nil {
+		t.Fatalf("ExportJSON() error = %v", err)
+	}
+
+	content, err := os.ReadFile(destPath)
+	if err != nil {
+		t.Fatalf("Failed to read output file: %v", err)
+	}
+
+	var result map[string]string
+	if err := json.Unmarshal(content, &result); err != nil {
+		t.Fatalf("Failed to parse output JSON: %v", err)
+	}
+
+	if len(result) != 1 || result["item.create.wrench"] != "レンチ" {
+		t.Errorf("ExportJSON() = %v, want only the lang entry (book entries should be skipped)", result)
+	}
+}
+
 func TestExporter_ExportMerged(t *testing.T) {
 	exp := NewExporter()
 	destDir := t.TempDir()
@@ -93,7 +122,7 @@ func TestExporter_ExportMerged(t *testing.T) {
 	}
 
 	destPath := filepath.Join(destDir, "ja_jp.json")
-	err := exp.ExportMerged(originalContent, translations, destPath)
+	err := exp.ExportMerged(originalContent, translations, nil, destPath)
 	if err != nil {
 		t.Fatalf("ExportMerged() error = %v", err)
 	}
@@ -124,6 +153,79 @@ func TestExporter_ExportMerged(t *testing.T) {
 	}
 }
 
+func TestExporter_ExportMerged_AppendsMissingKeys(t *testing.T) {
+	exp := NewExporter()
+	destDir := t.TempDir()
+
+	originalContent := []byte(`{
+  "item.create.wrench": "Wrench"
+}`)
+
+	translations := []*models.TranslationWithSource{
+		{Key: "item.create.legacy", SourceText: "Legacy", Translation: models.Translation{TargetText: strPtr("旧")}},
+		{Key: "item.create.wrench", SourceText: "Wrench", Translation: models.Translation{TargetText: strPtr("レンチ")}},
+		{Key: "item.create.goggles", SourceText: "Goggles", Translation: models.Translation{TargetText: strPtr("ゴーグル")}},
+		{Key: "block.create.gearbox", SourceText: "Gearbox", Translation: models.Translation{TargetText: strPtr("ギアボックス")}},
+		{Key: "tooltip.create.hint", SourceText: "Hint", Translation: models.Translation{TargetText: nil}}, // Not translated
+	}
+	// Key order of the source lang file; the legacy key is not in it
+	sourceKeys := []string{"block.create.gearbox", "item.create.wrench", "tooltip.create.hint", "item.create.goggles"}
+
+	destPath := filepath.Join(destDir, "ja_jp.json")
+	if err := exp.ExportMerged(originalContent, translations, sourceKeys, destPath); err != nil {
+		t.Fatalf("ExportMerged() error = %v", err)
+	}
+
+	content, err := os.ReadFile(destPath)
+	if err != nil {
+		t.Fatalf("Failed to read output file: %v", err)
+	}
+
+	want := `{
+  "item.create.wrench": "レンチ",
+  "block.create.gearbox": "ギアボックス",
+  "item.create.goggles": "ゴーグル",
+  "item.create.legacy": "旧"
+}`
+	if string(content) != want {
+		t.Errorf("ExportMerged() =\n%s\nwant\n%s", content, want)
+	}
+}
+
+func TestExporter_ExportMerged_PreservesLayout(t *testing.T) {
+	exp := NewExporter()
+	destDir := t.TempDir()
+
+	originalContent := []byte(`{
+  // Tools
+  "item.create.wrench": "Wrench",
+  "block.create.gearbox": "Gearbox"
+}`)
+
+	translations := []*models.TranslationWithSource{
+		{Key: "block.create.gearbox", SourceText: "Gearbox", Translation: models.Translation{TargetText: strPtr("ギアボックス")}},
+	}
+
+	destPath := filepath.Join(destDir, "ja_jp.json")
+	if err := exp.ExportMerged(originalContent, translations, nil, destPath); err != nil {
+		t.Fatalf("ExportMerged() error = %v", err)
+	}
+
+	content, err := os.ReadFile(destPath)
+	if err != nil {
+		t.Fatalf("Failed to read output file: %v", err)
+	}
+
+	want := `{
+  // Tools
+  "item.create.wrench": "Wrench",
+  "block.create.gearbox": "ギアボックス"
+}`
+	if string(content) != want {
+		t.Errorf("ExportMerged() =\n%s\nwant\n%s", content, want)
+	}
+}
+
 func TestExporter_ExportTerms(t *testing.T) {
 	exp := NewExporter()
 	destDir := t.TempDir()
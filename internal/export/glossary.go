@@ -0,0 +1,421 @@
+package export
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/iuif/minecraft-mod-dictionary/pkg/models"
+)
+
+const (
+	// termPriorityDefault is the priority of terms that do not specify one (as in data/terms).
+	termPriorityDefault = 100
+
+	// tbxPriorityType and tbxOriginType are the admin types carrying Term.Priority and Term.Source.
+	tbxPriorityType = "x-moddict-priority"
+	tbxOriginType   = "x-moddict-source"
+)
+
+// termCSVHeader is the column order of glossary CSV files.
+var termCSVHeader = []string{"scope", "source_text", "target_text", "tags", "priority", "context", "notes", "source"}
+
+// tbxRoot is the XML structure of a TBX-Basic (martif) document.
+type tbxRoot struct {
+	XMLName xml.Name       `xml:"martif"`
+	Type    string         `xml:"type,attr"`
+	Lang    string         `xml:"http://www.w3.org/XML/1998/namespace lang,attr"`
+	Header  tbxHeader      `xml:"martifHeader"`
+	Entries []tbxTermEntry `xml:"text>body>termEntry"`
+}
+
+type tbxHeader struct {
+	SourceDesc   string `xml:"fileDesc>sourceDesc>p"`
+	EncodingDesc tbxP   `xml:"encodingDesc>p"`
+}
+
+type tbxP struct {
+	Type string `xml:"type,attr,omitempty"`
+	Text string `xml:",chardata"`
+}
+
+type tbxTermEntry struct {
+	ID       string       `xml:"id,attr,omitempty"`
+	Descrips []tbxTyped   `xml:"descrip"`
+	Admins   []tbxTyped   `xml:"admin"`
+	Notes    []string     `xml:"note"`
+	LangSets []tbxLangSet `xml:"langSet"`
+}
+
+type tbxTyped struct {
+	Type string `xml:"type,attr"`
+	Text string `xml:",chardata"`
+}
+
+type tbxLangSet struct {
+	Lang string   `xml:"http://www.w3.org/XML/1998/namespace lang,attr"`
+	TIGs []tbxTIG `xml:"tig"`
+}
+
+type tbxTIG struct {
+	Term string `xml:"term"`
+}
+
+// ExportTBX exports terms as a TBX-Basic glossary. The scope is written as projectSubset,
+// tags as subjectField, the context as definition and notes as note; priority and source
+// use x-moddict admin types so they survive a round trip.
+func (e *Exporter) ExportTBX(terms []*models.Term, sourceLang, targetLang string, destPath string) error {
+	content, err := buildTBX(terms, sourceLang, targetLang)
+	if err != nil {
+		return err
+	}
+	return e.writeFile(content, destPath)
+}
+
+func buildTBX(terms []*models.Term, sourceLang, targetLang string) ([]byte, error) {
+	root := tbxRoot{
+		Type: "TBX-Basic",
+		Lang: bcp47Lang(sourceLang),
+		Header: tbxHeader{
+			SourceDesc:   "moddict term dictionary",
+			EncodingDesc: tbxP{Type: "XCSURI", Text: "TBXBasicXCSV02.xcs"},
+		},
+	}
+
+	for i, term := range sortedTerms(terms) {
+		entry := tbxTermEntry{
+			ID:     fmt.Sprintf("t%d", i+1),
+			Admins: []tbxTyped{{Type: "projectSubset", Text: term.Scope}},
+			LangSets: []tbxLangSet{
+				{Lang: bcp47Lang(sourceLang), TIGs: []tbxTIG{{Term: term.SourceText}}},
+				{Lang: bcp47Lang(targetLang), TIGs: []tbxTIG{{Term: term.TargetText}}},
+			},
+		}
+		if len(term.Tags) > 0 {
+			entry.Descrips = append(entry.Descrips, tbxTyped{Type: "subjectField", Text: strings.Join(term.Tags, ", ")})
+		}
+		if term.Context != nil && *term.Context != "" {
+			entry.Descrips = append(entry.Descrips, tbxTyped{Type: "definition", Text: *term.Context})
+		}
+		entry.Admins = append(entry.Admins, tbxTyped{Type: tbxPriorityType, Text: strconv.Itoa(term.Priority)})
+		if term.Source != "" {
+			entry.Admins = append(entry.Admins, tbxTyped{Type: tbxOriginType, Text: term.Source})
+		}
+		if term.Notes != nil && *term.Notes != "" {
+			entry.Notes = []string{*term.Notes}
+		}
+		root.Entries = append(root.Entries, entry)
+	}
+
+	body, err := xml.MarshalIndent(root, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal TBX: %w", err)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	buf.WriteString(`<!DOCTYPE martif SYSTEM "TBXBasiccoreStructV02.dtd">` + "\n")
+	buf.Write(body)
+	buf.WriteByte('\n')
+	return buf.Bytes(), nil
+}
+
+// ParseTBX reads terms from a TBX glossary. Languages are matched by their primary subtag;
+// entries without both a source and a target term are skipped. Entries without a
+// projectSubset get the global scope.
+func ParseTBX(content []byte, sourceLang, targetLang string) ([]*models.Term, error) {
+	var root tbxRoot
+	if err := xml.Unmarshal(content, &root); err != nil {
+		return nil, fmt.Errorf("failed to parse TBX: %w", err)
+	}
+
+	srcPrimary := primaryLang(sourceLang)
+	trgPrimary := primaryLang(targetLang)
+
+	var terms []*models.Term
+	for _, entry := range root.Entries {
+		term := &models.Term{
+			Scope:      models.ScopeGlobal,
+			SourceLang: sourceLang,
+			TargetLang: targetLang,
+			Priority:   termPriorityDefault,
+		}
+
+		for _, langSet := range entry.LangSets {
+			if len(langSet.TIGs) == 0 {
+				continue
+			}
+			text := strings.TrimSpace(langSet.TIGs[0].Term)
+			switch primaryLang(langSet.Lang) {
+			case srcPrimary:
+				term.SourceText = text
+			case trgPrimary:
+				term.TargetText = text
+			}
+		}
+		if term.SourceText == "" || term.TargetText == "" {
+			continue
+		}
+
+		for _, descrip := range entry.Descrips {
+			text := strings.TrimSpace(descrip.Text)
+			switch descrip.Type {
+			case "subjectField":
+				term.Tags = splitTags(text)
+			case "definition":
+				if text != "" {
+					term.Context = &text
+				}
+			}
+		}
+		for _, admin := range entry.Admins {
+			text := strings.TrimSpace(admin.Text)
+			switch admin.Type {
+			case "projectSubset":
+				if text != "" {
+					term.Scope = text
+				}
+			case tbxPriorityType:
+				if priority, err := strconv.Atoi(text); err == nil {
+					term.Priority = priority
+				}
+			case tbxOriginType:
+				term.Source = text
+			}
+		}
+		if len(entry.Notes) > 0 {
+			notes := strings.TrimSpace(strings.Join(entry.Notes, "\n"))
+			if notes != "" {
+				term.Notes = &notes
+			}
+		}
+
+		terms = append(terms, term)
+	}
+
+	return terms, nil
+}
+
+// ExportTermsCSV exports terms as a spreadsheet-friendly CSV glossary (UTF-8 with BOM).
+// Tags are separated by semicolons.
+func (e *Exporter) ExportTermsCSV(terms []*models.Term, destPath string) error {
+	var buf bytes.Buffer
+	// UTF-8 BOM for Excel compatibility
+	buf.Write([]byte{0xEF, 0xBB, 0xBF})
+
+	writer := csv.NewWriter(&buf)
+	if err := writer.Write(termCSVHeader); err != nil {
+		return fmt.Errorf("failed to write header: %w", err)
+	}
+	for _, term := range sortedTerms(terms) {
+		row := []string{
+			term.Scope,
+			term.SourceText,
+			term.TargetText,
+			strings.Join(term.Tags, ";"),
+			strconv.Itoa(term.Priority),
+			derefString(term.Context),
+			derefString(term.Notes),
+			term.Source,
+		}
+		if err := writer.Write(row); err != nil {
+			return fmt.Errorf("failed to write row: %w", err)
+		}
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return fmt.Errorf("failed to write CSV: %w", err)
+	}
+
+	return e.writeFile(buf.Bytes(), destPath)
+}
+
+// ParseTermsCSV reads a CSV glossary. Columns are matched by header name, so they may be
+// reordered or omitted in a spreadsheet; source_text and target_text are required.
+func ParseTermsCSV(content []byte, sourceLang, targetLang string) ([]*models.Term, error) {
+	reader := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(content, []byte("\xef\xbb\xbf"))))
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV header: %w", err)
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, required := range []string{"source_text", "target_text"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("CSV is missing the %s column", required)
+		}
+	}
+
+	var terms []*models.Term
+	line := 1
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		line++
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CSV line %d: %w", line, err)
+		}
+
+		field := func(name string) string {
+			i, ok := columns[name]
+			if !ok || i >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[i])
+		}
+
+		term := &models.Term{
+			Scope:      field("scope"),
+			SourceText: field("source_text"),
+			TargetText: field("target_text"),
+			SourceLang: sourceLang,
+			TargetLang: targetLang,
+			Tags:       splitTags(field("tags")),
+			Priority:   termPriorityDefault,
+			Source:     field("source"),
+		}
+		if term.SourceText == "" && term.TargetText == "" {
+			continue
+		}
+		if term.SourceText == "" || term.TargetText == "" {
+			return nil, fmt.Errorf("line %d: source_text and target_text are required", line)
+		}
+		if term.Scope == "" {
+			term.Scope = models.ScopeGlobal
+		}
+		if priority := field("priority"); priority != "" {
+			p, err := strconv.Atoi(priority)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid priority %q", line, priority)
+			}
+			term.Priority = p
+		}
+		if context := field("context"); context != "" {
+			term.Context = &context
+		}
+		if notes := field("notes"); notes != "" {
+			term.Notes = &notes
+		}
+
+		terms = append(terms, term)
+	}
+
+	return terms, nil
+}
+
+// TermYAMLFile is the structure of a term file in data/terms.
+type TermYAMLFile struct {
+	Scope string         `yaml:"scope"`
+	Terms []TermYAMLItem `yaml:"terms"`
+}
+
+// TermYAMLItem is one term of a TermYAMLFile.
+type TermYAMLItem struct {
+	Source   string   `yaml:"source"`
+	Target   string   `yaml:"target"`
+	Tags     []string `yaml:"tags,omitempty,flow"`
+	Priority int      `yaml:"priority,omitempty"`
+	Context  string   `yaml:"context,omitempty"`
+	Notes    string   `yaml:"notes,omitempty"`
+	Origin   string   `yaml:"origin,omitempty"`
+}
+
+// TermYAMLPath returns the data/terms file of a scope, relative to the terms directory:
+// global.yaml, categories/<name>.yaml or mods/<mod_id>.yaml.
+func TermYAMLPath(scope string) string {
+	scopeType, value := models.ParseScope(scope)
+	switch {
+	case scopeType == models.ScopeGlobal:
+		return "global.yaml"
+	case scopeType == models.ScopeCategory && value != "":
+		return filepath.Join("categories", value+".yaml")
+	case scopeType == models.ScopeMod && value != "":
+		return filepath.Join("mods", value+".yaml")
+	default:
+		return strings.ReplaceAll(scope, ":", "_") + ".yaml"
+	}
+}
+
+// BuildTermYAML groups terms by scope into data/terms files (path relative to the terms
+// directory -> content) that build reads back.
+func BuildTermYAML(terms []*models.Term) (map[string][]byte, error) {
+	byScope := make(map[string][]*models.Term)
+	for _, term := range sortedTerms(terms) {
+		byScope[term.Scope] = append(byScope[term.Scope], term)
+	}
+
+	files := make(map[string][]byte, len(byScope))
+	for scope, scopeTerms := range byScope {
+		file := TermYAMLFile{Scope: scope}
+		for _, term := range scopeTerms {
+			item := TermYAMLItem{
+				Source:  term.SourceText,
+				Target:  term.TargetText,
+				Tags:    term.Tags,
+				Context: derefString(term.Context),
+				Notes:   derefString(term.Notes),
+				Origin:  term.Source,
+			}
+			if term.Priority != termPriorityDefault {
+				item.Priority = term.Priority
+			}
+			file.Terms = append(file.Terms, item)
+		}
+
+		var buf bytes.Buffer
+		encoder := yaml.NewEncoder(&buf)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(file); err != nil {
+			return nil, fmt.Errorf("failed to marshal terms for %s: %w", scope, err)
+		}
+		encoder.Close()
+		files[TermYAMLPath(scope)] = buf.Bytes()
+	}
+
+	return files, nil
+}
+
+// sortedTerms orders terms by scope, then source text.
+func sortedTerms(terms []*models.Term) []*models.Term {
+	sorted := make([]*models.Term, len(terms))
+	copy(sorted, terms)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Scope != sorted[j].Scope {
+			return sorted[i].Scope < sorted[j].Scope
+		}
+		return sorted[i].SourceText < sorted[j].SourceText
+	})
+	return sorted
+}
+
+// splitTags splits a tag list separated by semicolons or commas.
+func splitTags(s string) []string {
+	var tags []string
+	for _, tag := range strings.FieldsFunc(s, func(r rune) bool { return r == ';' || r == ',' }) {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+func derefString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,164 @@
+package export
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/iuif/minecraft-mod-dictionary/pkg/models"
+)
+
+func glossaryTestTerms() []*models.Term {
+	context := "Minecraft公式訳準拠"
+	notes := "Botaniaの中核リソース, \"マナ\""
+	return []*models.Term{
+		{Scope: "global", SourceText: "Iron Ingot", TargetText: "鉄のインゴット", Tags: []string{"item", "material"}, Priority: 100, Context: &context},
+		{Scope: "mod:botania", SourceText: "Mana", TargetText: "マナ", Tags: []string{"mechanic"}, Priority: 90, Notes: &notes, Source: "community"},
+		{Scope: "category:tech", SourceText: "Energy", TargetText: "エネルギー", Priority: 100},
+	}
+}
+
+func assertTermsEqual(t *testing.T, got, want []*models.Term) {
+	t.Helper()
+
+	if len(got) != len(want) {
+		t.Fatalf("got %d terms, want %d", len(got), len(want))
+	}
+	wantByScope := make(map[string]*models.Term)
+	for _, term := range want {
+		wantByScope[term.Scope] = term
+	}
+	for _, g := range got {
+		w, ok := wantByScope[g.Scope]
+		if !ok {
+			t.Errorf("unexpected term scope %q", g.Scope)
+			continue
+		}
+		if g.SourceText != w.SourceText || g.TargetText != w.TargetText || g.Priority != w.Priority || g.Source != w.Source {
+			t.Errorf("term %s = %+v, want %+v", g.Scope, g, w)
+		}
+		if len(g.Tags) != 0 || len(w.Tags) != 0 {
+			if !reflect.DeepEqual(g.Tags, w.Tags) {
+				t.Errorf("term %s Tags = %v, want %v", g.Scope, g.Tags, w.Tags)
+			}
+		}
+		if derefString(g.Context) != derefString(w.Context) || derefString(g.Notes) != derefString(w.Notes) {
+			t.Errorf("term %s context/notes = %q/%q, want %q/%q", g.Scope,
+				derefString(g.Context), derefString(g.Notes), derefString(w.Context), derefString(w.Notes))
+		}
+		if g.SourceLang != "en_us" || g.TargetLang != "ja_jp" {
+			t.Errorf("term %s langs = %s/%s", g.Scope, g.SourceLang, g.TargetLang)
+		}
+	}
+}
+
+func TestExporter_ExportTBX_RoundTrip(t *testing.T) {
+	destPath := filepath.Join(t.TempDir(), "glossary.tbx")
+	terms := glossaryTestTerms()
+
+	if err := NewExporter().ExportTBX(terms, "en_us", "ja_jp", destPath); err != nil {
+		t.Fatalf("ExportTBX() error = %v", err)
+	}
+
+	content, err := os.ReadFile(destPath)
+	if err != nil {
+		t.Fatalf("Failed to read output: %v", err)
+	}
+	for _, want := range []string{
+		`<martif type="TBX-Basic" xml:lang="en-US">`,
+		`<admin type="projectSubset">mod:botania</admin>`,
+		`<descrip type="subjectField">item, material</descrip>`,
+		`<langSet xml:lang="ja-JP">`,
+	} {
+		if !strings.Contains(string(content), want) {
+			t.Errorf("ExportTBX() output missing %q\n%s", want, content)
+		}
+	}
+
+	got, err := ParseTBX(content, "en_us", "ja_jp")
+	if err != nil {
+		t.Fatalf("ParseTBX() error = %v", err)
+	}
+	assertTermsEqual(t, got, terms)
+}
+
+func TestExporter_ExportTermsCSV_RoundTrip(t *testing.T) {
+	destPath := filepath.Join(t.TempDir(), "terms.csv")
+	terms := glossaryTestTerms()
+
+	if err := NewExporter().ExportTermsCSV(terms, destPath); err != nil {
+		t.Fatalf("ExportTermsCSV() error = %v", err)
+	}
+
+	content, err := os.ReadFile(destPath)
+	if err != nil {
+		t.Fatalf("Failed to read output: %v", err)
+	}
+
+	got, err := ParseTermsCSV(content, "en_us", "ja_jp")
+	if err != nil {
+		t.Fatalf("ParseTermsCSV() error = %v", err)
+	}
+	assertTermsEqual(t, got, terms)
+}
+
+func TestParseTermsCSV(t *testing.T) {
+	tests := []struct {
+		name      string
+		content   string
+		wantCount int
+		wantErr   bool
+	}{
+		{"reordered columns", "target_text,source_text,tags\nマナ,Mana,a;b\n", 1, false},
+		{"blank rows skipped", "source_text,target_text\nMana,マナ\n,\n", 1, false},
+		{"missing column", "source_text,tags\nMana,a\n", 0, true},
+		{"missing target", "source_text,target_text\nMana,\n", 0, true},
+		{"invalid priority", "source_text,target_text,priority\nMana,マナ,high\n", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			terms, err := ParseTermsCSV([]byte(tt.content), "en_us", "ja_jp")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseTermsCSV() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(terms) != tt.wantCount {
+				t.Errorf("ParseTermsCSV() got %d terms, want %d", len(terms), tt.wantCount)
+			}
+			for _, term := range terms {
+				if term.Scope != models.ScopeGlobal || term.Priority != 100 {
+					t.Errorf("defaults not applied: %+v", term)
+				}
+			}
+		})
+	}
+}
+
+func TestBuildTermYAML(t *testing.T) {
+	files, err := BuildTermYAML(glossaryTestTerms())
+	if err != nil {
+		t.Fatalf("BuildTermYAML() error = %v", err)
+	}
+
+	wantPaths := []string{"global.yaml", filepath.Join("mods", "botania.yaml"), filepath.Join("categories", "tech.yaml")}
+	if len(files) != len(wantPaths) {
+		t.Fatalf("BuildTermYAML() returned %d files, want %d", len(files), len(wantPaths))
+	}
+	for _, path := range wantPaths {
+		if _, ok := files[path]; !ok {
+			t.Errorf("BuildTermYAML() missing %s", path)
+		}
+	}
+
+	botania := string(files[filepath.Join("mods", "botania.yaml")])
+	for _, want := range []string{"scope: mod:botania", "source: Mana", "tags: [mechanic]", "priority: 90", "origin: community"} {
+		if !strings.Contains(botania, want) {
+			t.Errorf("mods/botania.yaml missing %q\n%s", want, botania)
+		}
+	}
+	if strings.Contains(string(files["global.yaml"]), "priority:") {
+		t.Errorf("global.yaml should omit the default priority\n%s", files["global.yaml"])
+	}
+}
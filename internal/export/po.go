@@ -0,0 +1,283 @@
+package export
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/iuif/minecraft-mod-dictionary/pkg/models"
+)
+
+// POOptions controls gettext PO/POT export.
+type POOptions struct {
+	ModID      string // used for the header and #: references
+	SourceLang string // e.g. "en_us"
+	TargetLang string // e.g. "ja_jp"; ignored for templates
+	Template   bool   // write a POT template (empty msgstr, no notes or flags)
+}
+
+// POEntry is one message of a PO file.
+type POEntry struct {
+	Context    string   // msgctxt (lang key)
+	ID         string   // msgid (source text)
+	Str        string   // msgstr (target text)
+	Comments   []string // translator comments ("# ")
+	References []string // "#:" references
+	Flags      []string // "#," flags, e.g. "fuzzy"
+	LineNumber int      // line of the msgid
+}
+
+// Fuzzy reports whether the entry carries the fuzzy flag.
+func (e POEntry) Fuzzy() bool {
+	for _, flag := range e.Flags {
+		if flag == "fuzzy" {
+			return true
+		}
+	}
+	return false
+}
+
+// ExportPO exports translations as a gettext PO file (or POT template with opts.Template).
+// msgctxt is the lang key, msgid the source text and msgstr the target text;
+// needs_review translations are marked fuzzy and notes become translator comments.
+// Book entries are skipped.
+func (e *Exporter) ExportPO(translations []*models.TranslationWithSource, opts POOptions, destPath string) error {
+	return e.writeFile(buildPO(translations, opts), destPath)
+}
+
+// ExportPOT exports sources as a gettext POT template (see ExportPO). Templates are built
+// from the sources of a version rather than from translation rows, so that they list every
+// key whether or not a translation into some language exists. Book entries are skipped.
+func (e *Exporter) ExportPOT(sources []*models.TranslationSource, opts POOptions, destPath string) error {
+	entries := make([]*models.TranslationWithSource, 0, len(sources))
+	for _, s := range sources {
+		entries = append(entries, &models.TranslationWithSource{
+			ModID:      s.ModID,
+			Key:        s.Key,
+			SourceText: s.SourceText,
+			SourceLang: s.SourceLang,
+		})
+	}
+	opts.Template = true
+	return e.writeFile(buildPO(entries, opts), destPath)
+}
+
+func buildPO(translations []*models.TranslationWithSource, opts POOptions) []byte {
+	// Book entries are only exported as book files
+	sorted := LangTranslations(translations)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Key < sorted[j].Key
+	})
+
+	var buf bytes.Buffer
+
+	language := ""
+	if opts.Template {
+		fmt.Fprintf(&buf, "# %s translation template generated by moddict\n", opts.ModID)
+	} else {
+		language = gettextLang(opts.TargetLang)
+		fmt.Fprintf(&buf, "# %s %s translations generated by moddict\n", opts.ModID, opts.TargetLang)
+	}
+	buf.WriteString("msgid \"\"\n")
+	buf.WriteString("msgstr \"\"\n")
+	fmt.Fprintf(&buf, "%s\n", quotePO("Project-Id-Version: "+opts.ModID+"\n"))
+	fmt.Fprintf(&buf, "%s\n", quotePO("Language: "+language+"\n"))
+	fmt.Fprintf(&buf, "%s\n", quotePO("MIME-Version: 1.0\n"))
+	fmt.Fprintf(&buf, "%s\n", quotePO("Content-Type: text/plain; charset=UTF-8\n"))
+	fmt.Fprintf(&buf, "%s\n", quotePO("Content-Transfer-Encoding: 8bit\n"))
+	fmt.Fprintf(&buf, "%s\n", quotePO("X-Source-Language: "+opts.SourceLang+"\n"))
+
+	for _, t := range sorted {
+		buf.WriteString("\n")
+
+		if !opts.Template && t.Notes != nil && *t.Notes != "" {
+			for _, line := range strings.Split(*t.Notes, "\n") {
+				fmt.Fprintf(&buf, "# %s\n", line)
+			}
+		}
+		fmt.Fprintf(&buf, "#: %s\n", poReference(opts.ModID, opts.SourceLang))
+		if !opts.Template && t.Status == models.StatusNeedsReview {
+			buf.WriteString("#, fuzzy\n")
+		}
+
+		target := ""
+		if !opts.Template && t.TargetText != nil {
+			target = *t.TargetText
+		}
+
+		writePOField(&buf, "msgctxt", t.Key)
+		writePOField(&buf, "msgid", t.SourceText)
+		writePOField(&buf, "msgstr", target)
+	}
+
+	return buf.Bytes()
+}
+
+// poReference returns the file a key comes from: the mod's source lang file.
+func poReference(modID, sourceLang string) string {
+	return fmt.Sprintf("assets/%s/lang/%s.json", modID, sourceLang)
+}
+
+// writePOField writes a keyword with its quoted value; multi-line values are split after each newline.
+func writePOField(buf *bytes.Buffer, keyword, value string) {
+	lines := strings.SplitAfter(value, "\n")
+	if len(lines) > 1 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	if len(lines) <= 1 {
+		fmt.Fprintf(buf, "%s %s\n", keyword, quotePO(value))
+		return
+	}
+
+	fmt.Fprintf(buf, "%s \"\"\n", keyword)
+	for _, line := range lines {
+		fmt.Fprintf(buf, "%s\n", quotePO(line))
+	}
+}
+
+// quotePO quotes a string with the C escapes gettext understands.
+func quotePO(s string) string {
+	var sb strings.Builder
+	sb.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '\\':
+			sb.WriteString(`\\`)
+		case '"':
+			sb.WriteString(`\"`)
+		case '\n':
+			sb.WriteString(`\n`)
+		case '\r':
+			sb.WriteString(`\r`)
+		case '\t':
+			sb.WriteString(`\t`)
+		default:
+			sb.WriteRune(r)
+		}
+	}
+	sb.WriteByte('"')
+	return sb.String()
+}
+
+// gettextLang converts a Minecraft language code to a gettext locale ("ja_jp" -> "ja_JP").
+func gettextLang(lang string) string {
+	parts := strings.SplitN(lang, "_", 2)
+	if len(parts) != 2 {
+		return lang
+	}
+	return strings.ToLower(parts[0]) + "_" + strings.ToUpper(parts[1])
+}
+
+// ParsePO reads the messages of a PO file. The header entry and obsolete ("#~") entries are skipped.
+func ParsePO(content []byte) ([]POEntry, error) {
+	var entries []POEntry
+	var current POEntry
+	var field *string // field receiving continuation lines
+	started := false
+
+	flush := func() {
+		if started && !(current.Context == "" && current.ID == "") {
+			entries = append(entries, current)
+		}
+		current = POEntry{}
+		field = nil
+		started = false
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(bytes.TrimPrefix(content, []byte("\xef\xbb\xbf"))))
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	lineNum := 0
+
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
+
+		switch {
+		case line == "":
+			continue
+		case strings.HasPrefix(line, "#~"):
+			continue
+		case strings.HasPrefix(line, "#"):
+			// Comments start a new entry once the previous one has its strings
+			if started && field != nil {
+				flush()
+			}
+			switch {
+			case strings.HasPrefix(line, "#:"):
+				current.References = append(current.References, strings.Fields(line[2:])...)
+			case strings.HasPrefix(line, "#,"):
+				for _, flag := range strings.Split(line[2:], ",") {
+					if flag = strings.TrimSpace(flag); flag != "" {
+						current.Flags = append(current.Flags, flag)
+					}
+				}
+			case strings.HasPrefix(line, "# "), line == "#":
+				current.Comments = append(current.Comments, strings.TrimPrefix(strings.TrimPrefix(line, "#"), " "))
+			}
+		case strings.HasPrefix(line, `"`):
+			if field == nil {
+				return nil, fmt.Errorf("line %d: unexpected string continuation", lineNum)
+			}
+			value, err := unquotePO(line)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", lineNum, err)
+			}
+			*field += value
+		default:
+			keyword, rest, _ := strings.Cut(line, " ")
+			value, err := unquotePO(strings.TrimSpace(rest))
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", lineNum, err)
+			}
+
+			switch keyword {
+			case "msgctxt":
+				if started && field != nil {
+					flush()
+				}
+				current.Context = value
+				field = &current.Context
+			case "msgid":
+				if started && field != nil && field != &current.Context {
+					flush()
+				}
+				current.ID = value
+				current.LineNumber = lineNum
+				field = &current.ID
+			case "msgstr", "msgstr[0]":
+				current.Str = value
+				field = &current.Str
+			case "msgid_plural":
+				field = new(string)
+			default:
+				if strings.HasPrefix(keyword, "msgstr[") {
+					field = new(string)
+					continue
+				}
+				return nil, fmt.Errorf("line %d: unknown keyword %q", lineNum, keyword)
+			}
+			started = true
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read PO: %w", err)
+	}
+	flush()
+
+	return entries, nil
+}
+
+// unquotePO decodes a quoted PO string.
+func unquotePO(s string) (string, error) {
+	if len(s) < 2 || s[0] != '"' || s[len(s)-1] != '"' {
+		return "", fmt.Errorf("invalid quoted string: %s", s)
+	}
+	value, err := strconv.Unquote(s)
+	if err != nil {
+		return "", fmt.Errorf("invalid quoted string: %s", s)
+	}
+	return value, nil
+}
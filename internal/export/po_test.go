@@ -0,0 +1,235 @@
+package export
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/iuif/minecraft-mod-dictionary/pkg/models"
+)
+
+func TestExporter_ExportPO(t *testing.T) {
+	target1 := "レッドストーン"
+	target2 := "1行目\n2行目"
+	note := "Block name"
+	translations := []*models.TranslationWithSource{
+		{
+			Translation: models.Translation{TargetText: &target1, Status: models.StatusTranslated, Notes: &note},
+			Key:         "block.test.redstone",
+			SourceText:  "Redstone",
+		},
+		{
+			Translation: models.Translation{TargetText: &target2, Status: models.StatusNeedsReview},
+			Key:         "item.test.lines",
+			SourceText:  "Line \"1\"\nLine 2",
+		},
+		{
+			Translation: models.Translation{Status: models.StatusPending},
+			Key:         "item.test.pending",
+			SourceText:  "Pending",
+		},
+	}
+	book := &models.TranslationWithSource{
+		Translation: models.Translation{TargetText: &target1, Status: models.StatusTranslated},
+		Key:         "book:assets/test/patchouli_books/guide/en_us/entries/a.json#pages[0].text",
+		SourceText:  "Book page",
+	}
+
+	tests := []struct {
+		name     string
+		opts     POOptions
+		want     []string
+		notWant  []string
+		wantStrs map[string]string
+	}{
+		{
+			name: "po",
+			opts: POOptions{ModID: "test", SourceLang: "en_us", TargetLang: "ja_jp"},
+			want: []string{
+				`"Language: ja_JP\n"`,
+				"# Block name\n#: assets/test/lang/en_us.json\nmsgctxt \"block.test.redstone\"\nmsgid \"Redstone\"\nmsgstr \"レッドストーン\"\n",
+				"#: assets/test/lang/en_us.json\n#, fuzzy\nmsgctxt \"item.test.lines\"\n",
+				"msgid \"\"\n\"Line \\\"1\\\"\\n\"\n\"Line 2\"\n",
+			},
+			wantStrs: map[string]string{
+				"block.test.redstone": target1,
+				"item.test.lines":     target2,
+				"item.test.pending":   "",
+			},
+		},
+		{
+			name:    "pot",
+			opts:    POOptions{ModID: "test", SourceLang: "en_us", Template: true},
+			want:    []string{`"Language: \n"`, "msgctxt \"block.test.redstone\"\nmsgid \"Redstone\"\nmsgstr \"\"\n"},
+			notWant: []string{"# Block name", "#, fuzzy", "レッドストーン"},
+			wantStrs: map[string]string{
+				"block.test.redstone": "",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			destPath := filepath.Join(t.TempDir(), "test.po")
+			if err := NewExporter().ExportPO(append(translations, book), tt.opts, destPath); err != nil {
+				t.Fatalf("ExportPO() error = %v", err)
+			}
+			content, err := os.ReadFile(destPath)
+			if err != nil {
+				t.Fatalf("Failed to read output: %v", err)
+			}
+
+			for _, want := range tt.want {
+				if !strings.Contains(string(content), want) {
+					t.Errorf("ExportPO() output missing %q\n%s", want, content)
+				}
+			}
+			// Book entries are only exported as book files
+			for _, notWant := range append(tt.notWant, "Book page") {
+				if strings.Contains(string(content), notWant) {
+					t.Errorf("ExportPO() output contains %q", notWant)
+				}
+			}
+
+			entries, err := ParsePO(content)
+			if err != nil {
+				t.Fatalf("ParsePO() error = %v", err)
+			}
+			if len(entries) != len(translations) {
+				t.Fatalf("ParsePO() returned %d entries, want %d", len(entries), len(translations))
+			}
+			byKey := make(map[string]POEntry)
+			for _, e := range entries {
+				byKey[e.Context] = e
+			}
+			for key, want := range tt.wantStrs {
+				if got := byKey[key].Str; got != want {
+					t.Errorf("entry %s msgstr = %q, want %q", key, got, want)
+				}
+			}
+			for _, tr := range translations {
+				if got := byKey[tr.Key].ID; got != tr.SourceText {
+					t.Errorf("entry %s msgid = %q, want %q", tr.Key, got, tr.SourceText)
+				}
+			}
+		})
+	}
+}
+
+func TestExporter_ExportPOT(t *testing.T) {
+	sources := []*models.TranslationSource{
+		{ModID: "test", Key: "item.test.gear", SourceText: "Gear", SourceLang: "en_us"},
+		{ModID: "test", Key: "block.test.redstone", SourceText: "Redstone", SourceLang: "en_us"},
+		{ModID: "test", Key: "book:assets/test/patchouli_books/guide/en_us/entries/a.json#pages[0].text", SourceText: "Book page", SourceLang: "en_us"},
+	}
+
+	destPath := filepath.Join(t.TempDir(), "test.pot")
+	opts := POOptions{ModID: "test", SourceLang: "en_us", TargetLang: "ja_jp"}
+	if err := NewExporter().ExportPOT(sources, opts, destPath); err != nil {
+		t.Fatalf("ExportPOT() error = %v", err)
+	}
+	content, err := os.ReadFile(destPath)
+	if err != nil {
+		t.Fatalf("Failed to read output: %v", err)
+	}
+
+	for _, want := range []string{
+		"# test translation template generated by moddict",
+		`"Language: \n"`,
+		"msgctxt \"block.test.redstone\"\nmsgid \"Redstone\"\nmsgstr \"\"\n",
+	} {
+		if !strings.Contains(string(content), want) {
+			t.Errorf("ExportPOT() output missing %q\n%s", want, content)
+		}
+	}
+	if strings.Contains(string(content), "Book page") {
+		t.Error("ExportPOT() output contains a book entry")
+	}
+
+	entries, err := ParsePO(content)
+	if err != nil {
+		t.Fatalf("ParsePO() error = %v", err)
+	}
+	if len(entries) != 2 || entries[0].Context != "block.test.redstone" || entries[1].Context != "item.test.gear" {
+		t.Errorf("ParsePO() = %+v, want the two lang keys in key order", entries)
+	}
+}
+
+func TestParsePO(t *testing.T) {
+	content := `# header comment
+msgid ""
+msgstr ""
+"Language: ja_JP\n"
+
+# Translator note
+# second line
+#: assets/test/lang/en_us.json
+#, fuzzy, c-format
+msgctxt "item.test.a"
+msgid "Apple"
+msgstr "りんご"
+
+msgctxt "item.test.b"
+msgid ""
+"Multi\n"
+"line"
+msgstr ""
+"複数\n"
+"行"
+
+#~ msgctxt "item.test.old"
+#~ msgid "Old"
+#~ msgstr "古い"
+msgid "no context"
+msgstr "コンテキストなし"
+`
+
+	entries, err := ParsePO([]byte(content))
+	if err != nil {
+		t.Fatalf("ParsePO() error = %v", err)
+	}
+
+	want := []POEntry{
+		{Context: "item.test.a", ID: "Apple", Str: "りんご", Comments: []string{"Translator note", "second line"},
+			References: []string{"assets/test/lang/en_us.json"}, Flags: []string{"fuzzy", "c-format"}},
+		{Context: "item.test.b", ID: "Multi\nline", Str: "複数\n行"},
+		{Context: "", ID: "no context", Str: "コンテキストなし"},
+	}
+	if len(entries) != len(want) {
+		t.Fatalf("ParsePO() returned %d entries, want %d: %+v", len(entries), len(want), entries)
+	}
+	for i, w := range want {
+		e := entries[i]
+		if e.Context != w.Context || e.ID != w.ID || e.Str != w.Str {
+			t.Errorf("entry %d = (%q, %q, %q), want (%q, %q, %q)", i, e.Context, e.ID, e.Str, w.Context, w.ID, w.Str)
+		}
+		if strings.Join(e.Comments, "|") != strings.Join(w.Comments, "|") ||
+			strings.Join(e.References, "|") != strings.Join(w.References, "|") ||
+			strings.Join(e.Flags, "|") != strings.Join(w.Flags, "|") {
+			t.Errorf("entry %d comments/refs/flags = %v %v %v", i, e.Comments, e.References, e.Flags)
+		}
+	}
+	if !entries[0].Fuzzy() || entries[1].Fuzzy() {
+		t.Errorf("Fuzzy() = %v, %v", entries[0].Fuzzy(), entries[1].Fuzzy())
+	}
+}
+
+func TestParsePO_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{"unknown keyword", "msgfoo \"x\"\n"},
+		{"unquoted", "msgid x\n"},
+		{"orphan continuation", "\"x\"\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParsePO([]byte(tt.content)); err == nil {
+				t.Error("ParsePO() expected error")
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package export
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/iuif/minecraft-mod-dictionary/internal/database"
+	"github.com/iuif/minecraft-mod-dictionary/pkg/interfaces"
+	"github.com/iuif/minecraft-mod-dictionary/pkg/models"
+)
+
+// listStoredTranslations saves a translation with notes and tags to a SQLite database and
+// returns the mod's translations as loaded back by the repository, so that exporters are
+// tested with the columns the database actually returns.
+func listStoredTranslations(t *testing.T, notes string) []*models.TranslationWithSource {
+	t.Helper()
+	ctx := context.Background()
+
+	repo, err := database.NewRepository(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("NewRepository() error = %v", err)
+	}
+	t.Cleanup(func() { repo.Close() })
+	if err := repo.Migrate(); err != nil {
+		t.Fatalf("Migrate() error = %v", err)
+	}
+
+	if err := repo.SaveMod(ctx, &models.Mod{ID: "create", DisplayName: "Create"}); err != nil {
+		t.Fatalf("SaveMod() error = %v", err)
+	}
+	version := &models.ModVersion{ModID: "create", Version: "0.5.1", MCVersion: "1.20.1", IsDefault: true}
+	if err := repo.SaveVersion(ctx, version); err != nil {
+		t.Fatalf("SaveVersion() error = %v", err)
+	}
+	source, _, err := repo.GetOrCreateSource(ctx, "create", "block.create.gearbox", "Gearbox", "en_us")
+	if err != nil {
+		t.Fatalf("GetOrCreateSource() error = %v", err)
+	}
+	if err := repo.LinkSourceToVersion(ctx, source.ID, version.ID); err != nil {
+		t.Fatalf("LinkSourceToVersion() error = %v", err)
+	}
+	target := "ギアボックス"
+	if err := repo.SaveTranslation(ctx, &models.Translation{
+		SourceID:   source.ID,
+		TargetText: &target,
+		TargetLang: "ja_jp",
+		Status:     models.StatusTranslated,
+		Tags:       []string{"block"},
+		Notes:      &notes,
+	}); err != nil {
+		t.Fatalf("SaveTranslation() error = %v", err)
+	}
+
+	translations, err := repo.ListTranslationsWithSourceByMod(ctx, "create", interfaces.TranslationFilter{TargetLang: "ja_jp"})
+	if err != nil {
+		t.Fatalf("ListTranslationsWithSourceByMod() error = %v", err)
+	}
+	if len(translations) != 1 {
+		t.Fatalf("ListTranslationsWithSourceByMod() returned %d translations, want 1", len(translations))
+	}
+	return translations
+}
+
+func TestExporter_ExportXLIFF_StoredNotes(t *testing.T) {
+	translations := listStoredTranslations(t, "Block name, keep short")
+
+	destPath := filepath.Join(t.TempDir(), "create_ja_jp.xlf")
+	if err := NewExporter().ExportXLIFF("create", "en_us", "ja_jp", translations, nil, destPath); err != nil {
+		t.Fatalf("ExportXLIFF() error = %v", err)
+	}
+	content, err := os.ReadFile(destPath)
+	if err != nil {
+		t.Fatalf("Failed to read output: %v", err)
+	}
+	if want := `<note category="moddict">Block name, keep short</note>`; !strings.Contains(string(content), want) {
+		t.Errorf("ExportXLIFF() output missing %q\n%s", want, content)
+	}
+}
+
+func TestExporter_ExportPO_StoredNotes(t *testing.T) {
+	translations := listStoredTranslations(t, "Block name\nkeep short")
+
+	destPath := filepath.Join(t.TempDir(), "create_ja_jp.po")
+	opts := POOptions{ModID: "create", SourceLang: "en_us", TargetLang: "ja_jp"}
+	if err := NewExporter().ExportPO(translations, opts, destPath); err != nil {
+		t.Fatalf("ExportPO() error = %v", err)
+	}
+	content, err := os.ReadFile(destPath)
+	if err != nil {
+		t.Fatalf("Failed to read output: %v", err)
+	}
+	if want := "# Block name\n# keep short\n#: assets/create/lang/en_us.json\nmsgctxt \"block.create.gearbox\"\n"; !strings.Contains(string(content), want) {
+		t.Errorf("ExportPO() output missing translator comments %q\n%s", want, content)
+	}
+}
@@ -0,0 +1,407 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// packFormatRange maps a range of Minecraft versions to a resource pack format.
+type packFormatRange struct {
+	from   string // first version using this format (inclusive)
+	format int
+}
+
+// packFormats lists resource pack formats by the first Minecraft version that uses them.
+// Versions are compared numerically; see PackFormatForVersion.
+var packFormats = []packFormatRange{
+	{"1.6.1", 1},
+	{"1.9", 2},
+	{"1.11", 3},
+	{"1.13", 4},
+	{"1.15", 5},
+	{"1.16.2", 6},
+	{"1.17", 7},
+	{"1.18", 8},
+	{"1.19", 9},
+	{"1.19.3", 12},
+	{"1.19.4", 13},
+	{"1.20", 15},
+	{"1.20.2", 18},
+	{"1.20.3", 22},
+	{"1.20.5", 32},
+	{"1.21", 34},
+	{"1.21.2", 42},
+	{"1.21.4", 46},
+	{"1.21.5", 55},
+	{"1.21.6", 63},
+	{"1.21.7", 64},
+}
+
+// latestKnownVersion is the newest version covered by packFormats.
+const latestKnownVersion = "1.21.8"
+
+// supportedFormatsSince is the first pack format that understands supported_formats and overlays (1.20.2).
+const supportedFormatsSince = 18
+
+// PackFormatForVersion returns the resource pack format for a Minecraft version like "1.20.1".
+func PackFormatForVersion(mcVersion string) (int, error) {
+	v, err := parseMCVersion(mcVersion)
+	if err != nil {
+		return 0, err
+	}
+	if compareMCVersion(v, mustParseMCVersion(packFormats[0].from)) < 0 {
+		return 0, fmt.Errorf("resource packs are not supported before %s: %s", packFormats[0].from, mcVersion)
+	}
+	if compareMCVersion(v, mustParseMCVersion(latestKnownVersion)) > 0 {
+		return 0, fmt.Errorf("unknown Minecraft version %s (latest known: %s)", mcVersion, latestKnownVersion)
+	}
+
+	format := packFormats[0].format
+	for _, r := range packFormats {
+		if compareMCVersion(v, mustParseMCVersion(r.from)) >= 0 {
+			format = r.format
+		}
+	}
+	return format, nil
+}
+
+// PackOverlay is an overlay directory applied on top of the base pack for some pack formats.
+type PackOverlay struct {
+	Directory string
+	MinFormat int
+	MaxFormat int
+}
+
+// ResourcePack collects files for a Minecraft resource pack and writes it
+// either as a directory or as a zip archive.
+type ResourcePack struct {
+	Description string
+	PackFormat  int
+	MinFormat   int // supported_formats range; written when the pack targets 1.20.2+ or has overlays
+	MaxFormat   int
+	Overlays    []PackOverlay
+
+	files map[string][]byte
+}
+
+// NewResourcePack creates a resource pack targeting a Minecraft version or range ("1.20.1", "1.20.1-1.21.1").
+// pack_format is taken from the lowest version so that older clients accept the pack,
+// and supported_formats covers the whole range.
+func NewResourcePack(description, mcVersions string) (*ResourcePack, error) {
+	minVersion, maxVersion := mcVersions, mcVersions
+	if parts := strings.SplitN(mcVersions, "-", 2); len(parts) == 2 {
+		minVersion, maxVersion = strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1])
+	}
+
+	minFormat, err := PackFormatForVersion(minVersion)
+	if err != nil {
+		return nil, err
+	}
+	maxFormat, err := PackFormatForVersion(maxVersion)
+	if err != nil {
+		return nil, err
+	}
+	if maxFormat < minFormat {
+		return nil, fmt.Errorf("invalid Minecraft version range: %s", mcVersions)
+	}
+
+	return &ResourcePack{
+		Description: description,
+		PackFormat:  minFormat,
+		MinFormat:   minFormat,
+		MaxFormat:   maxFormat,
+		files:       make(map[string][]byte),
+	}, nil
+}
+
+// AddOverlay registers an overlay directory for a Minecraft version and returns its directory name.
+// Files added under that directory replace base files on clients of that version.
+func (p *ResourcePack) AddOverlay(mcVersion string) (string, error) {
+	format, err := PackFormatForVersion(mcVersion)
+	if err != nil {
+		return "", err
+	}
+	if format < supportedFormatsSince {
+		return "", fmt.Errorf("overlays require Minecraft 1.20.2 or later: %s", mcVersion)
+	}
+
+	dir := "overlay_" + strings.ReplaceAll(mcVersion, ".", "_")
+	p.Overlays = append(p.Overlays, PackOverlay{Directory: dir, MinFormat: format, MaxFormat: format})
+	if format > p.MaxFormat {
+		p.MaxFormat = format
+	}
+	if format < p.MinFormat {
+		p.MinFormat = format
+	}
+	return dir, nil
+}
+
+// AddFile adds a file at a slash-separated path relative to the pack root.
+func (p *ResourcePack) AddFile(path string, data []byte) {
+	p.files[path] = data
+}
+
+// AddLang adds assets/<namespace>/lang/<lang>.json under root ("" for the base pack, or an overlay directory).
+func (p *ResourcePack) AddLang(root, namespace, lang string, entries map[string]string) error {
+	data, err := encodeLangJSON(entries)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s lang file: %w", namespace, err)
+	}
+	p.AddFile(joinPackPath(root, "assets", namespace, "lang", lang+".json"), data)
+	return nil
+}
+
+// HasFile reports whether a file was added at path.
+func (p *ResourcePack) HasFile(path string) bool {
+	_, ok := p.files[path]
+	return ok
+}
+
+// Mcmeta returns the pack.mcmeta content.
+func (p *ResourcePack) Mcmeta() ([]byte, error) {
+	pack := map[string]interface{}{
+		"pack_format": p.PackFormat,
+		"description": p.Description,
+	}
+	if p.MaxFormat >= supportedFormatsSince && (p.MinFormat != p.MaxFormat || len(p.Overlays) > 0) {
+		pack["supported_formats"] = []int{p.MinFormat, p.MaxFormat}
+	}
+
+	meta := map[string]interface{}{"pack": pack}
+	if len(p.Overlays) > 0 {
+		entries := make([]map[string]interface{}, 0, len(p.Overlays))
+		for _, o := range p.Overlays {
+			entries = append(entries, map[string]interface{}{
+				"formats":   []int{o.MinFormat, o.MaxFormat},
+				"directory": o.Directory,
+			})
+		}
+		meta["overlays"] = map[string]interface{}{"entries": entries}
+	}
+
+	return json.MarshalIndent(meta, "", "  ")
+}
+
+// WriteDir writes the pack as a directory.
+func (p *ResourcePack) WriteDir(dir string) error {
+	files, err := p.allFiles()
+	if err != nil {
+		return err
+	}
+
+	for _, name := range sortedFileNames(files) {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return fmt.Errorf("failed to create directory: %w", err)
+		}
+		if err := os.WriteFile(path, files[name], 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", path, err)
+		}
+	}
+
+	return nil
+}
+
+// WriteZip writes the pack as a zip archive that can be dropped into the resourcepacks folder.
+func (p *ResourcePack) WriteZip(zipPath string) error {
+	files, err := p.allFiles()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(zipPath), 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	modified := time.Now()
+	for _, name := range sortedFileNames(files) {
+		w, err := zw.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: modified})
+		if err != nil {
+			return fmt.Errorf("failed to add %s to zip: %w", name, err)
+		}
+		if _, err := w.Write(files[name]); err != nil {
+			return fmt.Errorf("failed to add %s to zip: %w", name, err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("failed to finish zip: %w", err)
+	}
+
+	if err := os.WriteFile(zipPath, buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", zipPath, err)
+	}
+
+	return nil
+}
+
+// allFiles returns the added files plus pack.mcmeta and a default pack.png if none was added.
+func (p *ResourcePack) allFiles() (map[string][]byte, error) {
+	mcmeta, err := p.Mcmeta()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create pack.mcmeta: %w", err)
+	}
+
+	files := make(map[string][]byte, len(p.files)+2)
+	for name, data := range p.files {
+		files[name] = data
+	}
+	files["pack.mcmeta"] = mcmeta
+
+	if _, ok := files["pack.png"]; !ok {
+		icon, err := DefaultPackIcon()
+		if err != nil {
+			return nil, err
+		}
+		files["pack.png"] = icon
+	}
+
+	return files, nil
+}
+
+// DefaultPackIcon returns a generated 64x64 pack.png.
+func DefaultPackIcon() ([]byte, error) {
+	const size = 64
+	img := image.NewRGBA(image.Rect(0, 0, size, size))
+
+	border := color.RGBA{R: 0x3a, G: 0x2a, B: 0x1a, A: 0xff}
+	fill := color.RGBA{R: 0xbc, G: 0x00, B: 0x2d, A: 0xff} // red disc on white, like the Japanese flag
+	background := color.RGBA{R: 0xf5, G: 0xf5, B: 0xf0, A: 0xff}
+
+	for y := 0; y < size; y++ {
+		for x := 0; x < size; x++ {
+			dx, dy := x-size/2, y-size/2
+			switch {
+			case x < 3 || y < 3 || x >= size-3 || y >= size-3:
+				img.Set(x, y, border)
+			case dx*dx+dy*dy <= 16*16:
+				img.Set(x, y, fill)
+			default:
+				img.Set(x, y, background)
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, fmt.Errorf("failed to encode pack.png: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// encodeLangJSON encodes a lang file with sorted keys and without HTML escaping.
+func encodeLangJSON(entries map[string]string) ([]byte, error) {
+	keys := make([]string, 0, len(entries))
+	for key := range entries {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var buf bytes.Buffer
+	buf.WriteString("{\n")
+	for i, key := range keys {
+		k, err := marshalNoEscape(key)
+		if err != nil {
+			return nil, err
+		}
+		v, err := marshalNoEscape(entries[key])
+		if err != nil {
+			return nil, err
+		}
+		fmt.Fprintf(&buf, "  %s: %s", k, v)
+		if i < len(keys)-1 {
+			buf.WriteByte(',')
+		}
+		buf.WriteByte('\n')
+	}
+	buf.WriteString("}\n")
+
+	return buf.Bytes(), nil
+}
+
+func marshalNoEscape(s string) (string, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(s); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
+
+func joinPackPath(parts ...string) string {
+	nonEmpty := parts[:0:0]
+	for _, part := range parts {
+		if part != "" {
+			nonEmpty = append(nonEmpty, part)
+		}
+	}
+	return strings.Join(nonEmpty, "/")
+}
+
+func sortedFileNames(files map[string][]byte) []string {
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// parseMCVersion parses "1.20.1" into numeric components.
+func parseMCVersion(version string) ([]int, error) {
+	parts := strings.Split(strings.TrimSpace(version), ".")
+	if len(parts) < 2 {
+		return nil, fmt.Errorf("invalid Minecraft version: %q", version)
+	}
+
+	nums := make([]int, len(parts))
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			return nil, fmt.Errorf("invalid Minecraft version: %q", version)
+		}
+		nums[i] = n
+	}
+	return nums, nil
+}
+
+func mustParseMCVersion(version string) []int {
+	v, err := parseMCVersion(version)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// compareMCVersion compares two parsed versions; missing components count as 0.
+func compareMCVersion(a, b []int) int {
+	for i := 0; i < len(a) || i < len(b); i++ {
+		var x, y int
+		if i < len(a) {
+			x = a[i]
+		}
+		if i < len(b) {
+			y = b[i]
+		}
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
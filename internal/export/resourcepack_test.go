@@ -0,0 +1,166 @@
+package export
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestPackFormatForVersion(t *testing.T) {
+	tests := []struct {
+		version string
+		want    int
+		wantErr bool
+	}{
+		{version: "1.12.2", want: 3},
+		{version: "1.16.1", want: 5},
+		{version: "1.16.5", want: 6},
+		{version: "1.19.2", want: 9},
+		{version: "1.20", want: 15},
+		{version: "1.20.1", want: 15},
+		{version: "1.20.2", want: 18},
+		{version: "1.20.4", want: 22},
+		{version: "1.20.6", want: 32},
+		{version: "1.21.1", want: 34},
+		{version: "1.21.4", want: 46},
+		{version: "1.5.2", wantErr: true},
+		{version: "1.99", wantErr: true},
+		{version: "latest", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			got, err := PackFormatForVersion(tt.version)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("PackFormatForVersion(%q) error = %v, wantErr %v", tt.version, err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("PackFormatForVersion(%q) = %d, want %d", tt.version, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResourcePack_Mcmeta(t *testing.T) {
+	tests := []struct {
+		name       string
+		mcVersions string
+		overlays   []string
+		want       string
+	}{
+		{
+			name:       "single_legacy_version",
+			mcVersions: "1.20.1",
+			want:       `{"pack":{"description":"test","pack_format":15}}`,
+		},
+		{
+			name:       "range",
+			mcVersions: "1.20.2-1.21.1",
+			want:       `{"pack":{"description":"test","pack_format":18,"supported_formats":[18,34]}}`,
+		},
+		{
+			name:       "overlay",
+			mcVersions: "1.20.1",
+			overlays:   []string{"1.21.1"},
+			want:       `{"overlays":{"entries":[{"directory":"overlay_1_21_1","formats":[34,34]}]},"pack":{"description":"test","pack_format":15,"supported_formats":[15,34]}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pack, err := NewResourcePack("test", tt.mcVersions)
+			if err != nil {
+				t.Fatalf("NewResourcePack() error = %v", err)
+			}
+			for _, overlay := range tt.overlays {
+				if _, err := pack.AddOverlay(overlay); err != nil {
+					t.Fatalf("AddOverlay() error = %v", err)
+				}
+			}
+
+			data, err := pack.Mcmeta()
+			if err != nil {
+				t.Fatalf("Mcmeta() error = %v", err)
+			}
+
+			var got, want interface{}
+			json.Unmarshal(data, &got)
+			json.Unmarshal([]byte(tt.want), &want)
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("Mcmeta() = %s, want %s", data, tt.want)
+			}
+		})
+	}
+}
+
+func TestResourcePack_AddOverlay_TooOld(t *testing.T) {
+	pack, err := NewResourcePack("test", "1.20.1")
+	if err != nil {
+		t.Fatalf("NewResourcePack() error = %v", err)
+	}
+	if _, err := pack.AddOverlay("1.20.1"); err == nil {
+		t.Error("AddOverlay() expected error for version without overlay support")
+	}
+}
+
+func TestResourcePack_WriteZip(t *testing.T) {
+	pack, err := NewResourcePack("test", "1.20.1")
+	if err != nil {
+		t.Fatalf("NewResourcePack() error = %v", err)
+	}
+	if err := pack.AddLang("", "create", "ja_jp", map[string]string{"item.create.wrench": "レンチ"}); err != nil {
+		t.Fatalf("AddLang() error = %v", err)
+	}
+
+	zipPath := filepath.Join(t.TempDir(), "pack.zip")
+	if err := pack.WriteZip(zipPath); err != nil {
+		t.Fatalf("WriteZip() error = %v", err)
+	}
+
+	reader, err := zip.OpenReader(zipPath)
+	if err != nil {
+		t.Fatalf("failed to open zip: %v", err)
+	}
+	defer reader.Close()
+
+	var names []string
+	for _, f := range reader.File {
+		names = append(names, f.Name)
+	}
+	want := []string{"assets/create/lang/ja_jp.json", "pack.mcmeta", "pack.png"}
+	if !reflect.DeepEqual(names, want) {
+		t.Errorf("zip entries = %v, want %v", names, want)
+	}
+}
+
+func TestResourcePack_WriteDir(t *testing.T) {
+	pack, err := NewResourcePack("test", "1.20.1")
+	if err != nil {
+		t.Fatalf("NewResourcePack() error = %v", err)
+	}
+	pack.AddFile("pack.png", []byte("custom"))
+	if err := pack.AddLang("", "create", "ja_jp", map[string]string{"b": "<B>", "a": "A"}); err != nil {
+		t.Fatalf("AddLang() error = %v", err)
+	}
+
+	dir := t.TempDir()
+	if err := pack.WriteDir(dir); err != nil {
+		t.Fatalf("WriteDir() error = %v", err)
+	}
+
+	lang, err := os.ReadFile(filepath.Join(dir, "assets", "create", "lang", "ja_jp.json"))
+	if err != nil {
+		t.Fatalf("failed to read lang file: %v", err)
+	}
+	if want := "{\n  \"a\": \"A\",\n  \"b\": \"<B>\"\n}\n"; string(lang) != want {
+		t.Errorf("lang file = %q, want %q", lang, want)
+	}
+
+	icon, err := os.ReadFile(filepath.Join(dir, "pack.png"))
+	if err != nil || string(icon) != "custom" {
+		t.Errorf("pack.png = %q, %v; want custom icon", icon, err)
+	}
+}
@@ -0,0 +1,262 @@
+package export
+
+import (
+	"encoding/xml"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/iuif/minecraft-mod-dictionary/pkg/models"
+)
+
+const (
+	// tmxModProp and tmxKeyProp record where a unit's translation is used.
+	tmxModProp = "x-moddict-mod"
+	tmxKeyProp = "x-moddict-key"
+)
+
+// TMXEntry is a translation exported to (or read from) translation memory.
+type TMXEntry struct {
+	ModID      string
+	Key        string
+	SourceText string
+	TargetText string
+	Status     string
+}
+
+// TMXUnit is one translation unit of a TMX file.
+type TMXUnit struct {
+	Source  string
+	Target  string
+	Origins []TMXOrigin // mod/key properties, if any
+}
+
+// TMXOrigin is a mod and key the unit was exported from.
+type TMXOrigin struct {
+	ModID string
+	Key   string
+}
+
+// tmxRoot is the XML structure of a TMX 1.4b document.
+type tmxRoot struct {
+	XMLName xml.Name  `xml:"tmx"`
+	Version string    `xml:"version,attr"`
+	Header  tmxHeader `xml:"header"`
+	Body    tmxBody   `xml:"body"`
+}
+
+type tmxHeader struct {
+	CreationTool        string `xml:"creationtool,attr"`
+	CreationToolVersion string `xml:"creationtoolversion,attr"`
+	SegType             string `xml:"segtype,attr"`
+	OTMF                string `xml:"o-tmf,attr"`
+	AdminLang           string `xml:"adminlang,attr"`
+	SrcLang             string `xml:"srclang,attr"`
+	DataType            string `xml:"datatype,attr"`
+}
+
+type tmxBody struct {
+	Units []tmxTU `xml:"tu"`
+}
+
+type tmxTU struct {
+	Props []tmxProp `xml:"prop"`
+	TUVs  []tmxTUV  `xml:"tuv"`
+}
+
+type tmxProp struct {
+	Type string `xml:"type,attr"`
+	Text string `xml:",chardata"`
+}
+
+type tmxTUV struct {
+	Lang    string `xml:"http://www.w3.org/XML/1998/namespace lang,attr"`
+	OldLang string `xml:"lang,attr,omitempty"` // TMX 1.1 style
+	Seg     string `xml:"seg"`
+}
+
+// ExportTMX exports translations as a TMX 1.4b translation memory.
+// Entries are deduplicated by source text: the best translation (by status, then by how
+// often it is used) becomes the unit, with a mod/key property per place it is used.
+func (e *Exporter) ExportTMX(entries []TMXEntry, sourceLang, targetLang, destPath string) error {
+	content, err := buildTMX(entries, sourceLang, targetLang)
+	if err != nil {
+		return err
+	}
+	return e.writeFile(content, destPath)
+}
+
+func buildTMX(entries []TMXEntry, sourceLang, targetLang string) ([]byte, error) {
+	srcLang := bcp47Lang(sourceLang)
+	trgLang := bcp47Lang(targetLang)
+
+	root := tmxRoot{
+		Version: "1.4",
+		Header: tmxHeader{
+			CreationTool:        "moddict",
+			CreationToolVersion: "1.0",
+			SegType:             "sentence",
+			OTMF:                "moddict",
+			AdminLang:           "en-US",
+			SrcLang:             srcLang,
+			DataType:            "plaintext",
+		},
+	}
+
+	for _, unit := range DedupTMXEntries(entries) {
+		tu := tmxTU{
+			TUVs: []tmxTUV{
+				{Lang: srcLang, Seg: unit.Source},
+				{Lang: trgLang, Seg: unit.Target},
+			},
+		}
+		for _, origin := range unit.Origins {
+			tu.Props = append(tu.Props,
+				tmxProp{Type: tmxModProp, Text: origin.ModID},
+				tmxProp{Type: tmxKeyProp, Text: origin.Key},
+			)
+		}
+		root.Body.Units = append(root.Body.Units, tu)
+	}
+
+	body, err := xml.MarshalIndent(root, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal TMX: %w", err)
+	}
+
+	return append([]byte(xml.Header), append(body, '\n')...), nil
+}
+
+// DedupTMXEntries groups entries by source text and picks one target per source.
+// Pending entries, book entries and entries without a target are dropped. Units are sorted by source text.
+func DedupTMXEntries(entries []TMXEntry) []TMXUnit {
+	bySource := make(map[string][]TMXEntry)
+	for _, entry := range entries {
+		if entry.SourceText == "" || entry.TargetText == "" || entry.Status == models.StatusPending || IsBookKey(entry.Key) {
+			continue
+		}
+		bySource[entry.SourceText] = append(bySource[entry.SourceText], entry)
+	}
+
+	sources := make([]string, 0, len(bySource))
+	for source := range bySource {
+		sources = append(sources, source)
+	}
+	sort.Strings(sources)
+
+	units := make([]TMXUnit, 0, len(sources))
+	for _, source := range sources {
+		group := bySource[source]
+
+		rank := make(map[string]int)
+		count := make(map[string]int)
+		for _, entry := range group {
+			count[entry.TargetText]++
+			if r := tmxStatusRank(entry.Status); r > rank[entry.TargetText] {
+				rank[entry.TargetText] = r
+			}
+		}
+
+		best := ""
+		for target := range count {
+			if best == "" ||
+				rank[target] > rank[best] ||
+				(rank[target] == rank[best] && count[target] > count[best]) ||
+				(rank[target] == rank[best] && count[target] == count[best] && target < best) {
+				best = target
+			}
+		}
+
+		unit := TMXUnit{Source: source, Target: best}
+		for _, entry := range group {
+			if entry.TargetText == best && entry.ModID != "" && entry.Key != "" {
+				unit.Origins = append(unit.Origins, TMXOrigin{ModID: entry.ModID, Key: entry.Key})
+			}
+		}
+		sort.Slice(unit.Origins, func(i, j int) bool {
+			if unit.Origins[i].ModID != unit.Origins[j].ModID {
+				return unit.Origins[i].ModID < unit.Origins[j].ModID
+			}
+			return unit.Origins[i].Key < unit.Origins[j].Key
+		})
+		units = append(units, unit)
+	}
+
+	return units
+}
+
+// tmxStatusRank orders statuses by how trustworthy their translations are.
+func tmxStatusRank(status string) int {
+	switch status {
+	case models.StatusVerified, models.StatusOfficial:
+		return 3
+	case models.StatusTranslated, models.StatusInherited:
+		return 2
+	case models.StatusNeedsReview:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// ParseTMX reads the units of a TMX file for a language pair. Languages are matched by
+// their primary subtag ("ja" matches "ja-JP"); units lacking either side are skipped.
+func ParseTMX(content []byte, sourceLang, targetLang string) ([]TMXUnit, error) {
+	var root tmxRoot
+	if err := xml.Unmarshal(content, &root); err != nil {
+		return nil, fmt.Errorf("failed to parse TMX: %w", err)
+	}
+
+	srcPrimary := primaryLang(sourceLang)
+	if srcPrimary == "" {
+		srcPrimary = primaryLang(root.Header.SrcLang)
+	}
+	trgPrimary := primaryLang(targetLang)
+
+	var units []TMXUnit
+	for _, tu := range root.Body.Units {
+		var unit TMXUnit
+		for _, tuv := range tu.TUVs {
+			lang := tuv.Lang
+			if lang == "" {
+				lang = tuv.OldLang
+			}
+			switch primaryLang(lang) {
+			case srcPrimary:
+				unit.Source = tuv.Seg
+			case trgPrimary:
+				unit.Target = tuv.Seg
+			}
+		}
+		if unit.Source == "" || unit.Target == "" {
+			continue
+		}
+
+		var origin TMXOrigin
+		for _, prop := range tu.Props {
+			switch prop.Type {
+			case tmxModProp:
+				origin.ModID = prop.Text
+			case tmxKeyProp:
+				origin.Key = prop.Text
+				if origin.ModID != "" {
+					unit.Origins = append(unit.Origins, origin)
+				}
+				origin = TMXOrigin{}
+			}
+		}
+
+		units = append(units, unit)
+	}
+
+	return units, nil
+}
+
+// primaryLang returns the lower-cased primary subtag of a language code ("ja_jp", "ja-JP" -> "ja").
+func primaryLang(lang string) string {
+	lang = strings.ToLower(strings.TrimSpace(lang))
+	if i := strings.IndexAny(lang, "-_"); i >= 0 {
+		lang = lang[:i]
+	}
+	return lang
+}
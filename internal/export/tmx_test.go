@@ -0,0 +1,138 @@
+package export
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/iuif/minecraft-mod-dictionary/pkg/models"
+)
+
+func TestExporter_ExportTMX_RoundTrip(t *testing.T) {
+	tmpDir := t.TempDir()
+	destPath := filepath.Join(tmpDir, "memory.tmx")
+
+	entries := []TMXEntry{
+		{ModID: "moda", Key: "block.moda.copper", SourceText: "Copper Block", TargetText: "銅ブロック", Status: models.StatusTranslated},
+		{ModID: "modb", Key: "block.modb.copper", SourceText: "Copper Block", TargetText: "銅ブロック", Status: models.StatusTranslated},
+		{ModID: "modc", Key: "block.modc.copper", SourceText: "Copper Block", TargetText: "銅のブロック", Status: models.StatusVerified},
+		{ModID: "moda", Key: "item.moda.gear", SourceText: "Gear & <Cog>", TargetText: "歯車", Status: models.StatusNeedsReview},
+		{ModID: "moda", Key: "item.moda.pending", SourceText: "Pending", Status: models.StatusPending},
+	}
+
+	exporter := NewExporter()
+	if err := exporter.ExportTMX(entries, "en_us", "ja_jp", destPath); err != nil {
+		t.Fatalf("ExportTMX() error = %v", err)
+	}
+
+	content, err := os.ReadFile(destPath)
+	if err != nil {
+		t.Fatalf("Failed to read output: %v", err)
+	}
+	xml := string(content)
+
+	for _, want := range []string{
+		`<tmx version="1.4">`,
+		`srclang="en-US"`,
+		`<tuv xml:lang="ja-JP">`,
+		`<prop type="x-moddict-mod">modc</prop>`,
+		`<seg>Gear &amp; &lt;Cog&gt;</seg>`,
+	} {
+		if !strings.Contains(xml, want) {
+			t.Errorf("ExportTMX() output missing %q\n%s", want, xml)
+		}
+	}
+	if strings.Contains(xml, "Pending") {
+		t.Error("ExportTMX() exported a pending entry")
+	}
+
+	units, err := ParseTMX(content, "en_us", "ja_jp")
+	if err != nil {
+		t.Fatalf("ParseTMX() error = %v", err)
+	}
+	if len(units) != 2 {
+		t.Fatalf("ParseTMX() returned %d units, want 2", len(units))
+	}
+
+	copper := units[0]
+	if copper.Source != "Copper Block" || copper.Target != "銅のブロック" {
+		t.Errorf("unit 0 = %q -> %q, want verified translation", copper.Source, copper.Target)
+	}
+	if len(copper.Origins) != 1 || copper.Origins[0] != (TMXOrigin{ModID: "modc", Key: "block.modc.copper"}) {
+		t.Errorf("unit 0 Origins = %v", copper.Origins)
+	}
+	if units[1].Source != "Gear & <Cog>" || units[1].Target != "歯車" {
+		t.Errorf("unit 1 = %q -> %q", units[1].Source, units[1].Target)
+	}
+}
+
+func TestDedupTMXEntries_Frequency(t *testing.T) {
+	entries := []TMXEntry{
+		{ModID: "a", Key: "k1", SourceText: "Copper", TargetText: "銅", Status: models.StatusTranslated},
+		{ModID: "b", Key: "k2", SourceText: "Copper", TargetText: "カッパー", Status: models.StatusTranslated},
+		{ModID: "c", Key: "k3", SourceText: "Copper", TargetText: "銅", Status: models.StatusInherited},
+	}
+
+	units := DedupTMXEntries(entries)
+	if len(units) != 1 {
+		t.Fatalf("DedupTMXEntries() returned %d units, want 1", len(units))
+	}
+	if units[0].Target != "銅" {
+		t.Errorf("Target = %q, want most frequent translation", units[0].Target)
+	}
+	if len(units[0].Origins) != 2 {
+		t.Errorf("Origins = %v, want 2", units[0].Origins)
+	}
+}
+
+func TestDedupTMXEntries_SkipBookKeys(t *testing.T) {
+	entries := []TMXEntry{
+		{ModID: "a", Key: "k1", SourceText: "Copper", TargetText: "銅", Status: models.StatusTranslated},
+		{ModID: "a", Key: BookKey("assets/a/patchouli_books/guide/en_us/entries/ores.json", "name"), SourceText: "Ores", TargetText: "鉱石", Status: models.StatusTranslated},
+	}
+
+	units := DedupTMXEntries(entries)
+	if len(units) != 1 || units[0].Source != "Copper" {
+		t.Errorf("DedupTMXEntries() = %+v, want only the lang entry", units)
+	}
+}
+
+func TestParseTMX_ForeignFile(t *testing.T) {
+	content := `<?xml version="1.0" encoding="UTF-8"?>
+<tmx version="1.4">
+  <header creationtool="OmegaT" segtype="sentence" o-tmf="OmegaT TMX" adminlang="EN-US" srclang="EN-US" datatype="plaintext"/>
+  <body>
+    <tu><tuv xml:lang="EN-US"><seg>Iron Ingot</seg></tuv><tuv xml:lang="JA"><seg>鉄インゴット</seg></tuv></tu>
+    <tu><tuv xml:lang="EN-US"><seg>Only source</seg></tuv></tu>
+    <tu><tuv lang="en"><seg>Gold</seg></tuv><tuv lang="ja-JP"><seg>金</seg></tuv><tuv lang="de-DE"><seg>Gold</seg></tuv></tu>
+  </body>
+</tmx>`
+
+	units, err := ParseTMX([]byte(content), "en_us", "ja_jp")
+	if err != nil {
+		t.Fatalf("ParseTMX() error = %v", err)
+	}
+
+	tests := []struct {
+		source string
+		target string
+	}{
+		{"Iron Ingot", "鉄インゴット"},
+		{"Gold", "金"},
+	}
+	if len(units) != len(tests) {
+		t.Fatalf("ParseTMX() returned %d units, want %d", len(units), len(tests))
+	}
+	for i, tt := range tests {
+		if units[i].Source != tt.source || units[i].Target != tt.target || len(units[i].Origins) != 0 {
+			t.Errorf("unit %d = %+v, want %q -> %q", i, units[i], tt.source, tt.target)
+		}
+	}
+}
+
+func TestParseTMX_Invalid(t *testing.T) {
+	if _, err := ParseTMX([]byte(`<xliff version="2.0"></xliff>`), "en_us", "ja_jp"); err == nil {
+		t.Error("ParseTMX() expected error for non-TMX root")
+	}
+}
@@ -0,0 +1,224 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/iuif/minecraft-mod-dictionary/pkg/interfaces"
+	"github.com/iuif/minecraft-mod-dictionary/pkg/models"
+)
+
+// Reasons a translation CSV row is reported as a conflict instead of being applied.
+const (
+	CSVConflictSourceChanged = "source_changed" // the source text differs from the current source
+	CSVConflictProtected     = "protected"      // the row would overwrite a verified/official translation
+)
+
+// TranslationCSVRow is one row of a translation CSV (export -format csv).
+type TranslationCSVRow struct {
+	Line       int
+	ModID      string // empty unless the CSV has a mod_id column
+	Key        string
+	SourceText string
+	TargetText string
+}
+
+// CSVConflict is a CSV row that was not applied because it disagrees with the database.
+type CSVConflict struct {
+	File          string
+	Line          int
+	ModID         string
+	Key           string
+	Reason        string
+	CSVSource     string
+	CurrentSource string
+	CSVTarget     string
+	CurrentTarget string
+}
+
+// CSVImportStats counts the outcome of translation CSV rows.
+type CSVImportStats struct {
+	Rows         int
+	Updated      int
+	Unchanged    int
+	SkippedEmpty int
+	SameAsSource int
+	NotFound     int
+	UnknownMod   int
+	Conflicts    []CSVConflict
+}
+
+// Add adds the counts and conflicts of other to s.
+func (s *CSVImportStats) Add(other *CSVImportStats) {
+	s.Rows += other.Rows
+	s.Updated += other.Updated
+	s.Unchanged += other.Unchanged
+	s.SkippedEmpty += other.SkippedEmpty
+	s.SameAsSource += other.SameAsSource
+	s.NotFound += other.NotFound
+	s.UnknownMod += other.UnknownMod
+	s.Conflicts = append(s.Conflicts, other.Conflicts...)
+}
+
+// ParseTranslationCSV reads a translation CSV. Columns are matched by header name;
+// key, source_text and target_text are required. An optional mod_id (or mod) column,
+// written by the combined export, gives the mod of each row.
+func ParseTranslationCSV(content []byte) ([]TranslationCSVRow, error) {
+	reader := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(content, []byte("\xef\xbb\xbf"))))
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV header: %w", err)
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, required := range []string{"key", "source_text", "target_text"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("CSV is missing the %s column", required)
+		}
+	}
+	modColumn := "mod_id"
+	if _, ok := columns[modColumn]; !ok {
+		modColumn = "mod"
+	}
+
+	var rows []TranslationCSVRow
+	line := 1
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		line++
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CSV line %d: %w", line, err)
+		}
+
+		field := func(name string) string {
+			i, ok := columns[name]
+			if !ok || i >= len(record) {
+				return ""
+			}
+			return record[i]
+		}
+
+		row := TranslationCSVRow{
+			Line:       line,
+			ModID:      strings.TrimSpace(field(modColumn)),
+			Key:        strings.TrimSpace(field("key")),
+			SourceText: field("source_text"),
+			TargetText: field("target_text"),
+		}
+		if row.Key == "" {
+			continue
+		}
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+// ApplyTranslationCSV applies translation CSV rows to the targetLang translations of modID;
+// rows with a mod ID use their own mod. Rows are matched by mod, key and source text:
+// rows whose source text differs from the current source, or that would overwrite a
+// verified/official translation with a different text, are returned as conflicts
+// (with file, the CSV path, for reporting) and not applied.
+func ApplyTranslationCSV(ctx context.Context, repo interfaces.SourceRepository, modID, targetLang, file string, rows []TranslationCSVRow) (*CSVImportStats, error) {
+	stats := &CSVImportStats{Rows: len(rows)}
+	knownMods := make(map[string]bool)
+
+	for _, row := range rows {
+		rowMod := modID
+		if row.ModID != "" {
+			rowMod = row.ModID
+		}
+		if _, ok := knownMods[rowMod]; !ok {
+			known := false
+			if rowMod != "" {
+				_, err := repo.GetMod(ctx, rowMod)
+				if err != nil && !errors.Is(err, interfaces.ErrModNotFound) {
+					return nil, fmt.Errorf("failed to get mod %s: %w", rowMod, err)
+				}
+				known = err == nil
+			}
+			knownMods[rowMod] = known
+		}
+		if !knownMods[rowMod] {
+			stats.UnknownMod++
+			continue
+		}
+
+		if row.TargetText == "" {
+			stats.SkippedEmpty++
+			continue
+		}
+
+		source, err := repo.GetSourceByModAndKey(ctx, rowMod, row.Key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get source for %s: %w", row.Key, err)
+		}
+		if source == nil {
+			stats.NotFound++
+			continue
+		}
+
+		trans, err := repo.GetTranslationBySource(ctx, source.ID, targetLang)
+		if errors.Is(err, interfaces.ErrTranslationNotFound) {
+			stats.NotFound++
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get translation for %s: %w", row.Key, err)
+		}
+
+		conflict := CSVConflict{
+			File:          file,
+			Line:          row.Line,
+			ModID:         rowMod,
+			Key:           row.Key,
+			CSVSource:     row.SourceText,
+			CurrentSource: source.SourceText,
+			CSVTarget:     row.TargetText,
+		}
+		if trans.TargetText != nil {
+			conflict.CurrentTarget = *trans.TargetText
+		}
+
+		if row.SourceText != source.SourceText {
+			conflict.Reason = CSVConflictSourceChanged
+			stats.Conflicts = append(stats.Conflicts, conflict)
+			continue
+		}
+		if row.TargetText == source.SourceText {
+			stats.SameAsSource++
+			continue
+		}
+		if trans.TargetText != nil && *trans.TargetText == row.TargetText {
+			stats.Unchanged++
+			continue
+		}
+		if trans.TargetText != nil && (trans.Status == models.StatusVerified || trans.Status == models.StatusOfficial) {
+			conflict.Reason = CSVConflictProtected
+			stats.Conflicts = append(stats.Conflicts, conflict)
+			continue
+		}
+
+		target := row.TargetText
+		trans.TargetText = &target
+		trans.Status = models.StatusTranslated
+		if err := repo.SaveTranslation(ctx, trans); err != nil {
+			return nil, fmt.Errorf("failed to update %s: %w", row.Key, err)
+		}
+		stats.Updated++
+	}
+
+	return stats, nil
+}
@@ -0,0 +1,145 @@
+package export
+
+import (
+	"context"
+	"testing"
+
+	"github.com/iuif/minecraft-mod-dictionary/pkg/models"
+	"github.com/iuif/minecraft-mod-dictionary/pkg/repotest"
+)
+
+func TestParseTranslationCSV(t *testing.T) {
+	content := "\xef\xbb\xbfmod_id,key,source_text,target_text\n" +
+		"create,block.create.gear,Gear,歯車\n" +
+		"create,,Empty key,空\n" +
+		"botania,item.botania.petal,\"Petal, White\",\n"
+
+	rows, err := ParseTranslationCSV([]byte(content))
+	if err != nil {
+		t.Fatalf("ParseTranslationCSV() error = %v", err)
+	}
+	want := []TranslationCSVRow{
+		{Line: 2, ModID: "create", Key: "block.create.gear", SourceText: "Gear", TargetText: "歯車"},
+		{Line: 4, ModID: "botania", Key: "item.botania.petal", SourceText: "Petal, White"},
+	}
+	if len(rows) != len(want) {
+		t.Fatalf("ParseTranslationCSV() returned %d rows, want %d: %+v", len(rows), len(want), rows)
+	}
+	for i := range want {
+		if rows[i] != want[i] {
+			t.Errorf("row %d = %+v, want %+v", i, rows[i], want[i])
+		}
+	}
+
+	if _, err := ParseTranslationCSV([]byte("key,source_text\nk,v\n")); err == nil {
+		t.Error("ParseTranslationCSV() without target_text column succeeded, want error")
+	}
+}
+
+// seedCSVTranslation creates a source of modID with a ja_jp translation.
+func seedCSVTranslation(t *testing.T, repo *repotest.Repository, modID, key, sourceText, targetText, status string) {
+	t.Helper()
+	ctx := context.Background()
+
+	source, _, err := repo.GetOrCreateSource(ctx, modID, key, sourceText, "en_us")
+	if err != nil {
+		t.Fatalf("GetOrCreateSource() error = %v", err)
+	}
+	trans := &models.Translation{SourceID: source.ID, TargetLang: "ja_jp", Status: status}
+	if targetText != "" {
+		trans.TargetText = &targetText
+	}
+	if err := repo.SaveTranslation(ctx, trans); err != nil {
+		t.Fatalf("SaveTranslation() error = %v", err)
+	}
+}
+
+func TestApplyTranslationCSV(t *testing.T) {
+	ctx := context.Background()
+	repo := repotest.NewRepository()
+	for _, id := range []string{"create", "botania"} {
+		if err := repo.SaveMod(ctx, &models.Mod{ID: id, DisplayName: id}); err != nil {
+			t.Fatalf("SaveMod() error = %v", err)
+		}
+	}
+
+	seedCSVTranslation(t, repo, "create", "item.create.gear", "Gear", "", models.StatusPending)
+	seedCSVTranslation(t, repo, "create", "item.create.shaft", "Shaft", "シャフト", models.StatusTranslated)
+	seedCSVTranslation(t, repo, "create", "item.create.belt", "Mechanical Belt", "ベルト", models.StatusPending)
+	seedCSVTranslation(t, repo, "create", "item.create.wrench", "Wrench", "レンチ", models.StatusVerified)
+	seedCSVTranslation(t, repo, "botania", "item.botania.petal", "Petal", "", models.StatusPending)
+
+	rows := []TranslationCSVRow{
+		{Line: 2, Key: "item.create.gear", SourceText: "Gear", TargetText: "歯車"},
+		{Line: 3, Key: "item.create.shaft", SourceText: "Shaft", TargetText: "シャフト"},
+		{Line: 4, Key: "item.create.belt", SourceText: "Belt", TargetText: "メカニカルベルト"},
+		{Line: 5, Key: "item.create.wrench", SourceText: "Wrench", TargetText: "スパナ"},
+		{Line: 6, Key: "item.create.cog", SourceText: "Cog", TargetText: "コグ"},
+		{Line: 7, Key: "item.create.empty", SourceText: "Empty"},
+		{Line: 8, ModID: "botania", Key: "item.botania.petal", SourceText: "Petal", TargetText: "花びら"},
+		{Line: 9, ModID: "unknown", Key: "item.unknown.thing", SourceText: "Thing", TargetText: "物"},
+	}
+
+	stats, err := ApplyTranslationCSV(ctx, repo, "create", "ja_jp", "create.csv", rows)
+	if err != nil {
+		t.Fatalf("ApplyTranslationCSV() error = %v", err)
+	}
+
+	if stats.Rows != 8 || stats.Updated != 2 || stats.Unchanged != 1 || stats.NotFound != 1 ||
+		stats.SkippedEmpty != 1 || stats.UnknownMod != 1 {
+		t.Errorf("stats = %+v", stats)
+	}
+
+	if len(stats.Conflicts) != 2 {
+		t.Fatalf("got %d conflicts, want 2: %+v", len(stats.Conflicts), stats.Conflicts)
+	}
+	changed := stats.Conflicts[0]
+	if changed.Reason != CSVConflictSourceChanged || changed.Key != "item.create.belt" || changed.Line != 4 ||
+		changed.File != "create.csv" || changed.CSVSource != "Belt" || changed.CurrentSource != "Mechanical Belt" {
+		t.Errorf("source changed conflict = %+v", changed)
+	}
+	protected := stats.Conflicts[1]
+	if protected.Reason != CSVConflictProtected || protected.Key != "item.create.wrench" ||
+		protected.CSVTarget != "スパナ" || protected.CurrentTarget != "レンチ" {
+		t.Errorf("protected conflict = %+v", protected)
+	}
+
+	for _, tc := range []struct {
+		modID, key, want, status string
+	}{
+		{"create", "item.create.gear", "歯車", models.StatusTranslated},
+		{"create", "item.create.belt", "ベルト", models.StatusPending},
+		{"create", "item.create.wrench", "レンチ", models.StatusVerified},
+		{"botania", "item.botania.petal", "花びら", models.StatusTranslated},
+	} {
+		source, err := repo.GetSourceByModAndKey(ctx, tc.modID, tc.key)
+		if err != nil || source == nil {
+			t.Fatalf("GetSourceByModAndKey(%s) = %v, %v", tc.key, source, err)
+		}
+		trans, err := repo.GetTranslationBySource(ctx, source.ID, "ja_jp")
+		if err != nil {
+			t.Fatalf("GetTranslationBySource(%s) error = %v", tc.key, err)
+		}
+		if trans.TargetText == nil || *trans.TargetText != tc.want || trans.Status != tc.status {
+			t.Errorf("%s = %v (%s), want %q (%s)", tc.key, trans.TargetText, trans.Status, tc.want, tc.status)
+		}
+	}
+}
+
+func TestApplyTranslationCSV_SameAsSource(t *testing.T) {
+	ctx := context.Background()
+	repo := repotest.NewRepository()
+	if err := repo.SaveMod(ctx, &models.Mod{ID: "create", DisplayName: "Create"}); err != nil {
+		t.Fatalf("SaveMod() error = %v", err)
+	}
+	seedCSVTranslation(t, repo, "create", "item.create.rpm", "RPM", "", models.StatusPending)
+
+	rows := []TranslationCSVRow{{Line: 2, Key: "item.create.rpm", SourceText: "RPM", TargetText: "RPM"}}
+	stats, err := ApplyTranslationCSV(ctx, repo, "create", "ja_jp", "create.csv", rows)
+	if err != nil {
+		t.Fatalf("ApplyTranslationCSV() error = %v", err)
+	}
+	if stats.SameAsSource != 1 || stats.Updated != 0 {
+		t.Errorf("stats = %+v, want one row skipped as same as source", stats)
+	}
+}
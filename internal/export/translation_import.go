@@ -0,0 +1,131 @@
+package export
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/iuif/minecraft-mod-dictionary/pkg/interfaces"
+	"github.com/iuif/minecraft-mod-dictionary/pkg/models"
+)
+
+// TranslationImportRepository is the repository ApplyTranslations writes to.
+type TranslationImportRepository interface {
+	interfaces.SourceRepository
+	GetPendingSourcesBySameText(ctx context.Context, modID, sourceText string, excludeSourceID int64) ([]*models.TranslationSource, error)
+}
+
+// TranslationImportOptions configures ApplyTranslations.
+type TranslationImportOptions struct {
+	TargetLang string
+	Official   bool // save as verified translations by "official"; keys already official are skipped
+	Propagate  bool // also translate pending sources of the mod with the same source text
+}
+
+// TranslationImportStats counts the outcome of an imported key/translation map.
+type TranslationImportStats struct {
+	Keys            int
+	Updated         int
+	Propagated      int
+	AlreadyOfficial int
+	SkippedEmpty    int
+	SameAsSource    int
+	NotFound        int
+}
+
+// ApplyTranslations saves a key/translation map (translate -from-json, -from-yaml, -official)
+// to the translations of modID, in key order. Empty translations, translations equal to the
+// source text and keys without a source or translation are counted and skipped; any other
+// error is returned, so that a surrounding transaction can be rolled back.
+func ApplyTranslations(ctx context.Context, repo TranslationImportRepository, modID string, translations map[string]string, opts TranslationImportOptions) (*TranslationImportStats, error) {
+	stats := &TranslationImportStats{Keys: len(translations)}
+
+	keys := make([]string, 0, len(translations))
+	for key := range translations {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		target := translations[key]
+		// Skip empty translations to prevent data corruption
+		if target == "" {
+			stats.SkippedEmpty++
+			continue
+		}
+
+		source, err := repo.GetSourceByModAndKey(ctx, modID, key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get source for %s: %w", key, err)
+		}
+		if source == nil {
+			stats.NotFound++
+			continue
+		}
+
+		// Skip if target is same as source (untranslated)
+		if target == source.SourceText {
+			stats.SameAsSource++
+			continue
+		}
+
+		trans, err := repo.GetTranslationBySource(ctx, source.ID, opts.TargetLang)
+		if errors.Is(err, interfaces.ErrTranslationNotFound) {
+			stats.NotFound++
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get translation for %s: %w", key, err)
+		}
+
+		if opts.Official && trans.Translator != nil && *trans.Translator == "official" {
+			stats.AlreadyOfficial++
+			continue
+		}
+
+		setImportedTarget(trans, target, opts.Official)
+		if err := repo.SaveTranslation(ctx, trans); err != nil {
+			return nil, fmt.Errorf("failed to update %s: %w", key, err)
+		}
+		stats.Updated++
+
+		if !opts.Propagate {
+			continue
+		}
+
+		// Apply the same translation to other pending entries with identical source_text
+		pendingSources, err := repo.GetPendingSourcesBySameText(ctx, modID, source.SourceText, source.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get pending sources for %s: %w", key, err)
+		}
+		for _, pendingSource := range pendingSources {
+			pendingTrans, err := repo.GetTranslationBySource(ctx, pendingSource.ID, opts.TargetLang)
+			if errors.Is(err, interfaces.ErrTranslationNotFound) {
+				continue
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to get translation for %s: %w", pendingSource.Key, err)
+			}
+			setImportedTarget(pendingTrans, target, opts.Official)
+			if err := repo.SaveTranslation(ctx, pendingTrans); err != nil {
+				return nil, fmt.Errorf("failed to update %s: %w", pendingSource.Key, err)
+			}
+			stats.Propagated++
+		}
+	}
+
+	return stats, nil
+}
+
+// setImportedTarget sets the imported text and the status it is saved with.
+func setImportedTarget(trans *models.Translation, target string, official bool) {
+	trans.TargetText = &target
+	if official {
+		translator := "official"
+		trans.Status = models.StatusVerified
+		trans.Translator = &translator
+		return
+	}
+	trans.Status = models.StatusTranslated
+}
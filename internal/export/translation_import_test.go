@@ -0,0 +1,163 @@
+package export
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/iuif/minecraft-mod-dictionary/internal/database"
+	"github.com/iuif/minecraft-mod-dictionary/pkg/models"
+)
+
+// newImportRepository creates a SQLite database with the mod "create" whose default version
+// has the given keys (key -> source text), each with a pending ja_jp translation.
+func newImportRepository(t *testing.T, sources map[string]string) *database.Repository {
+	t.Helper()
+	ctx := context.Background()
+
+	repo, err := database.NewRepository(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("NewRepository() error = %v", err)
+	}
+	t.Cleanup(func() { repo.Close() })
+	if err := repo.Migrate(); err != nil {
+		t.Fatalf("Migrate() error = %v", err)
+	}
+
+	if err := repo.SaveMod(ctx, &models.Mod{ID: "create", DisplayName: "Create"}); err != nil {
+		t.Fatalf("SaveMod() error = %v", err)
+	}
+	version := &models.ModVersion{ModID: "create", Version: "0.5.1", MCVersion: "1.20.1", IsDefault: true}
+	if err := repo.SaveVersion(ctx, version); err != nil {
+		t.Fatalf("SaveVersion() error = %v", err)
+	}
+	for key, text := range sources {
+		source, _, err := repo.GetOrCreateSource(ctx, "create", key, text, "en_us")
+		if err != nil {
+			t.Fatalf("GetOrCreateSource() error = %v", err)
+		}
+		if err := repo.LinkSourceToVersion(ctx, source.ID, version.ID); err != nil {
+			t.Fatalf("LinkSourceToVersion() error = %v", err)
+		}
+		if err := repo.SaveTranslation(ctx, &models.Translation{SourceID: source.ID, TargetLang: "ja_jp", Status: models.StatusPending}); err != nil {
+			t.Fatalf("SaveTranslation() error = %v", err)
+		}
+	}
+	return repo
+}
+
+// importedTranslation returns the ja_jp translation of a key of the mod "create".
+func importedTranslation(t *testing.T, repo *database.Repository, key string) *models.Translation {
+	t.Helper()
+	ctx := context.Background()
+
+	source, err := repo.GetSourceByModAndKey(ctx, "create", key)
+	if err != nil || source == nil {
+		t.Fatalf("GetSourceByModAndKey(%s) = %v, %v", key, source, err)
+	}
+	trans, err := repo.GetTranslationBySource(ctx, source.ID, "ja_jp")
+	if err != nil {
+		t.Fatalf("GetTranslationBySource(%s) error = %v", key, err)
+	}
+	return trans
+}
+
+func TestApplyTranslations(t *testing.T) {
+	ctx := context.Background()
+	repo := newImportRepository(t, map[string]string{
+		"block.create.gearbox":   "Gearbox",
+		"item.create.gearbox":    "Gearbox",
+		"item.create.rpm":        "RPM",
+		"item.create.belt":       "Belt",
+		"item.create.wrench":     "Wrench",
+		"item.create.unaffected": "Unaffected",
+	})
+
+	translations := map[string]string{
+		"block.create.gearbox": "ギアボックス",
+		"item.create.rpm":      "RPM",
+		"item.create.belt":     "",
+		"item.create.missing":  "無い",
+	}
+	stats, err := ApplyTranslations(ctx, repo, "create", translations, TranslationImportOptions{TargetLang: "ja_jp", Propagate: true})
+	if err != nil {
+		t.Fatalf("ApplyTranslations() error = %v", err)
+	}
+	if stats.Keys != 4 || stats.Updated != 1 || stats.Propagated != 1 || stats.SameAsSource != 1 ||
+		stats.SkippedEmpty != 1 || stats.NotFound != 1 {
+		t.Errorf("stats = %+v", stats)
+	}
+	for _, key := range []string{"block.create.gearbox", "item.create.gearbox"} {
+		trans := importedTranslation(t, repo, key)
+		if trans.TargetText == nil || *trans.TargetText != "ギアボックス" || trans.Status != models.StatusTranslated {
+			t.Errorf("%s = %v (%s), want ギアボックス (translated)", key, trans.TargetText, trans.Status)
+		}
+	}
+
+	official := map[string]string{"item.create.wrench": "レンチ"}
+	opts := TranslationImportOptions{TargetLang: "ja_jp", Official: true}
+	if _, err := ApplyTranslations(ctx, repo, "create", official, opts); err != nil {
+		t.Fatalf("ApplyTranslations() official error = %v", err)
+	}
+	trans := importedTranslation(t, repo, "item.create.wrench")
+	if trans.Status != models.StatusVerified || trans.Translator == nil || *trans.Translator != "official" {
+		t.Errorf("official translation = %s by %v, want verified by official", trans.Status, trans.Translator)
+	}
+	stats, err = ApplyTranslations(ctx, repo, "create", official, opts)
+	if err != nil {
+		t.Fatalf("ApplyTranslations() official error = %v", err)
+	}
+	if stats.AlreadyOfficial != 1 || stats.Updated != 0 {
+		t.Errorf("stats of a repeated official import = %+v, want one key already official", stats)
+	}
+}
+
+// failingSaveRepository fails to save the translation of the source failSourceID.
+type failingSaveRepository struct {
+	*database.Repository
+	failSourceID int64
+}
+
+var errSaveFailed = errors.New("save failed")
+
+func (r *failingSaveRepository) SaveTranslation(ctx context.Context, translation *models.Translation) error {
+	if translation.SourceID == r.failSourceID {
+		return errSaveFailed
+	}
+	return r.Repository.SaveTranslation(ctx, translation)
+}
+
+func TestApplyTranslations_SaveErrorRollsBack(t *testing.T) {
+	ctx := context.Background()
+	repo := newImportRepository(t, map[string]string{
+		"item.create.belt":  "Belt",
+		"item.create.gear":  "Gear",
+		"item.create.shaft": "Shaft",
+	})
+	gear, err := repo.GetSourceByModAndKey(ctx, "create", "item.create.gear")
+	if err != nil || gear == nil {
+		t.Fatalf("GetSourceByModAndKey() = %v, %v", gear, err)
+	}
+
+	// Keys are applied in order, so the belt is saved before the gear fails
+	translations := map[string]string{
+		"item.create.belt":  "ベルト",
+		"item.create.gear":  "歯車",
+		"item.create.shaft": "シャフト",
+	}
+	err = repo.WithTx(ctx, func(txRepo *database.Repository) error {
+		_, err := ApplyTranslations(ctx, &failingSaveRepository{Repository: txRepo, failSourceID: gear.ID}, "create", translations, TranslationImportOptions{TargetLang: "ja_jp"})
+		return err
+	})
+	if !errors.Is(err, errSaveFailed) {
+		t.Fatalf("ApplyTranslations() in WithTx error = %v, want errSaveFailed", err)
+	}
+
+	for key := range translations {
+		trans := importedTranslation(t, repo, key)
+		if trans.TargetText != nil || trans.Status != models.StatusPending {
+			t.Errorf("%s = %v (%s) after a failed import, want untouched pending translation", key, trans.TargetText, trans.Status)
+		}
+	}
+}
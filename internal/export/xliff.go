@@ -0,0 +1,352 @@
+package export
+
+import (
+	"encoding/xml"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/iuif/minecraft-mod-dictionary/pkg/models"
+)
+
+const (
+	xliffNamespace         = "urn:oasis:names:tc:xliff:document:2.0"
+	xliffGlossaryNamespace = "urn:oasis:names:tc:xliff:glossary:2.0"
+
+	// xliffSubStatePrefix qualifies subState values carrying the exact moddict status.
+	xliffSubStatePrefix = "moddict:"
+	// xliffNoteCategory is the category of notes holding Translation.Notes.
+	xliffNoteCategory = "moddict"
+)
+
+// XLIFFUnit is one translation unit of an XLIFF file.
+type XLIFFUnit struct {
+	Key    string // lang key (unit name, or unit id if no name)
+	Source string
+	Target string // empty if the unit has no (or an untranslated) target
+	Status string // moddict status mapped from the segment state
+	Notes  string
+}
+
+// XLIFFDocument is the content of an XLIFF 2.0 file.
+type XLIFFDocument struct {
+	SourceLang string // Minecraft language code, e.g. "en_us"
+	TargetLang string // Minecraft language code, e.g. "ja_jp"
+	Units      []XLIFFUnit
+}
+
+// xliffRoot is the XML structure of an XLIFF 2.0 document.
+type xliffRoot struct {
+	XMLName  xml.Name    `xml:"urn:oasis:names:tc:xliff:document:2.0 xliff"`
+	XmlnsGls string      `xml:"xmlns:gls,attr,omitempty"`
+	Version  string      `xml:"version,attr"`
+	SrcLang  string      `xml:"srcLang,attr"`
+	TrgLang  string      `xml:"trgLang,attr,omitempty"`
+	Files    []xliffFile `xml:"file"`
+}
+
+type xliffFile struct {
+	ID    string      `xml:"id,attr"`
+	Units []xliffUnit `xml:"unit"`
+}
+
+type xliffUnit struct {
+	ID       string         `xml:"id,attr"`
+	Name     string         `xml:"name,attr,omitempty"`
+	Glossary *xliffGlossary `xml:"gls:glossary,omitempty"`
+	Notes    *xliffNotes    `xml:"notes,omitempty"`
+	Segments []xliffSegment `xml:"segment"`
+}
+
+type xliffNotes struct {
+	Notes []xliffNote `xml:"note"`
+}
+
+type xliffNote struct {
+	Category string `xml:"category,attr,omitempty"`
+	Text     string `xml:",chardata"`
+}
+
+type xliffSegment struct {
+	State    string  `xml:"state,attr,omitempty"`
+	SubState string  `xml:"subState,attr,omitempty"`
+	Source   string  `xml:"source"`
+	Target   *string `xml:"target"`
+}
+
+type xliffGlossary struct {
+	Entries []xliffGlossEntry `xml:"gls:glossEntry"`
+}
+
+type xliffGlossEntry struct {
+	Term        xliffGlossTerm `xml:"gls:term"`
+	Translation string         `xml:"gls:translation"`
+	Definition  string         `xml:"gls:definition,omitempty"`
+}
+
+type xliffGlossTerm struct {
+	Source string `xml:"source,attr"`
+	Text   string `xml:",chardata"`
+}
+
+// xliffInvalidIDChars matches characters not allowed in an XLIFF id (NMTOKEN).
+var xliffInvalidIDChars = regexp.MustCompile(`[^\p{L}\p{N}._:\-]`)
+
+// ExportXLIFF exports translations as an XLIFF 2.0 file for CAT tools (OmegaT, Trados, ...).
+// Each key becomes a unit; terms whose source text appears in a unit are attached as
+// glossary hints. Book entries are skipped.
+func (e *Exporter) ExportXLIFF(fileID, sourceLang, targetLang string, translations []*models.TranslationWithSource, terms []*models.Term, destPath string) error {
+	content, err := buildXLIFF(fileID, sourceLang, targetLang, translations, terms)
+	if err != nil {
+		return err
+	}
+	return e.writeFile(content, destPath)
+}
+
+func buildXLIFF(fileID, sourceLang, targetLang string, translations []*models.TranslationWithSource, terms []*models.Term) ([]byte, error) {
+	// Book entries are only exported as book files
+	sorted := LangTranslations(translations)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Key < sorted[j].Key
+	})
+
+	glossary := newTermMatcher(terms)
+	file := xliffFile{ID: xliffID(fileID)}
+	usedIDs := make(map[string]bool, len(sorted))
+
+	for _, t := range sorted {
+		unit := xliffUnit{ID: uniqueXLIFFID(xliffID(t.Key), usedIDs)}
+		if unit.ID != t.Key {
+			unit.Name = t.Key
+		}
+
+		state, subState := xliffState(t.Status)
+		segment := xliffSegment{
+			State:    state,
+			SubState: subState,
+			Source:   t.SourceText,
+		}
+		if t.TargetText != nil && *t.TargetText != "" {
+			segment.Target = t.TargetText
+		}
+		unit.Segments = []xliffSegment{segment}
+
+		if t.Notes != nil && *t.Notes != "" {
+			unit.Notes = &xliffNotes{Notes: []xliffNote{{Category: xliffNoteCategory, Text: *t.Notes}}}
+		}
+
+		if matches := glossary.match(t.SourceText); len(matches) > 0 {
+			unit.Glossary = &xliffGlossary{}
+			for _, term := range matches {
+				entry := xliffGlossEntry{
+					Term:        xliffGlossTerm{Source: term.Scope, Text: term.SourceText},
+					Translation: term.TargetText,
+				}
+				if term.Context != nil {
+					entry.Definition = *term.Context
+				}
+				unit.Glossary.Entries = append(unit.Glossary.Entries, entry)
+			}
+		}
+
+		file.Units = append(file.Units, unit)
+	}
+
+	root := xliffRoot{
+		Version: "2.0",
+		SrcLang: bcp47Lang(sourceLang),
+		TrgLang: bcp47Lang(targetLang),
+		Files:   []xliffFile{file},
+	}
+	if len(terms) > 0 {
+		root.XmlnsGls = xliffGlossaryNamespace
+	}
+
+	body, err := xml.MarshalIndent(root, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal XLIFF: %w", err)
+	}
+
+	return append([]byte(xml.Header), append(body, '\n')...), nil
+}
+
+// ParseXLIFF reads the units of an XLIFF 2.0 file.
+// Targets that merely copy the source of an "initial" segment are treated as untranslated.
+func ParseXLIFF(content []byte) (*XLIFFDocument, error) {
+	var root xliffRoot
+	if err := xml.Unmarshal(content, &root); err != nil {
+		return nil, fmt.Errorf("failed to parse XLIFF: %w", err)
+	}
+	if !strings.HasPrefix(root.Version, "2.") {
+		return nil, fmt.Errorf("unsupported XLIFF version: %q (2.0 required)", root.Version)
+	}
+
+	doc := &XLIFFDocument{
+		SourceLang: minecraftLang(root.SrcLang),
+		TargetLang: minecraftLang(root.TrgLang),
+	}
+
+	for _, file := range root.Files {
+		for _, unit := range file.Units {
+			key := unit.Name
+			if key == "" {
+				key = unit.ID
+			}
+
+			var source, target strings.Builder
+			var state, subState string
+			for _, segment := range unit.Segments {
+				source.WriteString(segment.Source)
+				if segment.Target != nil {
+					target.WriteString(*segment.Target)
+				}
+				if state == "" {
+					state, subState = segment.State, segment.SubState
+				}
+			}
+
+			u := XLIFFUnit{
+				Key:    key,
+				Source: source.String(),
+				Target: target.String(),
+			}
+			if (state == "" || state == "initial") && u.Target == u.Source {
+				u.Target = ""
+			}
+			u.Status = statusFromXLIFF(state, subState, u.Target != "")
+
+			if unit.Notes != nil {
+				var notes []string
+				for _, note := range unit.Notes.Notes {
+					if text := strings.TrimSpace(note.Text); text != "" {
+						notes = append(notes, text)
+					}
+				}
+				u.Notes = strings.Join(notes, "\n")
+			}
+
+			doc.Units = append(doc.Units, u)
+		}
+	}
+
+	return doc, nil
+}
+
+// xliffState maps a moddict status to an XLIFF segment state.
+// The exact status is kept in subState so it survives a round trip.
+func xliffState(status string) (state, subState string) {
+	switch status {
+	case models.StatusTranslated:
+		return "translated", ""
+	case models.StatusInherited:
+		return "translated", xliffSubStatePrefix + status
+	case models.StatusVerified:
+		return "reviewed", ""
+	case models.StatusOfficial:
+		return "final", xliffSubStatePrefix + status
+	case models.StatusNeedsReview:
+		return "initial", xliffSubStatePrefix + status
+	default:
+		return "initial", ""
+	}
+}
+
+// statusFromXLIFF maps an XLIFF segment state back to a moddict status.
+// A moddict subState wins as long as the CAT tool did not change the state.
+func statusFromXLIFF(state, subState string, hasTarget bool) string {
+	if status := strings.TrimPrefix(subState, xliffSubStatePrefix); status != subState {
+		if mapped, _ := xliffState(status); mapped == state {
+			return status
+		}
+	}
+
+	switch state {
+	case "translated":
+		return models.StatusTranslated
+	case "reviewed", "final":
+		return models.StatusVerified
+	case "initial":
+		if hasTarget {
+			return models.StatusNeedsReview
+		}
+		return models.StatusPending
+	default:
+		if hasTarget {
+			return models.StatusTranslated
+		}
+		return models.StatusPending
+	}
+}
+
+// bcp47Lang converts a Minecraft language code to a BCP 47 tag ("ja_jp" -> "ja-JP").
+func bcp47Lang(lang string) string {
+	parts := strings.SplitN(lang, "_", 2)
+	if len(parts) != 2 {
+		return lang
+	}
+	return strings.ToLower(parts[0]) + "-" + strings.ToUpper(parts[1])
+}
+
+// minecraftLang converts a BCP 47 tag to a Minecraft language code ("ja-JP" -> "ja_jp").
+func minecraftLang(lang string) string {
+	return strings.ToLower(strings.ReplaceAll(lang, "-", "_"))
+}
+
+// xliffID makes a key usable as an XLIFF id.
+func xliffID(key string) string {
+	id := xliffInvalidIDChars.ReplaceAllString(key, "_")
+	if id == "" {
+		return "_"
+	}
+	return id
+}
+
+// uniqueXLIFFID appends a counter if id is already used in the file.
+func uniqueXLIFFID(id string, used map[string]bool) string {
+	unique := id
+	for i := 2; used[unique]; i++ {
+		unique = fmt.Sprintf("%s_%d", id, i)
+	}
+	used[unique] = true
+	return unique
+}
+
+// termMatcher finds dictionary terms that occur in a source text.
+type termMatcher struct {
+	terms    []*models.Term
+	patterns []*regexp.Regexp
+}
+
+// newTermMatcher compiles word-boundary patterns for terms. Terms should be ordered by
+// priority; the first term per source text wins.
+func newTermMatcher(terms []*models.Term) *termMatcher {
+	m := &termMatcher{}
+	seen := make(map[string]bool)
+	for _, term := range terms {
+		source := strings.ToLower(strings.TrimSpace(term.SourceText))
+		if source == "" || seen[source] {
+			continue
+		}
+		seen[source] = true
+
+		pattern, err := regexp.Compile(`(?i)(^|[^\p{L}\p{N}_])` + regexp.QuoteMeta(term.SourceText) + `($|[^\p{L}\p{N}_])`)
+		if err != nil {
+			continue
+		}
+		m.terms = append(m.terms, term)
+		m.patterns = append(m.patterns, pattern)
+	}
+	return m
+}
+
+// match returns the terms occurring in text.
+func (m *termMatcher) match(text string) []*models.Term {
+	var matches []*models.Term
+	for i, pattern := range m.patterns {
+		if pattern.MatchString(text) {
+			matches = append(matches, m.terms[i])
+		}
+	}
+	return matches
+}
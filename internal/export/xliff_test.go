@@ -0,0 +1,174 @@
+package export
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/iuif/minecraft-mod-dictionary/pkg/models"
+)
+
+func TestExporter_ExportXLIFF_RoundTrip(t *testing.T) {
+	tmpDir := t.TempDir()
+	destPath := filepath.Join(tmpDir, "test.xlf")
+
+	target1 := "レッドストーンのたいまつ"
+	target2 := "公式訳"
+	note := "Block name, keep short"
+	context := "ore"
+	translations := []*models.TranslationWithSource{
+		{
+			Translation: models.Translation{TargetText: &target1, Status: models.StatusTranslated, Notes: &note},
+			Key:         "block.minecraft.redstone_torch",
+			SourceText:  "Redstone Torch",
+		},
+		{
+			Translation: models.Translation{Status: models.StatusPending},
+			Key:         "item.test.lines",
+			SourceText:  "Line 1\nLine <b>2</b> & more",
+		},
+		{
+			Translation: models.Translation{TargetText: &target2, Status: models.StatusOfficial},
+			Key:         "item.test.official",
+			SourceText:  "Official",
+		},
+	}
+	book := &models.TranslationWithSource{
+		Translation: models.Translation{TargetText: &target1, Status: models.StatusTranslated},
+		Key:         "book:assets/a/patchouli_books/b/en_us/e.json#pages[0].text",
+		SourceText:  "Book page",
+	}
+	terms := []*models.Term{
+		{Scope: "global", SourceText: "Redstone", TargetText: "レッドストーン", Context: &context},
+		{Scope: "global", SourceText: "Torch", TargetText: "たいまつ"},
+		{Scope: "global", SourceText: "Tor", TargetText: "x"},
+	}
+
+	exporter := NewExporter()
+	if err := exporter.ExportXLIFF("testmod", "en_us", "ja_jp", append(translations, book), terms, destPath); err != nil {
+		t.Fatalf("ExportXLIFF() error = %v", err)
+	}
+
+	content, err := os.ReadFile(destPath)
+	if err != nil {
+		t.Fatalf("Failed to read output: %v", err)
+	}
+	xml := string(content)
+
+	for _, want := range []string{
+		`<xliff xmlns="urn:oasis:names:tc:xliff:document:2.0" xmlns:gls="urn:oasis:names:tc:xliff:glossary:2.0" version="2.0" srcLang="en-US" trgLang="ja-JP">`,
+		`<unit id="block.minecraft.redstone_torch">`,
+		`<gls:term source="global">Redstone</gls:term>`,
+		`<gls:translation>たいまつ</gls:translation>`,
+		`<gls:definition>ore</gls:definition>`,
+		`<note category="moddict">Block name, keep short</note>`,
+		`<segment state="translated">`,
+		`<segment state="final" subState="moddict:official">`,
+	} {
+		if !strings.Contains(xml, want) {
+			t.Errorf("ExportXLIFF() output missing %q\n%s", want, xml)
+		}
+	}
+	if strings.Contains(xml, "Book page") {
+		t.Errorf("ExportXLIFF() exported a book entry\n%s", xml)
+	}
+	if strings.Contains(xml, ">x</gls:translation>") {
+		t.Errorf("ExportXLIFF() matched a partial word term")
+	}
+
+	doc, err := ParseXLIFF(content)
+	if err != nil {
+		t.Fatalf("ParseXLIFF() error = %v", err)
+	}
+	if doc.SourceLang != "en_us" || doc.TargetLang != "ja_jp" {
+		t.Errorf("ParseXLIFF() langs = %s, %s", doc.SourceLang, doc.TargetLang)
+	}
+
+	units := make(map[string]XLIFFUnit)
+	for _, u := range doc.Units {
+		units[u.Key] = u
+	}
+	for _, tr := range translations {
+		u, ok := units[tr.Key]
+		if !ok {
+			t.Errorf("ParseXLIFF() missing unit %s", tr.Key)
+			continue
+		}
+		if u.Source != tr.SourceText {
+			t.Errorf("unit %s Source = %q, want %q", tr.Key, u.Source, tr.SourceText)
+		}
+		wantTarget := ""
+		if tr.TargetText != nil {
+			wantTarget = *tr.TargetText
+		}
+		if u.Target != wantTarget {
+			t.Errorf("unit %s Target = %q, want %q", tr.Key, u.Target, wantTarget)
+		}
+		if u.Status != tr.Status {
+			t.Errorf("unit %s Status = %q, want %q", tr.Key, u.Status, tr.Status)
+		}
+	}
+	if got := units["block.minecraft.redstone_torch"].Notes; got != note {
+		t.Errorf("Notes = %q, want %q", got, note)
+	}
+}
+
+func TestParseXLIFF_States(t *testing.T) {
+	content := `<?xml version="1.0" encoding="UTF-8"?>
+<xliff xmlns="urn:oasis:names:tc:xliff:document:2.0" version="2.0" srcLang="en-US" trgLang="ja-JP">
+  <file id="f1">
+    <unit id="a"><segment state="reviewed"><source>A</source><target>エー</target></segment></unit>
+    <unit id="b"><segment state="initial"><source>B</source><target>B</target></segment></unit>
+    <unit id="c"><segment state="initial"><source>C</source><target>シー</target></segment></unit>
+    <unit id="d"><segment state="reviewed" subState="moddict:inherited"><source>D</source><target>ディー</target></segment></unit>
+    <unit id="e"><segment><source>E</source><target>イー</target></segment></unit>
+  </file>
+</xliff>`
+
+	doc, err := ParseXLIFF([]byte(content))
+	if err != nil {
+		t.Fatalf("ParseXLIFF() error = %v", err)
+	}
+
+	tests := []struct {
+		key        string
+		wantTarget string
+		wantStatus string
+	}{
+		{"a", "エー", models.StatusVerified},
+		{"b", "", models.StatusPending},
+		{"c", "シー", models.StatusNeedsReview},
+		{"d", "ディー", models.StatusVerified},
+		{"e", "イー", models.StatusTranslated},
+	}
+
+	if len(doc.Units) != len(tests) {
+		t.Fatalf("ParseXLIFF() returned %d units, want %d", len(doc.Units), len(tests))
+	}
+	for i, tt := range tests {
+		u := doc.Units[i]
+		if u.Key != tt.key || u.Target != tt.wantTarget || u.Status != tt.wantStatus {
+			t.Errorf("unit %d = (%q, %q, %q), want (%q, %q, %q)",
+				i, u.Key, u.Target, u.Status, tt.key, tt.wantTarget, tt.wantStatus)
+		}
+	}
+}
+
+func TestParseXLIFF_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{"not xml", "not xml"},
+		{"xliff 1.2", `<xliff xmlns="urn:oasis:names:tc:xliff:document:1.2" version="1.2"></xliff>`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseXLIFF([]byte(tt.content)); err == nil {
+				t.Error("ParseXLIFF() expected error")
+			}
+		})
+	}
+}
@@ -49,8 +49,64 @@ func (e *Extractor) Extract(jarPath, destDir string) (*ExtractResult, error) {
 		Metadata:   make(map[string]string),
 	}
 
-	// First pass: detect mod info and collect file list
+	// First pass: detect mod info
+	if err := e.detectModInfo(&reader.Reader, jarPath, result); err != nil {
+		return nil, err
+	}
+
+	// Second pass: extract files
+	for _, file := range reader.File {
+		// Skip directories
+		if file.FileInfo().IsDir() {
+			continue
+		}
+
+		destPath := filepath.Join(destDir, file.Name)
+
+		// Create parent directories
+		if err := os.MkdirAll(filepath.Dir(destPath), 0755); err != nil {
+			return nil, fmt.Errorf("failed to create directory: %w", err)
+		}
+
+		// Extract file
+		if err := extractFile(file, destPath); err != nil {
+			return nil, fmt.Errorf("failed to extract %s: %w", file.Name, err)
+		}
+
+		// Track lang files
+		if isLangFile(file.Name) {
+			result.LangFiles = append(result.LangFiles, destPath)
+		}
+	}
+
+	// If mod ID is still empty, try to detect from lang file paths
+	if result.ModID == "" && len(result.LangFiles) > 0 {
+		modID := detectModIDFromLangPath(result.LangFiles[0])
+		if modID != "" {
+			result.ModID = modID
+			result.DisplayName = modID // Use mod ID as display name fallback
+			fmt.Printf("Detected mod ID from lang file path: %s\n", modID)
+		}
+	}
+
+	// If still no mod ID, try to extract from JAR filename
+	if result.ModID == "" {
+		modID := extractModIDFromFilename(filepath.Base(destDir))
+		if modID != "" {
+			result.ModID = modID
+			result.DisplayName = modID
+			fmt.Printf("Detected mod ID from filename: %s\n", modID)
+		}
+	}
+
+	return result, nil
+}
+
+// detectModInfo reads mod metadata (fabric.mod.json, quilt.mod.json, [neoforge.]mods.toml,
+// MANIFEST.MF) from the JAR and fills the mod fields of result.
+func (e *Extractor) detectModInfo(reader *zip.Reader, jarPath string, result *ExtractResult) error {
 	var fabricModJSON, modsToml, manifestMF []byte
+	var err error
 	var isNeoForge bool
 
 	for _, file := range reader.File {
@@ -64,13 +120,13 @@ func (e *Extractor) Extract(jarPath, destDir string) (*ExtractResult, error) {
 		case "fabric.mod.json":
 			fabricModJSON, err = readZipFile(file)
 			if err != nil {
-				return nil, fmt.Errorf("failed to read fabric.mod.json: %w", err)
+				return fmt.Errorf("failed to read fabric.mod.json: %w", err)
 			}
 		case "META-INF/neoforge.mods.toml":
 			// NeoForge uses neoforge.mods.toml (prioritize over mods.toml)
 			modsToml, err = readZipFile(file)
 			if err != nil {
-				return nil, fmt.Errorf("failed to read neoforge.mods.toml: %w", err)
+				return fmt.Errorf("failed to read neoforge.mods.toml: %w", err)
 			}
 			isNeoForge = true
 		case "META-INF/mods.toml":
@@ -78,7 +134,7 @@ func (e *Extractor) Extract(jarPath, destDir string) (*ExtractResult, error) {
 			if modsToml == nil {
 				modsToml, err = readZipFile(file)
 				if err != nil {
-					return nil, fmt.Errorf("failed to read mods.toml: %w", err)
+					return fmt.Errorf("failed to read mods.toml: %w", err)
 				}
 			}
 		case "quilt.mod.json":
@@ -86,7 +142,7 @@ func (e *Extractor) Extract(jarPath, destDir string) (*ExtractResult, error) {
 			if fabricModJSON == nil {
 				fabricModJSON, err = readZipFile(file)
 				if err != nil {
-					return nil, fmt.Errorf("failed to read quilt.mod.json: %w", err)
+					return fmt.Errorf("failed to read quilt.mod.json: %w", err)
 				}
 				result.Loader = "quilt"
 			}
@@ -142,49 +198,41 @@ func (e *Extractor) Extract(jarPath, destDir string) (*ExtractResult, error) {
 		}
 	}
 
-	// Second pass: extract files
-	for _, file := range reader.File {
-		// Skip directories
-		if file.FileInfo().IsDir() {
-			continue
-		}
+	return nil
+}
 
-		destPath := filepath.Join(destDir, file.Name)
+// Inspect detects mod information from a JAR without extracting it.
+// Used to identify the mods in a modpack's mods folder.
+func (e *Extractor) Inspect(jarPath string) (*ExtractResult, error) {
+	reader, err := zip.OpenReader(jarPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open JAR: %w", err)
+	}
+	defer reader.Close()
 
-		// Create parent directories
-		if err := os.MkdirAll(filepath.Dir(destPath), 0755); err != nil {
-			return nil, fmt.Errorf("failed to create directory: %w", err)
-		}
+	result := &ExtractResult{
+		LangFiles: make([]string, 0),
+		Metadata:  make(map[string]string),
+	}
 
-		// Extract file
-		if err := extractFile(file, destPath); err != nil {
-			return nil, fmt.Errorf("failed to extract %s: %w", file.Name, err)
-		}
+	if err := e.detectModInfo(&reader.Reader, jarPath, result); err != nil {
+		return nil, err
+	}
 
-		// Track lang files
+	for _, file := range reader.File {
 		if isLangFile(file.Name) {
-			result.LangFiles = append(result.LangFiles, destPath)
+			result.LangFiles = append(result.LangFiles, file.Name)
 		}
 	}
 
-	// If mod ID is still empty, try to detect from lang file paths
+	// Fall back to the lang file namespace, then to the JAR filename
 	if result.ModID == "" && len(result.LangFiles) > 0 {
-		modID := detectModIDFromLangPath(result.LangFiles[0])
-		if modID != "" {
-			result.ModID = modID
-			result.DisplayName = modID // Use mod ID as display name fallback
-			fmt.Printf("Detected mod ID from lang file path: %s\n", modID)
-		}
+		result.ModID = strings.Split(result.LangFiles[0], "/")[1]
+		result.DisplayName = result.ModID
 	}
-
-	// If still no mod ID, try to extract from JAR filename
 	if result.ModID == "" {
-		modID := extractModIDFromFilename(filepath.Base(destDir))
-		if modID != "" {
-			result.ModID = modID
-			result.DisplayName = modID
-			fmt.Printf("Detected mod ID from filename: %s\n", modID)
-		}
+		result.ModID = extractModIDFromFilename(filepath.Base(jarPath))
+		result.DisplayName = result.ModID
 	}
 
 	return result, nil
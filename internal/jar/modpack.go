@@ -0,0 +1,188 @@
+package jar
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// PackMod is a mod installed in a modpack.
+type PackMod struct {
+	ModID    string // Mod ID (from mod metadata, or guessed from the file name for manifests)
+	Version  string // Mod version (may be empty if unknown)
+	FileName string // JAR file name
+}
+
+// Modpack lists the mods of a modpack instance or manifest.
+type Modpack struct {
+	Source    string    // mods folder or manifest the mods were read from
+	MCVersion string    // Minecraft version (manifests only)
+	Mods      []PackMod // Installed mods, sorted by mod ID
+}
+
+// modrinthIndex represents modrinth.index.json (the manifest inside .mrpack files).
+type modrinthIndex struct {
+	FormatVersion int    `json:"formatVersion"`
+	Game          string `json:"game"`
+	Files         []struct {
+		Path string `json:"path"`
+	} `json:"files"`
+	Dependencies map[string]string `json:"dependencies"`
+}
+
+// curseForgeManifest represents the CurseForge modpack manifest.json.
+type curseForgeManifest struct {
+	ManifestType string `json:"manifestType"`
+}
+
+// LoadModpack reads the mods of a modpack from one of:
+//   - a mods folder (every *.jar is inspected)
+//   - an instance folder containing mods/ or modrinth.index.json
+//   - a Modrinth modrinth.index.json or .mrpack file
+//
+// CurseForge manifest.json only lists project/file IDs, which cannot be mapped to
+// mod IDs offline; use the instance mods folder instead.
+func (e *Extractor) LoadModpack(path string) (*Modpack, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to access modpack: %w", err)
+	}
+
+	if info.IsDir() {
+		if sub := filepath.Join(path, "mods"); isDir(sub) {
+			return e.loadModsDir(sub)
+		}
+		if index := filepath.Join(path, "modrinth.index.json"); fileExists(index) {
+			return loadModrinthIndexFile(index)
+		}
+		return e.loadModsDir(path)
+	}
+
+	if strings.EqualFold(filepath.Ext(path), ".mrpack") {
+		return loadMrpack(path)
+	}
+	return loadModrinthIndexFile(path)
+}
+
+// loadModsDir inspects every JAR file in a mods folder.
+func (e *Extractor) loadModsDir(dir string) (*Modpack, error) {
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read mods directory: %w", err)
+	}
+
+	pack := &Modpack{Source: dir}
+	for _, f := range files {
+		if f.IsDir() || !strings.HasSuffix(strings.ToLower(f.Name()), ".jar") {
+			continue
+		}
+
+		result, err := e.Inspect(filepath.Join(dir, f.Name()))
+		if err != nil {
+			fmt.Printf("Warning: skipping %s: %v\n", f.Name(), err)
+			continue
+		}
+		pack.Mods = append(pack.Mods, PackMod{
+			ModID:    result.ModID,
+			Version:  result.Version,
+			FileName: f.Name(),
+		})
+	}
+
+	sortPackMods(pack.Mods)
+	return pack, nil
+}
+
+// loadMrpack reads modrinth.index.json from a .mrpack archive.
+func loadMrpack(path string) (*Modpack, error) {
+	reader, err := zip.OpenReader(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open mrpack: %w", err)
+	}
+	defer reader.Close()
+
+	for _, file := range reader.File {
+		if file.Name != "modrinth.index.json" {
+			continue
+		}
+		content, err := readZipFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read modrinth.index.json: %w", err)
+		}
+		return parseModrinthIndex(path, content)
+	}
+
+	return nil, fmt.Errorf("modrinth.index.json not found in %s", path)
+}
+
+// loadModrinthIndexFile reads a modpack manifest file.
+func loadModrinthIndexFile(path string) (*Modpack, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open manifest: %w", err)
+	}
+	defer f.Close()
+
+	content, err := io.ReadAll(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest: %w", err)
+	}
+	return parseModrinthIndex(path, content)
+}
+
+// parseModrinthIndex parses a Modrinth index. Mod IDs and versions are guessed
+// from the JAR file names, since the index carries no mod metadata.
+func parseModrinthIndex(source string, content []byte) (*Modpack, error) {
+	var cf curseForgeManifest
+	if err := json.Unmarshal(content, &cf); err == nil && cf.ManifestType == "minecraftModpack" {
+		return nil, fmt.Errorf("CurseForge manifest.json only lists project IDs; point to the instance mods folder instead")
+	}
+
+	var index modrinthIndex
+	if err := json.Unmarshal(content, &index); err != nil {
+		return nil, fmt.Errorf("failed to parse modrinth.index.json: %w", err)
+	}
+	if index.Game != "minecraft" {
+		return nil, fmt.Errorf("unsupported modpack manifest: %s", source)
+	}
+
+	pack := &Modpack{
+		Source:    source,
+		MCVersion: index.Dependencies["minecraft"],
+	}
+	for _, f := range index.Files {
+		if !strings.HasPrefix(f.Path, "mods/") || !strings.HasSuffix(strings.ToLower(f.Path), ".jar") {
+			continue
+		}
+		name := strings.TrimPrefix(f.Path, "mods/")
+		pack.Mods = append(pack.Mods, PackMod{
+			ModID:    extractModIDFromFilename(name),
+			Version:  extractVersionFromFilename(name),
+			FileName: name,
+		})
+	}
+
+	sortPackMods(pack.Mods)
+	return pack, nil
+}
+
+func sortPackMods(mods []PackMod) {
+	sort.SliceStable(mods, func(i, j int) bool {
+		return mods[i].ModID < mods[j].ModID
+	})
+}
+
+func isDir(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
+
+func fileExists(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && !info.IsDir()
+}
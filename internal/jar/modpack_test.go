@@ -0,0 +1,159 @@
+package jar
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestExtractor_Inspect(t *testing.T) {
+	tests := []struct {
+		name        string
+		files       map[string]string
+		wantModID   string
+		wantVersion string
+	}{
+		{
+			name: "fabric metadata",
+			files: map[string]string{
+				"fabric.mod.json":                `{"id": "testmod", "name": "Test Mod", "version": "1.2.0"}`,
+				"assets/testmod/lang/en_us.json": `{}`,
+			},
+			wantModID:   "testmod",
+			wantVersion: "1.2.0",
+		},
+		{
+			name: "lang namespace fallback",
+			files: map[string]string{
+				"assets/langonly/lang/en_us.json": `{}`,
+			},
+			wantModID: "langonly",
+		},
+	}
+
+	ext := NewExtractor()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jarPath := createTestJAR(t, tt.files)
+			defer os.Remove(jarPath)
+
+			result, err := ext.Inspect(jarPath)
+			if err != nil {
+				t.Fatalf("Inspect() error = %v", err)
+			}
+			if result.ModID != tt.wantModID {
+				t.Errorf("Inspect() ModID = %v, want %v", result.ModID, tt.wantModID)
+			}
+			if result.Version != tt.wantVersion {
+				t.Errorf("Inspect() Version = %v, want %v", result.Version, tt.wantVersion)
+			}
+		})
+	}
+}
+
+func TestExtractor_LoadModpack_ModsDir(t *testing.T) {
+	instance := t.TempDir()
+	modsDir := filepath.Join(instance, "mods")
+	if err := os.Mkdir(modsDir, 0755); err != nil {
+		t.Fatalf("Failed to create mods dir: %v", err)
+	}
+
+	jars := map[string]map[string]string{
+		"zeta-1.0.jar": {"fabric.mod.json": `{"id": "zeta", "version": "1.0"}`},
+		"alpha.jar":    {"META-INF/mods.toml": "[[mods]]\nmodId = \"alpha\"\nversion = \"2.3.4\"\n"},
+	}
+	for name, files := range jars {
+		jarPath := createTestJAR(t, files)
+		if err := os.Rename(jarPath, filepath.Join(modsDir, name)); err != nil {
+			t.Fatalf("Failed to move JAR: %v", err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(modsDir, "old.jar.disabled"), []byte("x"), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	pack, err := NewExtractor().LoadModpack(instance)
+	if err != nil {
+		t.Fatalf("LoadModpack() error = %v", err)
+	}
+
+	want := []PackMod{
+		{ModID: "alpha", Version: "2.3.4", FileName: "alpha.jar"},
+		{ModID: "zeta", Version: "1.0", FileName: "zeta-1.0.jar"},
+	}
+	if len(pack.Mods) != len(want) {
+		t.Fatalf("LoadModpack() returned %d mods, want %d: %+v", len(pack.Mods), len(want), pack.Mods)
+	}
+	for i := range want {
+		if pack.Mods[i] != want[i] {
+			t.Errorf("LoadModpack() Mods[%d] = %+v, want %+v", i, pack.Mods[i], want[i])
+		}
+	}
+}
+
+func TestExtractor_LoadModpack_Manifest(t *testing.T) {
+	tests := []struct {
+		name      string
+		manifest  string
+		wantMC    string
+		wantMods  []PackMod
+		wantError string
+	}{
+		{
+			name: "modrinth index",
+			manifest: `{
+  "formatVersion": 1,
+  "game": "minecraft",
+  "files": [
+    {"path": "mods/sodium-0.5.8.jar"},
+    {"path": "resourcepacks/faithful.zip"},
+    {"path": "mods/create-1.20.1-0.5.1.jar"}
+  ],
+  "dependencies": {"minecraft": "1.20.1", "fabric-loader": "0.15.7"}
+}`,
+			wantMC: "1.20.1",
+			wantMods: []PackMod{
+				{ModID: "create", Version: "0.5.1", FileName: "create-1.20.1-0.5.1.jar"},
+				{ModID: "sodium", Version: "0.5.8", FileName: "sodium-0.5.8.jar"},
+			},
+		},
+		{
+			name:      "curseforge manifest",
+			manifest:  `{"manifestType": "minecraftModpack", "files": [{"projectID": 1, "fileID": 2}]}`,
+			wantError: "CurseForge",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "manifest.json")
+			if err := os.WriteFile(path, []byte(tt.manifest), 0644); err != nil {
+				t.Fatalf("Failed to write manifest: %v", err)
+			}
+
+			pack, err := NewExtractor().LoadModpack(path)
+			if tt.wantError != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantError) {
+					t.Fatalf("LoadModpack() error = %v, want containing %q", err, tt.wantError)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("LoadModpack() error = %v", err)
+			}
+
+			if pack.MCVersion != tt.wantMC {
+				t.Errorf("LoadModpack() MCVersion = %v, want %v", pack.MCVersion, tt.wantMC)
+			}
+			if len(pack.Mods) != len(tt.wantMods) {
+				t.Fatalf("LoadModpack() returned %d mods, want %d: %+v", len(pack.Mods), len(tt.wantMods), pack.Mods)
+			}
+			for i := range tt.wantMods {
+				if pack.Mods[i] != tt.wantMods[i] {
+					t.Errorf("LoadModpack() Mods[%d] = %+v, want %+v", i, pack.Mods[i], tt.wantMods[i])
+				}
+			}
+		})
+	}
+}
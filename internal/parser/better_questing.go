@@ -0,0 +1,230 @@
+package parser
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/iuif/minecraft-mod-dictionary/pkg/interfaces"
+)
+
+// BetterQuestingParser parses Better Questing quest database files (DefaultQuests.json).
+//
+// Better Questing serializes NBT to JSON with the tag type appended to each key,
+// e.g. "name:8" (string), "questDatabase:9" (list), "properties:10" (compound).
+// Entries are keyed by quest/quest-line ID so they stay stable when the list is reordered:
+//
+//	quest.{id}.name, quest.{id}.desc, questline.{id}.name, questline.{id}.desc
+//
+// Newer Better Questing versions identify quests by UUID, written as two longs
+// ("questIDHigh:4", "questIDLow:4"); the ID is then the UUID string.
+type BetterQuestingParser struct{}
+
+// Compile-time check that BetterQuestingParser implements interfaces.Parser.
+var _ interfaces.Parser = (*BetterQuestingParser)(nil)
+
+// NewBetterQuestingParser creates a new Better Questing quest database parser.
+func NewBetterQuestingParser() *BetterQuestingParser {
+	return &BetterQuestingParser{}
+}
+
+// betterQuestingFields are the translatable fields in a quest or quest line's properties.
+var betterQuestingFields = []string{"name", "desc"}
+
+// Parse extracts translation entries from Better Questing JSON content.
+func (p *BetterQuestingParser) Parse(content []byte) ([]interfaces.ParsedEntry, error) {
+	data, err := decodeBetterQuesting(content)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := []interfaces.ParsedEntry{}
+
+	p.walk(data, func(prefix string, props map[string]interface{}) {
+		for _, field := range betterQuestingFields {
+			_, value, ok := bqLookup(props, field)
+			if !ok {
+				continue
+			}
+			text, ok := value.(string)
+			if !ok || text == "" {
+				continue
+			}
+			entries = append(entries, interfaces.ParsedEntry{
+				Key:  prefix + "." + field,
+				Text: text,
+				Tags: []string{"betterquesting", strings.SplitN(prefix, ".", 2)[0]},
+			})
+		}
+	})
+
+	// Sort for consistent ordering (the database is a map keyed by list index)
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Key < entries[j].Key
+	})
+
+	return entries, nil
+}
+
+// Apply applies translations to the original Better Questing JSON content.
+func (p *BetterQuestingParser) Apply(content []byte, translations map[string]string) ([]byte, error) {
+	data, err := decodeBetterQuesting(content)
+	if err != nil {
+		return nil, err
+	}
+
+	p.walk(data, func(prefix string, props map[string]interface{}) {
+		for _, field := range betterQuestingFields {
+			typedKey, value, ok := bqLookup(props, field)
+			if !ok {
+				continue
+			}
+			if _, isString := value.(string); !isString {
+				continue
+			}
+			if translation, exists := translations[prefix+"."+field]; exists {
+				props[typedKey] = translation
+			}
+		}
+	})
+
+	// Marshal with indentation for readability
+	result, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal JSON: %w", err)
+	}
+
+	return result, nil
+}
+
+// SupportedTypes returns the pattern types this parser handles.
+func (p *BetterQuestingParser) SupportedTypes() []string {
+	return []string{"quest"}
+}
+
+// walk calls fn with the key prefix and the "betterquesting" properties compound
+// of every quest and quest line in the database.
+func (p *BetterQuestingParser) walk(data map[string]interface{}, fn func(prefix string, props map[string]interface{})) {
+	if _, db, ok := bqLookup(data, "questDatabase"); ok {
+		for index, quest := range bqListItems(db) {
+			p.visit(quest, "quest", "questID", index, fn)
+		}
+	}
+
+	if _, lines, ok := bqLookup(data, "questLines"); ok {
+		for index, line := range bqListItems(lines) {
+			p.visit(line, "questline", "lineID", index, fn)
+		}
+	}
+}
+
+// visit resolves the ID and properties of a single quest or quest line.
+func (p *BetterQuestingParser) visit(item interface{}, kind, idField, index string, fn func(prefix string, props map[string]interface{})) {
+	obj, ok := item.(map[string]interface{})
+	if !ok {
+		return
+	}
+
+	id, ok := bqID(obj, idField)
+	if !ok {
+		id = index
+	}
+
+	_, propsValue, ok := bqLookup(obj, "properties")
+	if !ok {
+		return
+	}
+	propsObj, ok := propsValue.(map[string]interface{})
+	if !ok {
+		return
+	}
+	_, bqValue, ok := bqLookup(propsObj, "betterquesting")
+	if !ok {
+		return
+	}
+	props, ok := bqValue.(map[string]interface{})
+	if !ok {
+		return
+	}
+
+	fn(fmt.Sprintf("%s.%s", kind, id), props)
+}
+
+// bqID returns the ID of a quest or quest line: the numeric idField ("questID"), or the
+// UUID combined from its idField+"High" and idField+"Low" halves.
+func bqID(obj map[string]interface{}, idField string) (string, bool) {
+	if _, value, ok := bqLookup(obj, idField); ok {
+		return fmt.Sprint(value), true
+	}
+
+	_, highValue, okHigh := bqLookup(obj, idField+"High")
+	_, lowValue, okLow := bqLookup(obj, idField+"Low")
+	if !okHigh || !okLow {
+		return "", false
+	}
+	high, err := strconv.ParseInt(fmt.Sprint(highValue), 10, 64)
+	if err != nil {
+		return "", false
+	}
+	low, err := strconv.ParseInt(fmt.Sprint(lowValue), 10, 64)
+	if err != nil {
+		return "", false
+	}
+
+	// Same layout as java.util.UUID(mostSigBits, leastSigBits).toString()
+	hi, lo := uint64(high), uint64(low)
+	return fmt.Sprintf("%08x-%04x-%04x-%04x-%012x", hi>>32, (hi>>16)&0xffff, hi&0xffff, lo>>48, lo&0xffffffffffff), true
+}
+
+// decodeBetterQuesting decodes quest database JSON, keeping numbers as json.Number
+// so that IDs and timestamps survive Apply unchanged.
+func decodeBetterQuesting(content []byte) (map[string]interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	decoder.UseNumber()
+
+	var data map[string]interface{}
+	if err := decoder.Decode(&data); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON: %w", err)
+	}
+	return data, nil
+}
+
+// bqLookup finds a typed key ("name:8") by its bare name ("name").
+// Untyped keys are accepted as well. Returns the actual key, its value and whether it was found.
+func bqLookup(obj map[string]interface{}, name string) (string, interface{}, bool) {
+	if value, ok := obj[name]; ok {
+		return name, value, true
+	}
+	for key, value := range obj {
+		if idx := strings.LastIndex(key, ":"); idx >= 0 && key[:idx] == name {
+			return key, value, true
+		}
+	}
+	return "", nil, false
+}
+
+// bqListItems returns the elements of an NBT list, keyed by their list index.
+// Better Questing writes lists as objects keyed "0:10", "1:10", ...; plain JSON arrays are also accepted.
+func bqListItems(value interface{}) map[string]interface{} {
+	items := make(map[string]interface{})
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			index := key
+			if idx := strings.LastIndex(key, ":"); idx >= 0 {
+				index = key[:idx]
+			}
+			items[index] = item
+		}
+	case []interface{}:
+		for i, item := range v {
+			items[fmt.Sprint(i)] = item
+		}
+	}
+
+	return items
+}
@@ -0,0 +1,246 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+)
+
+const betterQuestingFixture = `{
+  "format:8": "2.0.0",
+  "questDatabase:9": {
+    "0:10": {
+      "questID:3": 12,
+      "preRequisites:11": [],
+      "properties:10": {
+        "betterquesting:10": {
+          "name:8": "Getting Wood",
+          "desc:8": "Punch a tree to collect some logs.",
+          "icon:10": {"id:8": "minecraft:log", "Count:3": 1},
+          "isMain:1": 1
+        }
+      }
+    },
+    "1:10": {
+      "questID:3": 13,
+      "properties:10": {
+        "betterquesting:10": {
+          "name:8": "Stone Age",
+          "desc:8": ""
+        }
+      }
+    }
+  },
+  "questLines:9": {
+    "0:10": {
+      "lineID:3": 3,
+      "properties:10": {
+        "betterquesting:10": {
+          "name:8": "Chapter 1",
+          "desc:8": "The beginning of your journey."
+        }
+      },
+      "quests:9": {}
+    }
+  }
+}`
+
+func TestBetterQuestingParser_Parse(t *testing.T) {
+	parser := NewBetterQuestingParser()
+
+	entries, err := parser.Parse([]byte(betterQuestingFixture))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	wantTexts := map[string]string{
+		"quest.12.name":    "Getting Wood",
+		"quest.12.desc":    "Punch a tree to collect some logs.",
+		"quest.13.name":    "Stone Age",
+		"questline.3.name": "Chapter 1",
+		"questline.3.desc": "The beginning of your journey.",
+	}
+
+	if len(entries) != len(wantTexts) {
+		t.Errorf("Parse() got %d entries, want %d", len(entries), len(wantTexts))
+	}
+
+	keyMap := make(map[string]string)
+	for _, entry := range entries {
+		keyMap[entry.Key] = entry.Text
+	}
+
+	for key, wantText := range wantTexts {
+		if gotText, exists := keyMap[key]; !exists {
+			t.Errorf("Parse() missing key %q", key)
+		} else if gotText != wantText {
+			t.Errorf("Parse() key %q = %q, want %q", key, gotText, wantText)
+		}
+	}
+
+	// Empty descriptions should not be extracted
+	if _, exists := keyMap["quest.13.desc"]; exists {
+		t.Error("Parse() should skip empty desc")
+	}
+}
+
+// betterQuestingUUIDFixture is a quest database of Better Questing versions that
+// identify quests and quest lines by UUID halves instead of numeric IDs.
+const betterQuestingUUIDFixture = `{
+  "format:8": "2.1.0",
+  "questDatabase:9": {
+    "0:10": {
+      "questIDHigh:4": 4551622028411358831,
+      "questIDLow:4": -6795153568590067944,
+      "properties:10": {
+        "betterquesting:10": {
+          "name:8": "Getting Wood",
+          "desc:8": "Punch a tree to collect some logs."
+        }
+      }
+    }
+  },
+  "questLines:9": {
+    "0:10": {
+      "lineIDHigh:4": -4539648215293343044,
+      "lineIDLow:4": -7066428039321122389,
+      "properties:10": {
+        "betterquesting:10": {
+          "name:8": "Chapter 1"
+        }
+      },
+      "quests:9": {
+        "0:10": {
+          "questIDHigh:4": 4551622028411358831,
+          "questIDLow:4": -6795153568590067944
+        }
+      }
+    }
+  }
+}`
+
+func TestBetterQuestingParser_Parse_UUIDs(t *testing.T) {
+	parser := NewBetterQuestingParser()
+
+	entries, err := parser.Parse([]byte(betterQuestingUUIDFixture))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	want := []string{
+		"quest.3f2a9c1e-8b4d-4e6f-a1b2-c3d4e5f60718.desc",
+		"quest.3f2a9c1e-8b4d-4e6f-a1b2-c3d4e5f60718.name",
+		"questline.c0ffee00-1234-4abc-9def-0123456789ab.name",
+	}
+	if len(entries) != len(want) {
+		t.Fatalf("Parse() got %d entries, want %d: %+v", len(entries), len(want), entries)
+	}
+	for i, key := range want {
+		if entries[i].Key != key {
+			t.Errorf("entry %d key = %q, want %q", i, entries[i].Key, key)
+		}
+	}
+
+	result, err := parser.Apply([]byte(betterQuestingUUIDFixture), map[string]string{
+		"quest.3f2a9c1e-8b4d-4e6f-a1b2-c3d4e5f60718.name":     "木を手に入れる",
+		"questline.c0ffee00-1234-4abc-9def-0123456789ab.name": "第1章",
+	})
+	if err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	for _, want := range []string{`"木を手に入れる"`, `"第1章"`, `4551622028411358831`, `-6795153568590067944`} {
+		if !strings.Contains(string(result), want) {
+			t.Errorf("Apply() result missing %s", want)
+		}
+	}
+}
+
+func TestBetterQuestingParser_Parse_FallbackToIndex(t *testing.T) {
+	parser := NewBetterQuestingParser()
+
+	content := `{
+		"questDatabase:9": {
+			"4:10": {
+				"properties:10": {"betterquesting:10": {"name:8": "No ID"}}
+			}
+		}
+	}`
+
+	entries, err := parser.Parse([]byte(content))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	if len(entries) != 1 || entries[0].Key != "quest.4.name" {
+		t.Errorf("Parse() = %+v, want single entry with key quest.4.name", entries)
+	}
+}
+
+func TestBetterQuestingParser_Parse_Invalid(t *testing.T) {
+	parser := NewBetterQuestingParser()
+
+	if _, err := parser.Parse([]byte(`{invalid}`)); err == nil {
+		t.Error("Parse() expected error for invalid JSON")
+	}
+
+	entries, err := parser.Parse([]byte(`{}`))
+	if err != nil {
+		t.Errorf("Parse() unexpected error for empty JSON: %v", err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("Parse() expected 0 entries for empty JSON, got %d", len(entries))
+	}
+}
+
+func TestBetterQuestingParser_Apply(t *testing.T) {
+	parser := NewBetterQuestingParser()
+
+	translations := map[string]string{
+		"quest.12.name":    "木材の入手",
+		"quest.12.desc":    "木を殴って原木を集めよう。",
+		"questline.3.name": "第1章",
+	}
+
+	result, err := parser.Apply([]byte(betterQuestingFixture), translations)
+	if err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+
+	entries, err := parser.Parse(result)
+	if err != nil {
+		t.Fatalf("Parse result error = %v", err)
+	}
+
+	entryMap := make(map[string]string)
+	for _, entry := range entries {
+		entryMap[entry.Key] = entry.Text
+	}
+
+	for key, expected := range translations {
+		if got, exists := entryMap[key]; !exists {
+			t.Errorf("Apply() missing key %q", key)
+		} else if got != expected {
+			t.Errorf("Apply() key %q = %q, want %q", key, got, expected)
+		}
+	}
+
+	// Untranslated entries keep their original text
+	if got := entryMap["quest.13.name"]; got != "Stone Age" {
+		t.Errorf("Apply() quest.13.name = %q, want %q", got, "Stone Age")
+	}
+
+	// Typed keys and non-text fields are preserved
+	for _, want := range []string{`"name:8"`, `"questID:3": 12`, `"isMain:1": 1`, `"format:8": "2.0.0"`} {
+		if !strings.Contains(string(result), want) {
+			t.Errorf("Apply() result missing %s", want)
+		}
+	}
+}
+
+func TestBetterQuestingParser_SupportedTypes(t *testing.T) {
+	parser := NewBetterQuestingParser()
+
+	types := parser.SupportedTypes()
+	if len(types) != 1 || types[0] != "quest" {
+		t.Errorf("SupportedTypes() = %v, want [quest]", types)
+	}
+}
@@ -0,0 +1,152 @@
+package parser
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/iuif/minecraft-mod-dictionary/pkg/interfaces"
+)
+
+// JSONGenericParser parses data pack JSON files (advancements, per-language data files, ...).
+//
+// It walks the whole document and extracts well-known display fields such as
+// "title", "description" and "lore". Field values may be plain strings or text
+// components; for components only the readable parts are extracted (see text_component.go).
+// Keys are JSON paths like "display.title.text" or "pages[0].text".
+type JSONGenericParser struct{}
+
+// Compile-time check that JSONGenericParser implements interfaces.Parser.
+var _ interfaces.Parser = (*JSONGenericParser)(nil)
+
+// NewJSONGenericParser creates a new generic data pack JSON parser.
+func NewJSONGenericParser() *JSONGenericParser {
+	return &JSONGenericParser{}
+}
+
+// jsonGenericTextFields are the field names treated as display text.
+var jsonGenericTextFields = map[string]bool{
+	"title":       true,
+	"subtitle":    true,
+	"description": true,
+	"name":        true,
+	"text":        true,
+	"tooltip":     true,
+	"lore":        true,
+}
+
+// resourceLocationRegex matches identifiers like "minecraft:stone", which are never display text.
+var resourceLocationRegex = regexp.MustCompile(`^#?[a-z0-9_.-]+:[a-z0-9_./-]+$`)
+
+// Parse extracts translation entries from data pack JSON content.
+func (p *JSONGenericParser) Parse(content []byte) ([]interfaces.ParsedEntry, error) {
+	data, err := decodeJSONGeneric(content)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields []textComponentField
+	p.walk("", data, &fields)
+
+	entries := make([]interfaces.ParsedEntry, 0, len(fields))
+	for _, f := range fields {
+		tags := []string{"data"}
+		if f.component {
+			tags = append(tags, "text_component")
+		}
+		entries = append(entries, interfaces.ParsedEntry{
+			Key:  f.key,
+			Text: f.text,
+			Tags: tags,
+		})
+	}
+
+	return entries, nil
+}
+
+// Apply applies translations to the original JSON content.
+func (p *JSONGenericParser) Apply(content []byte, translations map[string]string) ([]byte, error) {
+	data, err := decodeJSONGeneric(content)
+	if err != nil {
+		return nil, err
+	}
+
+	data = p.apply("", data, translations)
+
+	result, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal JSON: %w", err)
+	}
+
+	return result, nil
+}
+
+// SupportedTypes returns the pattern types this parser handles.
+func (p *JSONGenericParser) SupportedTypes() []string {
+	return []string{"data"}
+}
+
+// walk collects display fields in key order.
+func (p *JSONGenericParser) walk(path string, v interface{}, fields *[]textComponentField) {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(value))
+		for key := range value {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			childPath := joinComponentKey(path, key)
+			if jsonGenericTextFields[key] && !isIdentifierValue(value[key]) {
+				*fields = append(*fields, extractTextComponent(childPath, value[key])...)
+				continue
+			}
+			p.walk(childPath, value[key], fields)
+		}
+	case []interface{}:
+		for i, item := range value {
+			p.walk(fmt.Sprintf("%s[%d]", path, i), item, fields)
+		}
+	}
+}
+
+// apply mirrors walk, replacing display fields from translations.
+func (p *JSONGenericParser) apply(path string, v interface{}, translations map[string]string) interface{} {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		for key, child := range value {
+			childPath := joinComponentKey(path, key)
+			if jsonGenericTextFields[key] && !isIdentifierValue(child) {
+				value[key] = applyTextComponent(childPath, child, translations)
+				continue
+			}
+			value[key] = p.apply(childPath, child, translations)
+		}
+	case []interface{}:
+		for i, item := range value {
+			value[i] = p.apply(fmt.Sprintf("%s[%d]", path, i), item, translations)
+		}
+	}
+	return v
+}
+
+// isIdentifierValue reports whether v is a resource location rather than display text.
+func isIdentifierValue(v interface{}) bool {
+	s, ok := v.(string)
+	return ok && resourceLocationRegex.MatchString(strings.TrimSpace(s))
+}
+
+// decodeJSONGeneric decodes JSON keeping numbers as json.Number so they round-trip unchanged.
+func decodeJSONGeneric(content []byte) (interface{}, error) {
+	decoder := json.NewDecoder(strings.NewReader(string(content)))
+	decoder.UseNumber()
+
+	var data interface{}
+	if err := decoder.Decode(&data); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON: %w", err)
+	}
+	return data, nil
+}
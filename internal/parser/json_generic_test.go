@@ -0,0 +1,127 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+)
+
+const advancementFixture = `{
+  "display": {
+    "icon": {"item": "minecraft:diamond_pickaxe"},
+    "title": {"text": "Diamonds!", "color": "aqua"},
+    "description": {"translate": "advancements.mypack.diamonds.description", "fallback": "Mine your first diamond"},
+    "frame": "goal"
+  },
+  "parent": "mypack:root",
+  "criteria": {
+    "has_diamond": {
+      "trigger": "minecraft:inventory_changed",
+      "conditions": {"items": [{"items": ["minecraft:diamond"]}]}
+    }
+  },
+  "rewards": {"experience": 10}
+}`
+
+func TestJSONGenericParser_Parse(t *testing.T) {
+	parser := NewJSONGenericParser()
+
+	entries, err := parser.Parse([]byte(advancementFixture))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	wantTexts := map[string]string{
+		"display.title.text":           "Diamonds!",
+		"display.description.fallback": "Mine your first diamond",
+	}
+
+	if len(entries) != len(wantTexts) {
+		t.Errorf("Parse() got %d entries, want %d: %+v", len(entries), len(wantTexts), entries)
+	}
+
+	for _, entry := range entries {
+		if want, ok := wantTexts[entry.Key]; !ok {
+			t.Errorf("Parse() unexpected key %q", entry.Key)
+		} else if entry.Text != want {
+			t.Errorf("Parse() key %q = %q, want %q", entry.Key, entry.Text, want)
+		}
+	}
+}
+
+func TestJSONGenericParser_Parse_PlainStrings(t *testing.T) {
+	parser := NewJSONGenericParser()
+
+	content := `{
+		"name": "minecraft:stone",
+		"pages": [{"title": "Intro", "text": "Hello"}, {"type": "image"}],
+		"lore": ["Line one", {"text": "Line two", "italic": false}]
+	}`
+
+	entries, err := parser.Parse([]byte(content))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	keyMap := make(map[string]string)
+	for _, entry := range entries {
+		keyMap[entry.Key] = entry.Text
+	}
+
+	wantTexts := map[string]string{
+		"pages[0].title": "Intro",
+		"pages[0].text":  "Hello",
+		"lore[0]":        "Line one",
+		"lore[1].text":   "Line two",
+	}
+	for key, want := range wantTexts {
+		if got := keyMap[key]; got != want {
+			t.Errorf("Parse() key %q = %q, want %q", key, got, want)
+		}
+	}
+
+	// Resource locations are not display text
+	if _, exists := keyMap["name"]; exists {
+		t.Error("Parse() should skip identifier values")
+	}
+}
+
+func TestJSONGenericParser_Apply(t *testing.T) {
+	parser := NewJSONGenericParser()
+
+	translations := map[string]string{
+		"display.title.text":           "ダイヤモンド！",
+		"display.description.fallback": "初めてのダイヤモンドを採掘する",
+	}
+
+	result, err := parser.Apply([]byte(advancementFixture), translations)
+	if err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+
+	entries, err := parser.Parse(result)
+	if err != nil {
+		t.Fatalf("Parse result error = %v", err)
+	}
+
+	for _, entry := range entries {
+		if want := translations[entry.Key]; entry.Text != want {
+			t.Errorf("Apply() key %q = %q, want %q", entry.Key, entry.Text, want)
+		}
+	}
+
+	// Styling, translate keys and numbers are preserved
+	for _, want := range []string{`"color": "aqua"`, `"translate": "advancements.mypack.diamonds.description"`, `"experience": 10`} {
+		if !strings.Contains(string(result), want) {
+			t.Errorf("Apply() result missing %s", want)
+		}
+	}
+}
+
+func TestJSONGenericParser_SupportedTypes(t *testing.T) {
+	parser := NewJSONGenericParser()
+
+	types := parser.SupportedTypes()
+	if len(types) != 1 || types[0] != "data" {
+		t.Errorf("SupportedTypes() = %v, want [data]", types)
+	}
+}
@@ -62,26 +62,12 @@ func (p *JSONLangParser) Parse(content []byte) ([]interfaces.ParsedEntry, error)
 }
 
 // Apply applies translations to the original JSON content.
+// Values of existing keys are replaced in place; key order, whitespace, comments and
+// escapes of untranslated values are preserved (see RewriteJSONLang). content is the
+// source lang file, so translated keys missing from it have no source position and are
+// appended in key order; use RewriteJSONLang with AppendOrder to follow another file.
 func (p *JSONLangParser) Apply(content []byte, translations map[string]string) ([]byte, error) {
-	var data map[string]interface{}
-	if err := json.Unmarshal(content, &data); err != nil {
-		return nil, fmt.Errorf("failed to parse JSON: %w", err)
-	}
-
-	// Apply translations
-	for key, translation := range translations {
-		if _, exists := data[key]; exists {
-			data[key] = translation
-		}
-	}
-
-	// Marshal with indentation for readability
-	result, err := json.MarshalIndent(data, "", "  ")
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal JSON: %w", err)
-	}
-
-	return result, nil
+	return RewriteJSONLang(content, translations, AppendOrder(nil, translations))
 }
 
 // SupportedTypes returns the pattern types this parser handles.
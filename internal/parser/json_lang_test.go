@@ -126,6 +126,58 @@ func TestJSONLangParser_Apply(t *testing.T) {
 	}
 }
 
+func TestJSONLangParser_Apply_AppendsMissingKeys(t *testing.T) {
+	parser := NewJSONLangParser()
+
+	original := `{
+  "item.create.wrench": "Wrench"
+}`
+
+	result, err := parser.Apply([]byte(original), map[string]string{
+		"item.create.wrench":   "レンチ",
+		"item.create.goggles":  "ゴーグル",
+		"block.create.gearbox": "ギアボックス",
+	})
+	if err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+
+	want := `{
+  "item.create.wrench": "レンチ",
+  "block.create.gearbox": "ギアボックス",
+  "item.create.goggles": "ゴーグル"
+}`
+	if string(result) != want {
+		t.Errorf("Apply() =\n%s\nwant\n%s", result, want)
+	}
+}
+
+func TestJSONLangParser_Apply_PreservesOrderAndComments(t *testing.T) {
+	parser := NewJSONLangParser()
+
+	original := `{
+  // Tools
+  "item.create.wrench": "Wrench",
+  "block.create.gearbox": "Gearbox",
+  "tooltip.create.hint": "Don\u0027t panic"
+}`
+
+	result, err := parser.Apply([]byte(original), map[string]string{"block.create.gearbox": "ギアボックス"})
+	if err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+
+	want := `{
+  // Tools
+  "item.create.wrench": "Wrench",
+  "block.create.gearbox": "ギアボックス",
+  "tooltip.create.hint": "Don\u0027t panic"
+}`
+	if string(result) != want {
+		t.Errorf("Apply() =\n%s\nwant\n%s", result, want)
+	}
+}
+
 func TestJSONLangParser_SupportedTypes(t *testing.T) {
 	parser := NewJSONLangParser()
 
@@ -0,0 +1,558 @@
+package parser
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+)
+
+// jsonMember is a top-level member of a JSON object with its byte spans in the source.
+type jsonMember struct {
+	key        string
+	keyStart   int
+	keyEnd     int
+	valueStart int
+	valueEnd   int
+	commaEnd   int // offset just past the following comma, 0 if none
+	isString   bool
+	value      string // decoded value when isString
+}
+
+// jsonObjectLayout describes the top-level object of a JSON(C) document.
+type jsonObjectLayout struct {
+	members       []jsonMember
+	open          int  // offset of '{'
+	close         int  // offset of '}'
+	trailingComma bool // a comma follows the last member (JSONC)
+}
+
+// unicodeEscapeRegex matches \uXXXX escapes inside JSON strings.
+var unicodeEscapeRegex = regexp.MustCompile(`\\u([0-9a-fA-F]{4})`)
+
+// RewriteJSONLang rewrites string values of a flat JSON(C) lang file without re-marshaling it.
+//
+// Values of keys present in content are replaced in place; ordering, whitespace,
+// comments and the escapes of untouched values are kept byte-for-byte. Keys listed
+// in appendOrder that are missing from content but present in values are appended
+// after the last member, in appendOrder order, using the file's indentation.
+func RewriteJSONLang(content []byte, values map[string]string, appendOrder []string) ([]byte, error) {
+	layout, err := scanJSONObject(content)
+	if err != nil {
+		return nil, err
+	}
+
+	asciiOnly := usesASCIIEscapes(content)
+
+	var result bytes.Buffer
+	last := 0
+	existing := make(map[string]bool, len(layout.members))
+	for _, m := range layout.members {
+		existing[m.key] = true
+
+		value, ok := values[m.key]
+		if !ok || !m.isString || value == m.value {
+			continue
+		}
+		result.Write(content[last:m.valueStart])
+		result.WriteString(encodeJSONString(value, asciiOnly))
+		last = m.valueEnd
+	}
+
+	// Collect keys to append, in appendOrder order without duplicates
+	var added []string
+	for _, key := range appendOrder {
+		if _, ok := values[key]; ok && !existing[key] {
+			added = append(added, key)
+			existing[key] = true
+		}
+	}
+
+	if len(added) == 0 {
+		result.Write(content[last:])
+		return result.Bytes(), nil
+	}
+
+	insertAt, needComma := layout.appendPosition(content)
+	if insertAt < last {
+		return nil, fmt.Errorf("failed to append keys: unexpected layout")
+	}
+	result.Write(content[last:insertAt])
+
+	newline, indent, separator := layout.style(content)
+	if needComma {
+		result.WriteByte(',')
+	}
+	for i, key := range added {
+		if i > 0 {
+			result.WriteByte(',')
+		}
+		result.WriteString(newline)
+		result.WriteString(indent)
+		result.WriteString(encodeJSONString(key, asciiOnly))
+		result.WriteString(separator)
+		result.WriteString(encodeJSONString(values[key], asciiOnly))
+	}
+	if layout.trailingComma {
+		result.WriteByte(',')
+	}
+	if len(layout.members) == 0 {
+		result.WriteString(newline)
+	}
+	result.Write(content[insertAt:])
+
+	return result.Bytes(), nil
+}
+
+// MirrorJSONLang builds a lang file that follows the layout of source (typically en_us.json).
+//
+// Only string members whose key is in values are kept, with their value replaced;
+// key order, indentation, comments and blank-line grouping of the kept members are
+// carried over from source. Values equal to the source text keep their original escapes.
+func MirrorJSONLang(source []byte, values map[string]string) ([]byte, error) {
+	layout, err := scanJSONObject(source)
+	if err != nil {
+		return nil, err
+	}
+
+	asciiOnly := usesASCIIEscapes(source)
+
+	var result bytes.Buffer
+	result.Write(source[:layout.open+1])
+
+	var pending []byte // leading text of dropped members (comments, blank lines)
+	kept := 0
+	dropped := false // the previous member was dropped
+	for i, m := range layout.members {
+		leadStart := layout.open + 1
+		if i > 0 {
+			leadStart = layout.members[i-1].commaEnd
+		}
+		leading := source[leadStart:m.keyStart]
+		if dropped {
+			// A comment on the rest of a dropped member's line is dropped with it
+			if nl := bytes.IndexByte(leading, '\n'); nl >= 0 {
+				leading = leading[nl:]
+			}
+		}
+
+		value, ok := values[m.key]
+		dropped = !ok || !m.isString
+		if dropped {
+			// Keep comments and blank lines, drop the member's own indentation
+			if nl := bytes.LastIndexByte(leading, '\n'); nl >= 0 {
+				pending = append(pending, leading[:nl]...)
+			}
+			continue
+		}
+
+		if kept > 0 {
+			result.WriteByte(',')
+		}
+		result.Write(collapseBlankLines(append(pending, leading...)))
+		pending = pending[:0]
+
+		result.Write(source[m.keyStart:m.valueStart])
+		if value == m.value {
+			result.Write(source[m.valueStart:m.valueEnd])
+		} else {
+			result.WriteString(encodeJSONString(value, asciiOnly))
+		}
+		kept++
+	}
+
+	// Closing text: trailing comma (if the source uses one), whitespace and '}'
+	tailStart := layout.open + 1
+	if n := len(layout.members); n > 0 {
+		last := layout.members[n-1]
+		tailStart = last.valueEnd
+		if dropped {
+			// Skip the rest of the dropped member's line, keeping a trailing comma
+			// and the comment on the line of the last kept member
+			if kept > 0 {
+				if layout.trailingComma {
+					result.WriteByte(',')
+				}
+				if nl := bytes.IndexByte(pending, '\n'); nl >= 0 {
+					pending = pending[:nl]
+				}
+				result.Write(pending)
+			}
+			if nl := bytes.IndexByte(source[last.valueEnd:layout.close], '\n'); nl >= 0 {
+				tailStart = last.valueEnd + nl
+			} else if last.commaEnd != 0 {
+				tailStart = last.commaEnd
+			}
+		}
+	}
+	result.Write(source[tailStart:])
+
+	return result.Bytes(), nil
+}
+
+// collapseBlankLines reduces runs of blank lines to a single blank line.
+func collapseBlankLines(text []byte) []byte {
+	lines := bytes.Split(text, []byte("\n"))
+	out := make([][]byte, 0, len(lines))
+	blank := 0
+	for i, line := range lines {
+		if i > 0 && i < len(lines)-1 && len(bytes.TrimSpace(line)) == 0 {
+			blank++
+			if blank > 1 {
+				continue
+			}
+		} else {
+			blank = 0
+		}
+		out = append(out, line)
+	}
+	return bytes.Join(out, []byte("\n"))
+}
+
+// JSONLangKeys returns the top-level keys of a JSON(C) lang file in file order.
+func JSONLangKeys(content []byte) ([]string, error) {
+	layout, err := scanJSONObject(content)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, 0, len(layout.members))
+	for _, m := range layout.members {
+		keys = append(keys, m.key)
+	}
+	return keys, nil
+}
+
+// AppendOrder returns the order RewriteJSONLang appends keys of values in: keys in
+// sourceKeys order (the key order of the source lang file, see JSONLangKeys), followed
+// by the keys missing from sourceKeys in key order.
+func AppendOrder(sourceKeys []string, values map[string]string) []string {
+	order := make([]string, 0, len(values))
+	seen := make(map[string]bool, len(values))
+	for _, key := range sourceKeys {
+		if _, ok := values[key]; ok && !seen[key] {
+			order = append(order, key)
+			seen[key] = true
+		}
+	}
+
+	rest := make([]string, 0, len(values)-len(order))
+	for key := range values {
+		if !seen[key] {
+			rest = append(rest, key)
+		}
+	}
+	sort.Strings(rest)
+	return append(order, rest...)
+}
+
+// appendPosition returns where new members are inserted and whether a comma
+// must be written first. New members go after the last member's line
+// (after its comma and any // comment), or just before '}' for empty objects.
+func (l *jsonObjectLayout) appendPosition(content []byte) (int, bool) {
+	if len(l.members) == 0 {
+		return l.close, false
+	}
+
+	pos := l.members[len(l.members)-1].valueEnd
+	if !l.trailingComma {
+		// Insert the separating comma directly after the value
+		return pos, true
+	}
+
+	for pos < l.close && (content[pos] == ' ' || content[pos] == '\t') {
+		pos++
+	}
+	if pos < l.close && content[pos] == ',' {
+		pos++
+	}
+	afterComma := pos
+	for pos < l.close && (content[pos] == ' ' || content[pos] == '\t') {
+		pos++
+	}
+	if pos+1 < l.close && content[pos] == '/' && content[pos+1] == '/' {
+		for pos < l.close && content[pos] != '\n' && content[pos] != '\r' {
+			pos++
+		}
+	}
+	if pos < l.close && content[pos] != '\n' && content[pos] != '\r' {
+		// More content on the same line: insert right after the comma
+		return afterComma, false
+	}
+	return pos, false
+}
+
+// style detects the newline, member indentation and key/value separator of the file.
+func (l *jsonObjectLayout) style(content []byte) (newline, indent, separator string) {
+	newline, indent, separator = "\n", "  ", ": "
+	if bytes.Contains(content, []byte("\r\n")) {
+		newline = "\r\n"
+	}
+	if len(l.members) == 0 {
+		return
+	}
+
+	first := l.members[0]
+	lineStart := bytes.LastIndexByte(content[:first.keyStart], '\n')
+	if lineStart >= l.open {
+		ws := content[lineStart+1 : first.keyStart]
+		if len(bytes.Trim(ws, " \t")) == 0 {
+			indent = string(ws)
+		}
+	} else {
+		// Single-line object
+		newline, indent = "", " "
+	}
+
+	sep := content[first.keyEnd:first.valueStart]
+	if !bytes.ContainsAny(sep, "\n/") {
+		separator = string(sep)
+	}
+	return
+}
+
+// usesASCIIEscapes reports whether the file writes non-ASCII characters as \uXXXX escapes.
+func usesASCIIEscapes(content []byte) bool {
+	for _, c := range content {
+		if c >= 0x80 {
+			return false
+		}
+	}
+	for _, m := range unicodeEscapeRegex.FindAllSubmatch(content, -1) {
+		if code, err := strconv.ParseUint(string(m[1]), 16, 32); err == nil && code >= 0x80 {
+			return true
+		}
+	}
+	return false
+}
+
+// encodeJSONString encodes s as a JSON string literal without HTML escaping.
+// When asciiOnly is set, non-ASCII characters are written as \uXXXX escapes.
+func encodeJSONString(s string, asciiOnly bool) string {
+	var sb strings.Builder
+	sb.WriteByte('"')
+	for _, r := range s {
+		switch {
+		case r == '"':
+			sb.WriteString(`\"`)
+		case r == '\\':
+			sb.WriteString(`\\`)
+		case r == '\n':
+			sb.WriteString(`\n`)
+		case r == '\r':
+			sb.WriteString(`\r`)
+		case r == '\t':
+			sb.WriteString(`\t`)
+		case r < 0x20:
+			fmt.Fprintf(&sb, `\u%04x`, r)
+		case r >= 0x80 && asciiOnly:
+			if r > 0xFFFF {
+				hi, lo := utf16.EncodeRune(r)
+				fmt.Fprintf(&sb, `\u%04x\u%04x`, hi, lo)
+			} else {
+				fmt.Fprintf(&sb, `\u%04x`, r)
+			}
+		default:
+			sb.WriteRune(r)
+		}
+	}
+	sb.WriteByte('"')
+	return sb.String()
+}
+
+// ==================== JSON(C) Scanner ====================
+
+// jsonScanner walks JSON with // and /* */ comments, recording positions.
+type jsonScanner struct {
+	src []byte
+	pos int
+}
+
+// scanJSONObject scans the top-level object of content.
+func scanJSONObject(content []byte) (*jsonObjectLayout, error) {
+	s := &jsonScanner{src: content}
+	if bytes.HasPrefix(content, []byte("\xEF\xBB\xBF")) {
+		s.pos = 3
+	}
+
+	if err := s.skip(); err != nil {
+		return nil, err
+	}
+	if s.pos >= len(s.src) || s.src[s.pos] != '{' {
+		return nil, fmt.Errorf("failed to parse JSON: expected '{' at offset %d", s.pos)
+	}
+
+	layout := &jsonObjectLayout{open: s.pos}
+	s.pos++
+
+	for {
+		if err := s.skip(); err != nil {
+			return nil, err
+		}
+		if s.pos >= len(s.src) {
+			return nil, fmt.Errorf("failed to parse JSON: unterminated object")
+		}
+		if s.src[s.pos] == '}' {
+			layout.trailingComma = len(layout.members) > 0
+			break
+		}
+
+		m := jsonMember{keyStart: s.pos}
+		key, err := s.str()
+		if err != nil {
+			return nil, err
+		}
+		m.key, m.keyEnd = key, s.pos
+
+		if err := s.skip(); err != nil {
+			return nil, err
+		}
+		if s.pos >= len(s.src) || s.src[s.pos] != ':' {
+			return nil, fmt.Errorf("failed to parse JSON: expected ':' after key %q at offset %d", key, s.pos)
+		}
+		s.pos++
+		if err := s.skip(); err != nil {
+			return nil, err
+		}
+
+		m.valueStart = s.pos
+		if s.pos < len(s.src) && s.src[s.pos] == '"' {
+			m.value, err = s.str()
+			m.isString = true
+		} else {
+			err = s.skipValue()
+		}
+		if err != nil {
+			return nil, err
+		}
+		m.valueEnd = s.pos
+
+		if err := s.skip(); err != nil {
+			return nil, err
+		}
+		if s.pos >= len(s.src) {
+			return nil, fmt.Errorf("failed to parse JSON: unterminated object")
+		}
+		if s.src[s.pos] == ',' {
+			s.pos++
+			m.commaEnd = s.pos
+			layout.members = append(layout.members, m)
+			continue
+		}
+		layout.members = append(layout.members, m)
+		if s.src[s.pos] != '}' {
+			return nil, fmt.Errorf("failed to parse JSON: expected ',' or '}' at offset %d", s.pos)
+		}
+		break
+	}
+
+	layout.close = s.pos
+	s.pos++
+	if err := s.skip(); err != nil {
+		return nil, err
+	}
+	if s.pos < len(s.src) {
+		return nil, fmt.Errorf("failed to parse JSON: unexpected data at offset %d", s.pos)
+	}
+
+	return layout, nil
+}
+
+// skip skips whitespace and comments.
+func (s *jsonScanner) skip() error {
+	for s.pos < len(s.src) {
+		switch c := s.src[s.pos]; {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			s.pos++
+		case c == '/' && s.pos+1 < len(s.src) && s.src[s.pos+1] == '/':
+			for s.pos < len(s.src) && s.src[s.pos] != '\n' {
+				s.pos++
+			}
+		case c == '/' && s.pos+1 < len(s.src) && s.src[s.pos+1] == '*':
+			end := bytes.Index(s.src[s.pos+2:], []byte("*/"))
+			if end < 0 {
+				return fmt.Errorf("failed to parse JSON: unterminated comment at offset %d", s.pos)
+			}
+			s.pos += end + 4
+		default:
+			return nil
+		}
+	}
+	return nil
+}
+
+// str reads and decodes a string literal.
+func (s *jsonScanner) str() (string, error) {
+	start := s.pos
+	if s.pos >= len(s.src) || s.src[s.pos] != '"' {
+		return "", fmt.Errorf("failed to parse JSON: expected string at offset %d", s.pos)
+	}
+	s.pos++
+
+	for s.pos < len(s.src) {
+		switch s.src[s.pos] {
+		case '\\':
+			s.pos += 2
+		case '"':
+			s.pos++
+			var value string
+			if err := json.Unmarshal(s.src[start:s.pos], &value); err != nil {
+				return "", fmt.Errorf("failed to parse JSON string at offset %d: %w", start, err)
+			}
+			return value, nil
+		default:
+			s.pos++
+		}
+	}
+
+	return "", fmt.Errorf("failed to parse JSON: unterminated string at offset %d", start)
+}
+
+// skipValue skips a non-string value (object, array, number, literal).
+func (s *jsonScanner) skipValue() error {
+	if s.pos >= len(s.src) {
+		return fmt.Errorf("failed to parse JSON: unexpected end of input")
+	}
+
+	switch s.src[s.pos] {
+	case '{', '[':
+		depth := 0
+		for s.pos < len(s.src) {
+			if err := s.skip(); err != nil {
+				return err
+			}
+			if s.pos >= len(s.src) {
+				break
+			}
+			switch s.src[s.pos] {
+			case '"':
+				if _, err := s.str(); err != nil {
+					return err
+				}
+				continue
+			case '{', '[':
+				depth++
+			case '}', ']':
+				depth--
+			}
+			s.pos++
+			if depth == 0 {
+				return nil
+			}
+		}
+		return fmt.Errorf("failed to parse JSON: unterminated value")
+	default:
+		start := s.pos
+		for s.pos < len(s.src) && !strings.ContainsRune(" \t\r\n,}]/", rune(s.src[s.pos])) {
+			s.pos++
+		}
+		if s.pos == start {
+			return fmt.Errorf("failed to parse JSON: unexpected character %q at offset %d", s.src[s.pos], s.pos)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,318 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRewriteJSONLang_PreservesFormatting(t *testing.T) {
+	original := `{
+    // Items
+    "item.mymod.wrench": "Wrench",
+    "item.mymod.gear":   "Gear & Axle",
+    /* Blocks */
+    "block.mymod.press": "Press",
+    "block.mymod.count": 3
+}
+`
+
+	result, err := RewriteJSONLang([]byte(original), map[string]string{
+		"block.mymod.press": "プレス",
+		"item.mymod.gear":   "Gear & Axle", // same text as original: untouched
+		"unknown.key":       "ignored",
+	}, nil)
+	if err != nil {
+		t.Fatalf("RewriteJSONLang() error = %v", err)
+	}
+
+	want := `{
+    // Items
+    "item.mymod.wrench": "Wrench",
+    "item.mymod.gear":   "Gear & Axle",
+    /* Blocks */
+    "block.mymod.press": "プレス",
+    "block.mymod.count": 3
+}
+`
+	if string(result) != want {
+		t.Errorf("RewriteJSONLang() =\n%s\nwant\n%s", result, want)
+	}
+}
+
+func TestRewriteJSONLang_Escapes(t *testing.T) {
+	tests := []struct {
+		name     string
+		original string
+		value    string
+		want     string
+	}{
+		{
+			name:     "raw_utf8",
+			original: `{"a": "x", "b": "é"}`,
+			value:    "Line1\n\"レンチ\"",
+			want:     `{"a": "Line1\n\"レンチ\"", "b": "é"}`,
+		},
+		{
+			name:     "ascii_escaped_file",
+			original: `{"a": "x", "b": "\u00e9"}`,
+			value:    "レンチ😀",
+			want:     `{"a": "\u30ec\u30f3\u30c1\ud83d\ude00", "b": "\u00e9"}`,
+		},
+		{
+			name:     "no_html_escaping",
+			original: `{"a": "x"}`,
+			value:    "<b> & </b>",
+			want:     `{"a": "<b> & </b>"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := RewriteJSONLang([]byte(tt.original), map[string]string{"a": tt.value}, nil)
+			if err != nil {
+				t.Fatalf("RewriteJSONLang() error = %v", err)
+			}
+			if string(result) != tt.want {
+				t.Errorf("RewriteJSONLang() = %s, want %s", result, tt.want)
+			}
+		})
+	}
+}
+
+func TestRewriteJSONLang_AppendsInOrder(t *testing.T) {
+	values := map[string]string{
+		"b": "B",
+		"c": "C",
+		"d": "D",
+	}
+	order := []string{"a", "d", "b", "c"}
+
+	tests := []struct {
+		name     string
+		original string
+		want     string
+	}{
+		{
+			name:     "multiline",
+			original: "{\n  \"a\": \"A\"\n}\n",
+			want:     "{\n  \"a\": \"A\",\n  \"d\": \"D\",\n  \"b\": \"B\",\n  \"c\": \"C\"\n}\n",
+		},
+		{
+			name:     "trailing_comma_and_comment",
+			original: "{\n\t\"a\":\"A\", // last\n}",
+			want:     "{\n\t\"a\":\"A\", // last\n\t\"d\":\"D\",\n\t\"b\":\"B\",\n\t\"c\":\"C\",\n}",
+		},
+		{
+			name:     "crlf",
+			original: "{\r\n  \"a\": \"A\"\r\n}",
+			want:     "{\r\n  \"a\": \"A\",\r\n  \"d\": \"D\",\r\n  \"b\": \"B\",\r\n  \"c\": \"C\"\r\n}",
+		},
+		{
+			name:     "single_line",
+			original: `{"a": "A"}`,
+			want:     `{"a": "A", "d": "D", "b": "B", "c": "C"}`,
+		},
+		{
+			name:     "empty_object",
+			original: "{}",
+			want:     "{\n  \"d\": \"D\",\n  \"b\": \"B\",\n  \"c\": \"C\"\n}",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := RewriteJSONLang([]byte(tt.original), values, order)
+			if err != nil {
+				t.Fatalf("RewriteJSONLang() error = %v", err)
+			}
+			if string(result) != tt.want {
+				t.Errorf("RewriteJSONLang() =\n%q\nwant\n%q", result, tt.want)
+			}
+		})
+	}
+}
+
+func TestRewriteJSONLang_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{name: "not_object", content: `["a"]`},
+		{name: "unterminated", content: `{"a": "b"`},
+		{name: "missing_colon", content: `{"a" "b"}`},
+		{name: "unterminated_comment", content: `{"a": "b" /* }`},
+		{name: "trailing_data", content: `{"a": "b"} x`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := RewriteJSONLang([]byte(tt.content), map[string]string{"a": "x"}, nil); err == nil {
+				t.Error("RewriteJSONLang() expected error for invalid content")
+			}
+		})
+	}
+}
+
+func TestJSONLangKeys(t *testing.T) {
+	content := "\xEF\xBB\xBF" + `{
+  "z.key": "Z",
+  // comment
+  "a.key": {"nested": ["x", "}"]},
+  "m.key": "M",
+}`
+
+	keys, err := JSONLangKeys([]byte(content))
+	if err != nil {
+		t.Fatalf("JSONLangKeys() error = %v", err)
+	}
+
+	want := []string{"z.key", "a.key", "m.key"}
+	if !reflect.DeepEqual(keys, want) {
+		t.Errorf("JSONLangKeys() = %v, want %v", keys, want)
+	}
+}
+
+func TestMirrorJSONLang(t *testing.T) {
+	source := `{
+    // Items
+    "item.mymod.wrench": "Wrench",
+    "item.mymod.gear": "Gear",
+
+    // Blocks
+    "block.mymod.press": "Press",
+    "block.mymod.drill": "Drill"
+}
+`
+
+	tests := []struct {
+		name   string
+		values map[string]string
+		want   string
+	}{
+		{
+			name: "all_translated",
+			values: map[string]string{
+				"item.mymod.wrench": "レンチ",
+				"item.mymod.gear":   "歯車",
+				"block.mymod.press": "プレス",
+				"block.mymod.drill": "ドリル",
+			},
+			want: `{
+    // Items
+    "item.mymod.wrench": "レンチ",
+    "item.mymod.gear": "歯車",
+
+    // Blocks
+    "block.mymod.press": "プレス",
+    "block.mymod.drill": "ドリル"
+}
+`,
+		},
+		{
+			name: "omit_untranslated",
+			values: map[string]string{
+				"item.mymod.wrench": "レンチ",
+				"block.mymod.drill": "ドリル",
+			},
+			want: `{
+    // Items
+    "item.mymod.wrench": "レンチ",
+
+    // Blocks
+    "block.mymod.drill": "ドリル"
+}
+`,
+		},
+		{
+			name: "drop_first_of_group",
+			values: map[string]string{
+				"item.mymod.gear":   "歯車",
+				"block.mymod.press": "プレス",
+			},
+			want: `{
+    // Items
+    "item.mymod.gear": "歯車",
+
+    // Blocks
+    "block.mymod.press": "プレス"
+}
+`,
+		},
+		{
+			name:   "nothing_translated",
+			values: map[string]string{},
+			want:   "{\n}\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := MirrorJSONLang([]byte(source), tt.values)
+			if err != nil {
+				t.Fatalf("MirrorJSONLang() error = %v", err)
+			}
+			if string(result) != tt.want {
+				t.Errorf("MirrorJSONLang() =\n%s\nwant\n%s", result, tt.want)
+			}
+		})
+	}
+}
+
+func TestMirrorJSONLang_TrailingComments(t *testing.T) {
+	source := `{
+  "item.mymod.wrench": "Wrench", // the tool
+  "item.mymod.gear": "Gear", // small cog
+  "item.mymod.drill": "Drill", // mechanical
+}
+`
+
+	tests := []struct {
+		name   string
+		values map[string]string
+		want   string
+	}{
+		{
+			name:   "drop_first",
+			values: map[string]string{"item.mymod.gear": "歯車", "item.mymod.drill": "ドリル"},
+			want: `{
+  "item.mymod.gear": "歯車", // small cog
+  "item.mymod.drill": "ドリル", // mechanical
+}
+`,
+		},
+		{
+			name:   "drop_last",
+			values: map[string]string{"item.mymod.wrench": "レンチ"},
+			want: `{
+  "item.mymod.wrench": "レンチ", // the tool
+}
+`,
+		},
+		{
+			name:   "nothing_translated",
+			values: map[string]string{},
+			want:   "{\n}\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := MirrorJSONLang([]byte(source), tt.values)
+			if err != nil {
+				t.Fatalf("MirrorJSONLang() error = %v", err)
+			}
+			if string(result) != tt.want {
+				t.Errorf("MirrorJSONLang() =\n%s\nwant\n%s", result, tt.want)
+			}
+		})
+	}
+}
+
+func TestAppendOrder(t *testing.T) {
+	values := map[string]string{"c": "C", "a": "A", "d": "D", "b": "B"}
+	got := AppendOrder([]string{"d", "x", "b", "d"}, values)
+	want := []string{"d", "b", "a", "c"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("AppendOrder() = %v, want %v", got, want)
+	}
+}
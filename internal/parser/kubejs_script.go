@@ -0,0 +1,222 @@
+package parser
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/iuif/minecraft-mod-dictionary/pkg/interfaces"
+)
+
+// KubeJSScriptParser finds hard-coded display strings in KubeJS scripts
+// (kubejs/startup_scripts, kubejs/server_scripts, kubejs/client_scripts).
+//
+// It does not fully parse JavaScript. It reports the first string literal argument of
+// calls that produce player-visible text, such as Text.of("..."), Component.literal("...")
+// and .displayName("..."). Each entry is keyed by "{line}:{column}" of the literal and
+// carries the call name as context, so results are meant to be reported as candidates
+// for moving into lang files rather than stored as stable translation keys.
+type KubeJSScriptParser struct{}
+
+// Compile-time check that KubeJSScriptParser implements interfaces.Parser.
+var _ interfaces.Parser = (*KubeJSScriptParser)(nil)
+
+// NewKubeJSScriptParser creates a new KubeJS script scanner.
+func NewKubeJSScriptParser() *KubeJSScriptParser {
+	return &KubeJSScriptParser{}
+}
+
+// kubeJSCallRegex matches text-producing calls followed by a string literal argument.
+// Text.translate / Component.translatable are intentionally excluded: their argument is already a lang key.
+var kubeJSCallRegex = regexp.MustCompile(
+	`(Text\.(?:of|literal|string|black|dark_blue|dark_green|dark_aqua|dark_red|dark_purple|gold|gray|dark_gray|blue|green|aqua|red|light_purple|yellow|white)` +
+		`|Component\.(?:of|literal|string)` +
+		`|\.(?:displayName|formattedDisplayName|tooltip))` +
+		`\(\s*("(?:[^"\\\n]|\\.)*"|'(?:[^'\\\n]|\\.)*'|` + "`(?:[^`\\\\$]|\\\\.)*`" + `)`)
+
+// kubeJSLiteral is a matched string literal in a script.
+type kubeJSLiteral struct {
+	call  string
+	text  string
+	start int // offset of the opening quote
+	end   int // offset just past the closing quote
+	line  int
+	col   int
+}
+
+// Parse extracts hard-coded string literals from KubeJS script content.
+func (p *KubeJSScriptParser) Parse(content []byte) ([]interfaces.ParsedEntry, error) {
+	literals := p.literals(content)
+
+	entries := make([]interfaces.ParsedEntry, 0, len(literals))
+	for _, lit := range literals {
+		call := strings.TrimPrefix(lit.call, ".")
+		entries = append(entries, interfaces.ParsedEntry{
+			Key:        fmt.Sprintf("%d:%d", lit.line, lit.col),
+			Text:       lit.text,
+			Context:    &call,
+			Tags:       []string{"kubejs", "script"},
+			LineNumber: lit.line,
+		})
+	}
+
+	return entries, nil
+}
+
+// Apply replaces the matched literals with translations, keeping the original quote style.
+func (p *KubeJSScriptParser) Apply(content []byte, translations map[string]string) ([]byte, error) {
+	var result bytes.Buffer
+	last := 0
+
+	for _, lit := range p.literals(content) {
+		translation, exists := translations[fmt.Sprintf("%d:%d", lit.line, lit.col)]
+		if !exists {
+			continue
+		}
+		result.Write(content[last:lit.start])
+		result.WriteString(quoteJSString(translation, content[lit.start]))
+		last = lit.end
+	}
+	result.Write(content[last:])
+
+	return result.Bytes(), nil
+}
+
+// SupportedTypes returns the pattern types this parser handles.
+func (p *KubeJSScriptParser) SupportedTypes() []string {
+	return []string{"kubejs_script"}
+}
+
+// literals returns the translatable literals in content, in file order.
+func (p *KubeJSScriptParser) literals(content []byte) []kubeJSLiteral {
+	// Match against a copy with comments blanked out so offsets stay valid
+	code := blankJSComments(content)
+
+	var literals []kubeJSLiteral
+	for _, m := range kubeJSCallRegex.FindAllSubmatchIndex(code, -1) {
+		start, end := m[4], m[5]
+		text, ok := unquoteJSString(string(content[start:end]))
+		if !ok || strings.TrimSpace(text) == "" {
+			continue
+		}
+
+		lineStart := bytes.LastIndexByte(content[:start], '\n') + 1
+		literals = append(literals, kubeJSLiteral{
+			call:  string(content[m[2]:m[3]]),
+			text:  text,
+			start: start,
+			end:   end,
+			line:  bytes.Count(content[:start], []byte("\n")) + 1,
+			col:   len([]rune(string(content[lineStart:start]))) + 1,
+		})
+	}
+
+	return literals
+}
+
+// blankJSComments returns a copy of content with // and /* */ comments replaced by spaces.
+// Newlines are kept so that offsets and line numbers are unchanged.
+func blankJSComments(content []byte) []byte {
+	code := make([]byte, len(content))
+	copy(code, content)
+
+	var quote byte
+	for i := 0; i < len(code); i++ {
+		c := code[i]
+
+		if quote != 0 {
+			if c == '\\' {
+				i++
+			} else if c == quote || c == '\n' && quote != '`' {
+				quote = 0
+			}
+			continue
+		}
+
+		switch {
+		case c == '"' || c == '\'' || c == '`':
+			quote = c
+		case c == '/' && i+1 < len(code) && code[i+1] == '/':
+			for i < len(code) && code[i] != '\n' {
+				code[i] = ' '
+				i++
+			}
+		case c == '/' && i+1 < len(code) && code[i+1] == '*':
+			for i < len(code) && !(code[i] == '*' && i+1 < len(code) && code[i+1] == '/') {
+				if code[i] != '\n' {
+					code[i] = ' '
+				}
+				i++
+			}
+			if i+1 < len(code) {
+				code[i], code[i+1] = ' ', ' '
+				i++
+			}
+		}
+	}
+
+	return code
+}
+
+// unquoteJSString decodes a single-, double- or backtick-quoted JavaScript string literal.
+func unquoteJSString(literal string) (string, bool) {
+	if len(literal) < 2 {
+		return "", false
+	}
+	body := literal[1 : len(literal)-1]
+
+	var sb strings.Builder
+	for i := 0; i < len(body); i++ {
+		c := body[i]
+		if c != '\\' || i+1 >= len(body) {
+			sb.WriteByte(c)
+			continue
+		}
+
+		i++
+		switch body[i] {
+		case 'n':
+			sb.WriteByte('\n')
+		case 't':
+			sb.WriteByte('\t')
+		case 'u':
+			if i+4 < len(body) {
+				if r, err := strconv.ParseUint(body[i+1:i+5], 16, 32); err == nil {
+					sb.WriteRune(rune(r))
+					i += 4
+					continue
+				}
+			}
+			return "", false
+		default:
+			sb.WriteByte(body[i])
+		}
+	}
+
+	return sb.String(), true
+}
+
+// quoteJSString encodes s as a JavaScript string literal using the given quote character.
+func quoteJSString(s string, quote byte) string {
+	var sb strings.Builder
+	sb.WriteByte(quote)
+	for _, r := range s {
+		switch {
+		case r == '\\':
+			sb.WriteString(`\\`)
+		case r == rune(quote):
+			sb.WriteByte('\\')
+			sb.WriteRune(r)
+		case r == '\n':
+			sb.WriteString(`\n`)
+		case r == '$' && quote == '`':
+			sb.WriteString(`\$`)
+		default:
+			sb.WriteRune(r)
+		}
+	}
+	sb.WriteByte(quote)
+	return sb.String()
+}
@@ -0,0 +1,101 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+)
+
+const kubeJSScriptFixture = `// priority: 0
+// Text.of("commented out") is not a candidate
+
+StartupEvents.registry('item', event => {
+	event.create('copper_gear').displayName('Copper Gear')
+	event.create('tin_gear')
+		.displayName("Tin \"Gear\"")
+		.tooltip(Text.translate('item.pack.tin_gear.tooltip'))
+})
+
+/* block comment: Text.of('also ignored') */
+ItemEvents.tooltip(event => {
+	event.add('minecraft:stick', Text.of('A humble stick').gray())
+	event.add('minecraft:stone', Text.gold(` + "`Shiny`" + `))
+	event.add('minecraft:dirt', Text.of(''))
+	player.tell(Component.literal("Welcome!"))
+})
+`
+
+func TestKubeJSScriptParser_Parse(t *testing.T) {
+	parser := NewKubeJSScriptParser()
+
+	entries, err := parser.Parse([]byte(kubeJSScriptFixture))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	tests := []struct {
+		text string
+		call string
+		line int
+	}{
+		{text: "Copper Gear", call: "displayName", line: 5},
+		{text: `Tin "Gear"`, call: "displayName", line: 7},
+		{text: "A humble stick", call: "Text.of", line: 13},
+		{text: "Shiny", call: "Text.gold", line: 14},
+		{text: "Welcome!", call: "Component.literal", line: 16},
+	}
+
+	if len(entries) != len(tests) {
+		t.Fatalf("Parse() got %d entries, want %d: %+v", len(entries), len(tests), entries)
+	}
+
+	for i, tt := range tests {
+		entry := entries[i]
+		if entry.Text != tt.text {
+			t.Errorf("entries[%d].Text = %q, want %q", i, entry.Text, tt.text)
+		}
+		if entry.Context == nil || *entry.Context != tt.call {
+			t.Errorf("entries[%d].Context = %v, want %q", i, entry.Context, tt.call)
+		}
+		if entry.LineNumber != tt.line {
+			t.Errorf("entries[%d].LineNumber = %d, want %d", i, entry.LineNumber, tt.line)
+		}
+	}
+
+	if entries[0].Key != "5:42" {
+		t.Errorf("entries[0].Key = %q, want %q", entries[0].Key, "5:42")
+	}
+}
+
+func TestKubeJSScriptParser_Apply(t *testing.T) {
+	parser := NewKubeJSScriptParser()
+
+	translations := map[string]string{
+		"5:42": "銅の歯車",
+		"7:16": `錫の"歯車"`,
+	}
+
+	result, err := parser.Apply([]byte(kubeJSScriptFixture), translations)
+	if err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+
+	for _, want := range []string{`.displayName('銅の歯車')`, `.displayName("錫の\"歯車\"")`, `Text.of('A humble stick')`} {
+		if !strings.Contains(string(result), want) {
+			t.Errorf("Apply() result missing %s:\n%s", want, result)
+		}
+	}
+
+	// Untranslated content is unchanged
+	if got, want := strings.Count(string(result), "\n"), strings.Count(kubeJSScriptFixture, "\n"); got != want {
+		t.Errorf("Apply() changed line count: got %d, want %d", got, want)
+	}
+}
+
+func TestKubeJSScriptParser_SupportedTypes(t *testing.T) {
+	parser := NewKubeJSScriptParser()
+
+	types := parser.SupportedTypes()
+	if len(types) != 1 || types[0] != "kubejs_script" {
+		t.Errorf("SupportedTypes() = %v, want [kubejs_script]", types)
+	}
+}
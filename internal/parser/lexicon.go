@@ -0,0 +1,193 @@
+package parser
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/iuif/minecraft-mod-dictionary/pkg/interfaces"
+)
+
+// LexiconParser parses Lexicon-style custom JSON manuals: books whose files hold display
+// fields and nested lists of chapters, entries and pages, without the fixed layout of
+// Patchouli, Mantle or Modonomicon. For example:
+//
+//	{
+//	  "title": "Mana",
+//	  "pages": ["Mana is...", {"type": "image", "caption": "A mana pool"}],
+//	  "sections": [{"name": "Spreaders", "pages": ["..."]}]
+//	}
+//
+// Keys are JSON paths like "title", "pages[0]", "pages[1].caption" or "sections[0].name".
+type LexiconParser struct{}
+
+// Compile-time check that LexiconParser implements interfaces.Parser.
+var _ interfaces.Parser = (*LexiconParser)(nil)
+
+// NewLexiconParser creates a new Lexicon-style manual parser.
+func NewLexiconParser() *LexiconParser {
+	return &LexiconParser{}
+}
+
+// lexiconTextFields are the translatable fields of a manual, chapter, entry or page.
+var lexiconTextFields = map[string]bool{
+	"title":       true,
+	"subtitle":    true,
+	"name":        true,
+	"description": true,
+	"header":      true,
+	"text":        true,
+	"caption":     true,
+	"tooltip":     true,
+}
+
+// lexiconContainerFields hold nested chapters, entries or pages, either as a list or as
+// an object keyed by ID. String items of these lists are page texts.
+var lexiconContainerFields = map[string]bool{
+	"pages":      true,
+	"chapters":   true,
+	"sections":   true,
+	"entries":    true,
+	"categories": true,
+}
+
+// Parse extracts translation entries from Lexicon-style manual content.
+func (p *LexiconParser) Parse(content []byte) ([]interfaces.ParsedEntry, error) {
+	data, err := decodeLexicon(content)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := []interfaces.ParsedEntry{}
+	p.walk("", data, func(key, text string) {
+		entries = append(entries, interfaces.ParsedEntry{
+			Key:  key,
+			Text: text,
+			Tags: []string{"lexicon"},
+		})
+	})
+
+	return entries, nil
+}
+
+// Apply applies translations to the original manual content.
+func (p *LexiconParser) Apply(content []byte, translations map[string]string) ([]byte, error) {
+	data, err := decodeLexicon(content)
+	if err != nil {
+		return nil, err
+	}
+
+	p.apply("", data, translations)
+
+	result, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal JSON: %w", err)
+	}
+
+	return result, nil
+}
+
+// SupportedTypes returns the pattern types this parser handles.
+func (p *LexiconParser) SupportedTypes() []string {
+	return []string{"manual"}
+}
+
+// walk calls fn for each translatable text of obj, in key order.
+func (p *LexiconParser) walk(path string, obj map[string]interface{}, fn func(key, text string)) {
+	for _, field := range sortedKeys(obj) {
+		fieldPath := joinComponentKey(path, field)
+		switch value := obj[field].(type) {
+		case string:
+			if lexiconTextFields[field] && value != "" && !isIdentifierValue(value) {
+				fn(fieldPath, value)
+			}
+		case []interface{}:
+			if !lexiconContainerFields[field] {
+				continue
+			}
+			for i, item := range value {
+				itemPath := fmt.Sprintf("%s[%d]", fieldPath, i)
+				switch item := item.(type) {
+				case string:
+					if item != "" {
+						fn(itemPath, item)
+					}
+				case map[string]interface{}:
+					p.walk(itemPath, item, fn)
+				}
+			}
+		case map[string]interface{}:
+			if !lexiconContainerFields[field] {
+				continue
+			}
+			for _, id := range sortedKeys(value) {
+				if child, ok := value[id].(map[string]interface{}); ok {
+					p.walk(joinComponentKey(fieldPath, id), child, fn)
+				}
+			}
+		}
+	}
+}
+
+// apply mirrors walk, replacing texts from translations.
+func (p *LexiconParser) apply(path string, obj map[string]interface{}, translations map[string]string) {
+	for field, v := range obj {
+		fieldPath := joinComponentKey(path, field)
+		switch value := v.(type) {
+		case string:
+			if !lexiconTextFields[field] || isIdentifierValue(value) {
+				continue
+			}
+			if translation, ok := translations[fieldPath]; ok {
+				obj[field] = translation
+			}
+		case []interface{}:
+			if !lexiconContainerFields[field] {
+				continue
+			}
+			for i, item := range value {
+				itemPath := fmt.Sprintf("%s[%d]", fieldPath, i)
+				switch item := item.(type) {
+				case string:
+					if translation, ok := translations[itemPath]; ok {
+						value[i] = translation
+					}
+				case map[string]interface{}:
+					p.apply(itemPath, item, translations)
+				}
+			}
+		case map[string]interface{}:
+			if !lexiconContainerFields[field] {
+				continue
+			}
+			for id, child := range value {
+				if child, ok := child.(map[string]interface{}); ok {
+					p.apply(joinComponentKey(fieldPath, id), child, translations)
+				}
+			}
+		}
+	}
+}
+
+// decodeLexicon decodes a manual file, which must be a JSON object.
+func decodeLexicon(content []byte) (map[string]interface{}, error) {
+	data, err := decodeJSONGeneric(content)
+	if err != nil {
+		return nil, err
+	}
+	obj, ok := data.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("failed to parse manual: expected a JSON object")
+	}
+	return obj, nil
+}
+
+// sortedKeys returns the keys of obj in sorted order.
+func sortedKeys(obj map[string]interface{}) []string {
+	keys := make([]string, 0, len(obj))
+	for key := range obj {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
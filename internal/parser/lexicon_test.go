@@ -0,0 +1,207 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLexiconParser_Parse(t *testing.T) {
+	parser := NewLexiconParser()
+
+	tests := []struct {
+		name      string
+		content   string
+		wantCount int
+		wantTexts map[string]string
+	}{
+		{
+			name: "string_pages",
+			content: `{
+				"title": "Mana",
+				"icon": "botania:mana_pool",
+				"pages": ["Mana is the magical energy.", "It is stored in pools."]
+			}`,
+			wantCount: 3,
+			wantTexts: map[string]string{
+				"title":    "Mana",
+				"pages[0]": "Mana is the magical energy.",
+				"pages[1]": "It is stored in pools.",
+			},
+		},
+		{
+			name: "object_pages",
+			content: `{
+				"name": "Spreaders",
+				"pages": [
+					{"type": "text", "header": "Mana Spreaders", "text": "Spreaders fire mana bursts."},
+					{"type": "image", "image": "botania:textures/gui/spreader.png", "caption": "A spreader"},
+					{"type": "recipe", "recipe": "botania:mana_spreader"}
+				]
+			}`,
+			wantCount: 4,
+			wantTexts: map[string]string{
+				"name":             "Spreaders",
+				"pages[0].header":  "Mana Spreaders",
+				"pages[0].text":    "Spreaders fire mana bursts.",
+				"pages[1].caption": "A spreader",
+			},
+		},
+		{
+			name: "nested_chapters",
+			content: `{
+				"title": "Lexicon",
+				"chapters": [
+					{
+						"title": "Basics",
+						"sections": [{"name": "Flowers", "pages": ["Mystical flowers grow in the world."]}]
+					}
+				],
+				"entries": {
+					"intro": {"title": "Introduction", "pages": ["Welcome."]}
+				}
+			}`,
+			wantCount: 6,
+			wantTexts: map[string]string{
+				"title":                            "Lexicon",
+				"chapters[0].title":                "Basics",
+				"chapters[0].sections[0].name":     "Flowers",
+				"chapters[0].sections[0].pages[0]": "Mystical flowers grow in the world.",
+				"entries.intro.title":              "Introduction",
+				"entries.intro.pages[0]":           "Welcome.",
+			},
+		},
+		{
+			name: "identifiers_and_unknown_lists",
+			content: `{
+				"name": "botania:lexicon",
+				"description": "",
+				"items": ["botania:mana_pool"],
+				"pages": [""]
+			}`,
+			wantCount: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entries, err := parser.Parse([]byte(tt.content))
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+
+			if len(entries) != tt.wantCount {
+				t.Errorf("Parse() got %d entries, want %d: %+v", len(entries), tt.wantCount, entries)
+			}
+
+			keyMap := make(map[string]string)
+			for _, entry := range entries {
+				keyMap[entry.Key] = entry.Text
+			}
+			for key, wantText := range tt.wantTexts {
+				if gotText, exists := keyMap[key]; !exists {
+					t.Errorf("Parse() missing key %q", key)
+				} else if gotText != wantText {
+					t.Errorf("Parse() key %q = %q, want %q", key, gotText, wantText)
+				}
+			}
+		})
+	}
+}
+
+func TestLexiconParser_Parse_Invalid(t *testing.T) {
+	parser := NewLexiconParser()
+
+	for _, content := range []string{`{invalid json}`, `["not", "an", "object"]`} {
+		if _, err := parser.Parse([]byte(content)); err == nil {
+			t.Errorf("Parse(%s) expected error, got nil", content)
+		}
+	}
+}
+
+func TestLexiconParser_Apply(t *testing.T) {
+	parser := NewLexiconParser()
+
+	original := `{
+  "title": "Mana",
+  "sort": 1.50,
+  "pages": [
+    "Mana is the magical energy.",
+    {
+      "type": "image",
+      "image": "botania:textures/gui/pool.png",
+      "caption": "A mana pool"
+    }
+  ],
+  "entries": {
+    "intro": {
+      "title": "Introduction",
+      "pages": ["Welcome."]
+    }
+  }
+}`
+
+	translations := map[string]string{
+		"title":                  "マナ",
+		"pages[0]":               "マナは魔法のエネルギーです。",
+		"pages[1].caption":       "マナプール",
+		"entries.intro.title":    "はじめに",
+		"entries.intro.pages[0]": "ようこそ。",
+	}
+
+	result, err := parser.Apply([]byte(original), translations)
+	if err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+
+	entries, err := parser.Parse(result)
+	if err != nil {
+		t.Fatalf("Parse result error = %v", err)
+	}
+	if len(entries) != len(translations) {
+		t.Errorf("Parse result got %d entries, want %d", len(entries), len(translations))
+	}
+	for _, entry := range entries {
+		if want := translations[entry.Key]; entry.Text != want {
+			t.Errorf("Apply() key %q = %q, want %q", entry.Key, entry.Text, want)
+		}
+	}
+
+	// Non-text fields are kept unchanged
+	for _, want := range []string{`"sort": 1.50`, `"image": "botania:textures/gui/pool.png"`, `"type": "image"`} {
+		if !strings.Contains(string(result), want) {
+			t.Errorf("Apply() result missing %s:\n%s", want, result)
+		}
+	}
+}
+
+func TestLexiconParser_Apply_IgnoresAbsentFields(t *testing.T) {
+	parser := NewLexiconParser()
+
+	original := `{"title": "Mana", "pages": [{"type": "text", "text": "Body"}]}`
+
+	translations := map[string]string{
+		"description":     "説明",
+		"pages[0].header": "見出し",
+		"pages[1]":        "追加ページ",
+	}
+
+	result, err := parser.Apply([]byte(original), translations)
+	if err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+
+	for _, unwanted := range []string{"description", "header", "追加ページ"} {
+		if strings.Contains(string(result), unwanted) {
+			t.Errorf("Apply() added %q for a field absent from the original:\n%s", unwanted, result)
+		}
+	}
+}
+
+func TestLexiconParser_SupportedTypes(t *testing.T) {
+	parser := NewLexiconParser()
+
+	types := parser.SupportedTypes()
+	if len(types) != 1 || types[0] != "manual" {
+		t.Errorf("SupportedTypes() = %v, want [manual]", types)
+	}
+}
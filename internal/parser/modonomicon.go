@@ -0,0 +1,127 @@
+package parser
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/iuif/minecraft-mod-dictionary/pkg/interfaces"
+)
+
+// ModonomiconParser parses Modonomicon book JSON files (used by Ars Nouveau addons, Occultism).
+type ModonomiconParser struct{}
+
+// Compile-time check that ModonomiconParser implements interfaces.Parser.
+var _ interfaces.Parser = (*ModonomiconParser)(nil)
+
+// NewModonomiconParser creates a new Modonomicon book parser.
+func NewModonomiconParser() *ModonomiconParser {
+	return &ModonomiconParser{}
+}
+
+// modonomiconTopFields are the translatable fields of book, category and entry files.
+var modonomiconTopFields = []string{"name", "tooltip", "description"}
+
+// modonomiconPageFields are the translatable fields of a page.
+// Which ones are present depends on the page type:
+//   - modonomicon:text, modonomicon:spotlight, modonomicon:image: title, text
+//   - modonomicon:crafting_recipe, modonomicon:smelting_recipe, ...: title1, title2, text
+//   - modonomicon:multiblock: multiblock_name, text
+//   - modonomicon:entity: name, text
+var modonomiconPageFields = []string{"title", "title1", "title2", "multiblock_name", "name", "text"}
+
+// Parse extracts translation entries from Modonomicon JSON content.
+func (p *ModonomiconParser) Parse(content []byte) ([]interfaces.ParsedEntry, error) {
+	var data map[string]interface{}
+	if err := json.Unmarshal(content, &data); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON: %w", err)
+	}
+
+	entries := []interfaces.ParsedEntry{}
+
+	// Extract top-level translatable fields
+	for _, field := range modonomiconTopFields {
+		if text, ok := data[field].(string); ok && text != "" {
+			entries = append(entries, interfaces.ParsedEntry{
+				Key:  field,
+				Text: text,
+				Tags: []string{"modonomicon"},
+			})
+		}
+	}
+
+	// Extract translatable fields from pages
+	pages, _ := data["pages"].([]interface{})
+	for i, pageInterface := range pages {
+		page, ok := pageInterface.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		for _, field := range modonomiconPageFields {
+			if text, ok := page[field].(string); ok && text != "" {
+				entries = append(entries, interfaces.ParsedEntry{
+					Key:  fmt.Sprintf("pages[%d].%s", i, field),
+					Text: text,
+					Tags: []string{"modonomicon"},
+				})
+			}
+		}
+	}
+
+	return entries, nil
+}
+
+// Apply applies translations to the original Modonomicon JSON content.
+func (p *ModonomiconParser) Apply(content []byte, translations map[string]string) ([]byte, error) {
+	// Parse into generic map to preserve all fields
+	var data map[string]interface{}
+	if err := json.Unmarshal(content, &data); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON: %w", err)
+	}
+
+	// Apply top-level translations
+	for _, field := range modonomiconTopFields {
+		if _, ok := data[field].(string); !ok {
+			continue
+		}
+		if translation, exists := translations[field]; exists {
+			data[field] = translation
+		}
+	}
+
+	// Apply page translations
+	if pages, ok := data["pages"].([]interface{}); ok {
+		for i, pageInterface := range pages {
+			page, ok := pageInterface.(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			for _, field := range modonomiconPageFields {
+				if _, ok := page[field].(string); !ok {
+					continue
+				}
+				key := fmt.Sprintf("pages[%d].%s", i, field)
+				if translation, exists := translations[key]; exists {
+					page[field] = translation
+				}
+			}
+
+			pages[i] = page
+		}
+		data["pages"] = pages
+	}
+
+	// Marshal with indentation for readability
+	result, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal JSON: %w", err)
+	}
+
+	return result, nil
+}
+
+// SupportedTypes returns the pattern types this parser handles.
+func (p *ModonomiconParser) SupportedTypes() []string {
+	return []string{"modonomicon_book", "modonomicon_category", "modonomicon_entry"}
+}
@@ -0,0 +1,308 @@
+package parser
+
+import (
+	"testing"
+)
+
+func TestModonomiconParser_Parse_Category(t *testing.T) {
+	parser := NewModonomiconParser()
+
+	tests := []struct {
+		name      string
+		content   string
+		wantCount int
+		wantKeys  []string
+		wantTexts map[string]string
+	}{
+		{
+			name: "basic_category",
+			content: `{
+				"name": "Getting Started",
+				"icon": "occultism:dictionary_of_spirits",
+				"sort_number": 1
+			}`,
+			wantCount: 1,
+			wantKeys:  []string{"name"},
+			wantTexts: map[string]string{
+				"name": "Getting Started",
+			},
+		},
+		{
+			name: "book_with_tooltip",
+			content: `{
+				"name": "Dictionary of Spirits",
+				"tooltip": "A guide to summoning",
+				"model": "occultism:dictionary_of_spirits"
+			}`,
+			wantCount: 2,
+			wantKeys:  []string{"name", "tooltip"},
+			wantTexts: map[string]string{
+				"name":    "Dictionary of Spirits",
+				"tooltip": "A guide to summoning",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entries, err := parser.Parse([]byte(tt.content))
+			if err != nil {
+				t.Errorf("Parse() error = %v", err)
+				return
+			}
+
+			if len(entries) != tt.wantCount {
+				t.Errorf("Parse() got %d entries, want %d", len(entries), tt.wantCount)
+			}
+
+			keyMap := make(map[string]string)
+			for _, entry := range entries {
+				keyMap[entry.Key] = entry.Text
+			}
+
+			for _, wantKey := range tt.wantKeys {
+				if _, exists := keyMap[wantKey]; !exists {
+					t.Errorf("Parse() missing key %q", wantKey)
+				}
+			}
+
+			for key, wantText := range tt.wantTexts {
+				if gotText, exists := keyMap[key]; exists {
+					if gotText != wantText {
+						t.Errorf("Parse() key %q = %q, want %q", key, gotText, wantText)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestModonomiconParser_Parse_Entry(t *testing.T) {
+	parser := NewModonomiconParser()
+
+	tests := []struct {
+		name      string
+		content   string
+		wantCount int
+		wantKeys  []string
+		wantTexts map[string]string
+	}{
+		{
+			name: "text_pages",
+			content: `{
+				"category": "occultism:getting_started",
+				"name": "Demon's Dream",
+				"description": "Seeing beyond",
+				"icon": "occultism:datura",
+				"x": 2,
+				"y": 0,
+				"pages": [
+					{
+						"type": "modonomicon:text",
+						"title": "Introduction",
+						"text": "Eat the fruit to see the Otherworld."
+					}
+				]
+			}`,
+			wantCount: 4,
+			wantKeys:  []string{"name", "description", "pages[0].title", "pages[0].text"},
+			wantTexts: map[string]string{
+				"name":           "Demon's Dream",
+				"description":    "Seeing beyond",
+				"pages[0].title": "Introduction",
+				"pages[0].text":  "Eat the fruit to see the Otherworld.",
+			},
+		},
+		{
+			name: "page_type_specific_fields",
+			content: `{
+				"name": "Rituals",
+				"pages": [
+					{
+						"type": "modonomicon:crafting_recipe",
+						"title1": "Chalk",
+						"title2": "Golden Chalk",
+						"recipe_id_1": "occultism:crafting/chalk_white",
+						"recipe_id_2": "occultism:crafting/chalk_gold"
+					},
+					{
+						"type": "modonomicon:multiblock",
+						"multiblock_name": "Pentacle",
+						"multiblock_id": "occultism:summon_foliot",
+						"text": "Draw the pentacle with chalk."
+					},
+					{
+						"type": "modonomicon:entity",
+						"entity_id": "occultism:foliot",
+						"name": "Foliot",
+						"text": "A lesser spirit."
+					}
+				]
+			}`,
+			wantCount: 7,
+			wantKeys: []string{
+				"name",
+				"pages[0].title1",
+				"pages[0].title2",
+				"pages[1].multiblock_name",
+				"pages[1].text",
+				"pages[2].name",
+				"pages[2].text",
+			},
+			wantTexts: map[string]string{
+				"pages[0].title1":          "Chalk",
+				"pages[0].title2":          "Golden Chalk",
+				"pages[1].multiblock_name": "Pentacle",
+				"pages[2].name":            "Foliot",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entries, err := parser.Parse([]byte(tt.content))
+			if err != nil {
+				t.Errorf("Parse() error = %v", err)
+				return
+			}
+
+			if len(entries) != tt.wantCount {
+				t.Errorf("Parse() got %d entries, want %d", len(entries), tt.wantCount)
+			}
+
+			keyMap := make(map[string]string)
+			for _, entry := range entries {
+				keyMap[entry.Key] = entry.Text
+			}
+
+			for _, wantKey := range tt.wantKeys {
+				if _, exists := keyMap[wantKey]; !exists {
+					t.Errorf("Parse() missing key %q", wantKey)
+				}
+			}
+
+			for key, wantText := range tt.wantTexts {
+				if gotText, exists := keyMap[key]; exists {
+					if gotText != wantText {
+						t.Errorf("Parse() key %q = %q, want %q", key, gotText, wantText)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestModonomiconParser_Parse_Invalid(t *testing.T) {
+	parser := NewModonomiconParser()
+
+	if _, err := parser.Parse([]byte(`{invalid}`)); err == nil {
+		t.Error("Parse() expected error for invalid JSON")
+	}
+
+	entries, err := parser.Parse([]byte(`{}`))
+	if err != nil {
+		t.Errorf("Parse() unexpected error for empty JSON: %v", err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("Parse() expected 0 entries for empty JSON, got %d", len(entries))
+	}
+}
+
+func TestModonomiconParser_Apply_Entry(t *testing.T) {
+	parser := NewModonomiconParser()
+
+	original := `{
+  "category": "occultism:summoning_rituals",
+  "name": "Summon Foliot",
+  "icon": "occultism:foliot",
+  "pages": [
+    {
+      "type": "modonomicon:text",
+      "title": "Foliot",
+      "text": "The foliot is a lesser spirit."
+    },
+    {
+      "type": "modonomicon:multiblock",
+      "multiblock_name": "Pentacle",
+      "multiblock_id": "occultism:summon_foliot"
+    }
+  ]
+}`
+
+	translations := map[string]string{
+		"name":                     "フォリオットの召喚",
+		"pages[0].title":           "フォリオット",
+		"pages[0].text":            "フォリオットは下級の精霊です。",
+		"pages[1].multiblock_name": "五芒星",
+	}
+
+	result, err := parser.Apply([]byte(original), translations)
+	if err != nil {
+		t.Errorf("Apply() error = %v", err)
+		return
+	}
+
+	// Parse result to verify
+	entries, err := parser.Parse(result)
+	if err != nil {
+		t.Errorf("Parse result error = %v", err)
+		return
+	}
+
+	entryMap := make(map[string]string)
+	for _, entry := range entries {
+		entryMap[entry.Key] = entry.Text
+	}
+
+	for key, expected := range translations {
+		if got, exists := entryMap[key]; !exists {
+			t.Errorf("Apply() missing key %q", key)
+		} else if got != expected {
+			t.Errorf("Apply() key %q = %q, want %q", key, got, expected)
+		}
+	}
+}
+
+func TestModonomiconParser_Apply_IgnoresAbsentFields(t *testing.T) {
+	parser := NewModonomiconParser()
+
+	original := `{"name": "Rituals", "pages": [{"type": "modonomicon:text", "text": "Body"}]}`
+
+	translations := map[string]string{
+		"description":    "説明",
+		"pages[0].title": "タイトル",
+	}
+
+	result, err := parser.Apply([]byte(original), translations)
+	if err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+
+	entries, err := parser.Parse(result)
+	if err != nil {
+		t.Fatalf("Parse result error = %v", err)
+	}
+
+	if len(entries) != 2 {
+		t.Errorf("Apply() added fields not present in original, got %d entries", len(entries))
+	}
+}
+
+func TestModonomiconParser_SupportedTypes(t *testing.T) {
+	parser := NewModonomiconParser()
+
+	types := parser.SupportedTypes()
+	expectedTypes := []string{"modonomicon_book", "modonomicon_category", "modonomicon_entry"}
+	for _, expected := range expectedTypes {
+		found := false
+		for _, typ := range types {
+			if typ == expected {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Errorf("SupportedTypes() does not include %q", expected)
+		}
+	}
+}
@@ -56,7 +56,13 @@ func (r *Registry) List() []string {
 func NewDefaultRegistry() *Registry {
 	reg := NewRegistry()
 	reg.Register("json_lang", NewJSONLangParser())
+	reg.Register("json_generic", NewJSONGenericParser())
 	reg.Register("patchouli", NewPatchouliParser())
 	reg.Register("mantle_book", NewMantleBookParser())
+	reg.Register("modonomicon", NewModonomiconParser())
+	reg.Register("lexicon", NewLexiconParser())
+	reg.Register("betterquesting", NewBetterQuestingParser())
+	reg.Register("snbt", NewSNBTParser())
+	reg.Register("kubejs_script", NewKubeJSScriptParser())
 	return reg
 }
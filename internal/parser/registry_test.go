@@ -103,6 +103,11 @@ func TestNewDefaultRegistry(t *testing.T) {
 		t.Error("json_lang parser not registered")
 	}
 
+	// Check json_generic is registered
+	if _, ok := reg.Get("json_generic"); !ok {
+		t.Error("json_generic parser not registered")
+	}
+
 	// Check patchouli is registered
 	if _, ok := reg.Get("patchouli"); !ok {
 		t.Error("patchouli parser not registered")
@@ -112,4 +117,29 @@ func TestNewDefaultRegistry(t *testing.T) {
 	if _, ok := reg.Get("mantle_book"); !ok {
 		t.Error("mantle_book parser not registered")
 	}
+
+	// Check modonomicon is registered
+	if _, ok := reg.Get("modonomicon"); !ok {
+		t.Error("modonomicon parser not registered")
+	}
+
+	// Check lexicon is registered
+	if _, ok := reg.Get("lexicon"); !ok {
+		t.Error("lexicon parser not registered")
+	}
+
+	// Check betterquesting is registered
+	if _, ok := reg.Get("betterquesting"); !ok {
+		t.Error("betterquesting parser not registered")
+	}
+
+	// Check snbt is registered
+	if _, ok := reg.Get("snbt"); !ok {
+		t.Error("snbt parser not registered")
+	}
+
+	// Check kubejs_script is registered
+	if _, ok := reg.Get("kubejs_script"); !ok {
+		t.Error("kubejs_script parser not registered")
+	}
 }
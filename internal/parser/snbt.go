@@ -0,0 +1,544 @@
+package parser
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/iuif/minecraft-mod-dictionary/pkg/interfaces"
+)
+
+// SNBTParser parses FTB Quests SNBT files (config/ftbquests/quests/**/*.snbt).
+//
+// Entries are keyed by object ID using the FTB Quests lang-key convention, so that
+// the keys can be used directly as {lang.key} references in the rewritten SNBT:
+//
+//	ftbquests.chapter.{id}.title, ftbquests.quest.{id}.subtitle,
+//	ftbquests.quest.{id}.description.{line}, ftbquests.task.{id}.title, ...
+//
+// Strings holding JSON text components, and the JSON of tellraw/title command rewards,
+// are split into their readable parts (e.g. ftbquests.quest.{id}.title.extra[0].text).
+//
+// Apply rewrites only the string literals of translated fields and leaves the rest
+// of the file (ordering, indentation, number suffixes) untouched.
+type SNBTParser struct{}
+
+// Compile-time check that SNBTParser implements interfaces.Parser.
+var _ interfaces.Parser = (*SNBTParser)(nil)
+
+// NewSNBTParser creates a new FTB Quests SNBT parser.
+func NewSNBTParser() *SNBTParser {
+	return &SNBTParser{}
+}
+
+// snbtChildKinds maps list keys to the kind of the compounds they contain.
+var snbtChildKinds = map[string]string{
+	"quests":         "quest",
+	"tasks":          "task",
+	"rewards":        "reward",
+	"chapter_groups": "chapter_group",
+}
+
+// snbtField is a translatable string literal found in an SNBT document.
+type snbtField struct {
+	key  string
+	kind string // quest, chapter, task, ...
+	node *snbtNode
+
+	// Set when the literal holds a JSON text component (or a tellraw command):
+	// head is the command prefix kept as-is, parts are the readable fields below key.
+	head      string
+	component interface{}
+	parts     []textComponentField
+}
+
+// Parse extracts translation entries from SNBT content.
+func (p *SNBTParser) Parse(content []byte) ([]interfaces.ParsedEntry, error) {
+	fields, err := p.fields(content)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]interfaces.ParsedEntry, 0, len(fields))
+	for _, f := range fields {
+		line := bytes.Count(content[:f.node.start], []byte("\n")) + 1
+		if f.component == nil {
+			entries = append(entries, interfaces.ParsedEntry{
+				Key:        f.key,
+				Text:       f.node.text,
+				Tags:       []string{"ftbquests", f.kind},
+				LineNumber: line,
+			})
+			continue
+		}
+		for _, part := range f.parts {
+			entries = append(entries, interfaces.ParsedEntry{
+				Key:        part.key,
+				Text:       part.text,
+				Tags:       []string{"ftbquests", f.kind, "text_component"},
+				LineNumber: line,
+			})
+		}
+	}
+
+	return entries, nil
+}
+
+// Apply applies translations to the original SNBT content.
+func (p *SNBTParser) Apply(content []byte, translations map[string]string) ([]byte, error) {
+	fields, err := p.fields(content)
+	if err != nil {
+		return nil, err
+	}
+
+	return rewriteSNBT(content, fields, func(f snbtField) (string, bool, error) {
+		if f.component == nil {
+			translation, exists := translations[f.key]
+			return translation, exists, nil
+		}
+		if !hasAnyPart(f.parts, translations) {
+			return "", false, nil
+		}
+		encoded, err := encodeTextComponent(applyTextComponent(f.key, f.component, translations))
+		if err != nil {
+			return "", false, err
+		}
+		return f.head + encoded, true, nil
+	})
+}
+
+// ApplyLangKeys rewrites every translatable string to reference its lang key and
+// returns the entries the rewritten content references, for the lang files.
+// Plain strings become {lang.key} references; readable parts of text components
+// become {"translate": key, "fallback": text} components. Fallbacks of existing
+// translate components keep referencing their own key and are not returned.
+func (p *SNBTParser) ApplyLangKeys(content []byte) ([]byte, []interfaces.ParsedEntry, error) {
+	fields, err := p.fields(content)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var entries []interfaces.ParsedEntry
+	result, err := rewriteSNBT(content, fields, func(f snbtField) (string, bool, error) {
+		line := bytes.Count(content[:f.node.start], []byte("\n")) + 1
+		if f.component == nil {
+			entries = append(entries, interfaces.ParsedEntry{
+				Key:        f.key,
+				Text:       f.node.text,
+				Tags:       []string{"ftbquests", f.kind},
+				LineNumber: line,
+			})
+			return "{" + f.key + "}", true, nil
+		}
+
+		var parts []textComponentField
+		component := localizeTextComponent(f.key, f.component, &parts)
+		if len(parts) == 0 {
+			return "", false, nil
+		}
+		for _, part := range parts {
+			entries = append(entries, interfaces.ParsedEntry{
+				Key:        part.key,
+				Text:       part.text,
+				Tags:       []string{"ftbquests", f.kind, "text_component"},
+				LineNumber: line,
+			})
+		}
+		encoded, err := encodeTextComponent(component)
+		if err != nil {
+			return "", false, err
+		}
+		return f.head + encoded, true, nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return result, entries, nil
+}
+
+// rewriteSNBT replaces the string literals of fields with the values returned by replace.
+// Fields for which replace returns false are left untouched.
+func rewriteSNBT(content []byte, fields []snbtField, replace func(snbtField) (string, bool, error)) ([]byte, error) {
+	var result bytes.Buffer
+	last := 0
+	for _, f := range fields {
+		replacement, ok, err := replace(f)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+		result.Write(content[last:f.node.start])
+		result.WriteString(quoteSNBTString(replacement))
+		last = f.node.end
+	}
+	result.Write(content[last:])
+
+	return result.Bytes(), nil
+}
+
+// SupportedTypes returns the pattern types this parser handles.
+func (p *SNBTParser) SupportedTypes() []string {
+	return []string{"quest"}
+}
+
+// fields parses content and returns its translatable fields in file order.
+func (p *SNBTParser) fields(content []byte) ([]snbtField, error) {
+	root, err := parseSNBT(content)
+	if err != nil {
+		return nil, err
+	}
+	if root.kind != snbtCompound {
+		return nil, fmt.Errorf("failed to parse SNBT: root is not a compound")
+	}
+
+	kind := "file"
+	if root.get("quests") != nil {
+		kind = "chapter"
+	} else if root.get("rewards") != nil {
+		kind = "reward_table"
+	}
+
+	var fields []snbtField
+	p.walk(root, kind, "ftbquests."+kind, &fields)
+
+	sort.SliceStable(fields, func(i, j int) bool {
+		return fields[i].node.start < fields[j].node.start
+	})
+	return fields, nil
+}
+
+// walk collects translatable fields of a compound and recurses into known child lists.
+// Compounds without an id are keyed by their parent's prefix and list index.
+func (p *SNBTParser) walk(c *snbtNode, kind, fallbackPrefix string, fields *[]snbtField) {
+	prefix := fallbackPrefix
+	if id := c.get("id"); id != nil && id.text != "" {
+		prefix = fmt.Sprintf("ftbquests.%s.%s", kind, id.text)
+	}
+
+	for i, key := range c.keys {
+		value := c.values[i]
+
+		switch key {
+		case "title", "subtitle":
+			if isTranslatableSNBT(value) {
+				*fields = append(*fields, newSNBTField(prefix+"."+key, kind, value))
+			}
+		case "description":
+			if value.kind == snbtString && isTranslatableSNBT(value) {
+				*fields = append(*fields, newSNBTField(prefix+".description", kind, value))
+			}
+			if value.kind == snbtList {
+				for line, item := range value.items {
+					if isTranslatableSNBT(item) {
+						*fields = append(*fields, newSNBTField(fmt.Sprintf("%s.description.%d", prefix, line), kind, item))
+					}
+				}
+			}
+		case "command":
+			// Command rewards: only the JSON of tellraw/title commands is translatable
+			if value.kind != snbtString {
+				continue
+			}
+			if head, component, ok := splitTellrawCommand(value.text); ok {
+				key := prefix + ".command"
+				if parts := extractTextComponent(key, component); len(parts) > 0 {
+					*fields = append(*fields, snbtField{key: key, kind: kind, node: value, head: head, component: component, parts: parts})
+				}
+			}
+		default:
+			childKind, ok := snbtChildKinds[key]
+			if !ok || value.kind != snbtList {
+				continue
+			}
+			for index, item := range value.items {
+				if item.kind == snbtCompound {
+					p.walk(item, childKind, fmt.Sprintf("%s.%s.%d", prefix, childKind, index), fields)
+				}
+			}
+		}
+	}
+}
+
+// newSNBTField creates a field for a string literal, detecting JSON text components.
+func newSNBTField(key, kind string, node *snbtNode) snbtField {
+	f := snbtField{key: key, kind: kind, node: node}
+	if component, ok := decodeTextComponent(node.text); ok {
+		f.component = component
+		f.parts = extractTextComponent(key, component)
+	}
+	return f
+}
+
+// hasAnyPart reports whether translations contains any of the component parts.
+func hasAnyPart(parts []textComponentField, translations map[string]string) bool {
+	for _, part := range parts {
+		if _, ok := translations[part.key]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// isTranslatableSNBT reports whether a node is a non-empty string that is not already a lang-key reference.
+func isTranslatableSNBT(n *snbtNode) bool {
+	if n.kind != snbtString || strings.TrimSpace(n.text) == "" {
+		return false
+	}
+	return !isLangKeyReference(n.text)
+}
+
+// isLangKeyReference reports whether text is an FTB Quests lang-key reference like {ftbquests.quest.x.title}.
+func isLangKeyReference(text string) bool {
+	if len(text) < 3 || text[0] != '{' || text[len(text)-1] != '}' {
+		return false
+	}
+	return !strings.ContainsAny(text[1:len(text)-1], " \t\n\"{}:")
+}
+
+// quoteSNBTString encodes a string as a double-quoted SNBT literal.
+func quoteSNBTString(s string) string {
+	var sb strings.Builder
+	sb.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			sb.WriteString(`\"`)
+		case '\\':
+			sb.WriteString(`\\`)
+		case '\n':
+			sb.WriteString(`\n`)
+		case '\t':
+			sb.WriteString(`\t`)
+		default:
+			sb.WriteRune(r)
+		}
+	}
+	sb.WriteByte('"')
+	return sb.String()
+}
+
+// ==================== SNBT Reader ====================
+
+type snbtKind int
+
+const (
+	snbtCompound snbtKind = iota
+	snbtList
+	snbtString
+	snbtScalar
+)
+
+// snbtNode is a parsed SNBT value with its byte span in the source.
+type snbtNode struct {
+	kind   snbtKind
+	keys   []string    // compound keys, in file order
+	values []*snbtNode // compound values, parallel to keys
+	items  []*snbtNode // list items
+	text   string      // decoded string, or raw scalar token
+	start  int         // offset of the first byte of the value
+	end    int         // offset just past the last byte of the value
+}
+
+// get returns the value of a compound key, or nil.
+func (n *snbtNode) get(key string) *snbtNode {
+	for i, k := range n.keys {
+		if k == key {
+			return n.values[i]
+		}
+	}
+	return nil
+}
+
+// snbtReader is a minimal recursive-descent SNBT reader.
+// It accepts both comma- and newline-separated compounds and lists (FTB Quests style).
+type snbtReader struct {
+	src []byte
+	pos int
+}
+
+// parseSNBT parses a single SNBT value from content.
+func parseSNBT(content []byte) (*snbtNode, error) {
+	r := &snbtReader{src: content}
+	r.skipSpace()
+	node, err := r.value()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse SNBT: %w", err)
+	}
+	r.skipSpace()
+	if r.pos < len(r.src) {
+		return nil, fmt.Errorf("failed to parse SNBT: unexpected data at offset %d", r.pos)
+	}
+	return node, nil
+}
+
+// skipSpace skips whitespace and separating commas.
+func (r *snbtReader) skipSpace() {
+	for r.pos < len(r.src) {
+		switch r.src[r.pos] {
+		case ' ', '\t', '\n', '\r', ',':
+			r.pos++
+		default:
+			return
+		}
+	}
+}
+
+func (r *snbtReader) value() (*snbtNode, error) {
+	if r.pos >= len(r.src) {
+		return nil, fmt.Errorf("unexpected end of input")
+	}
+
+	switch r.src[r.pos] {
+	case '{':
+		return r.compound()
+	case '[':
+		return r.list()
+	case '"', '\'':
+		return r.quoted()
+	default:
+		return r.scalar()
+	}
+}
+
+func (r *snbtReader) compound() (*snbtNode, error) {
+	node := &snbtNode{kind: snbtCompound, start: r.pos}
+	r.pos++ // {
+
+	for {
+		r.skipSpace()
+		if r.pos >= len(r.src) {
+			return nil, fmt.Errorf("unterminated compound starting at offset %d", node.start)
+		}
+		if r.src[r.pos] == '}' {
+			r.pos++
+			node.end = r.pos
+			return node, nil
+		}
+
+		key, err := r.key()
+		if err != nil {
+			return nil, err
+		}
+
+		r.skipSpace()
+		if r.pos >= len(r.src) || r.src[r.pos] != ':' {
+			return nil, fmt.Errorf("expected ':' after key %q at offset %d", key, r.pos)
+		}
+		r.pos++
+		r.skipSpace()
+
+		value, err := r.value()
+		if err != nil {
+			return nil, err
+		}
+
+		node.keys = append(node.keys, key)
+		node.values = append(node.values, value)
+	}
+}
+
+func (r *snbtReader) list() (*snbtNode, error) {
+	node := &snbtNode{kind: snbtList, start: r.pos}
+	r.pos++ // [
+
+	// Typed arrays: [I; 1, 2, 3], [B; ...], [L; ...]
+	if r.pos+1 < len(r.src) && r.src[r.pos+1] == ';' && strings.IndexByte("BIL", r.src[r.pos]) >= 0 {
+		r.pos += 2
+	}
+
+	for {
+		r.skipSpace()
+		if r.pos >= len(r.src) {
+			return nil, fmt.Errorf("unterminated list starting at offset %d", node.start)
+		}
+		if r.src[r.pos] == ']' {
+			r.pos++
+			node.end = r.pos
+			return node, nil
+		}
+
+		item, err := r.value()
+		if err != nil {
+			return nil, err
+		}
+		node.items = append(node.items, item)
+	}
+}
+
+func (r *snbtReader) key() (string, error) {
+	if r.src[r.pos] == '"' || r.src[r.pos] == '\'' {
+		node, err := r.quoted()
+		if err != nil {
+			return "", err
+		}
+		return node.text, nil
+	}
+
+	start := r.pos
+	for r.pos < len(r.src) && !isSNBTDelimiter(r.src[r.pos]) && r.src[r.pos] != ':' {
+		r.pos++
+	}
+	if r.pos == start {
+		return "", fmt.Errorf("expected key at offset %d", start)
+	}
+	return string(r.src[start:r.pos]), nil
+}
+
+func (r *snbtReader) quoted() (*snbtNode, error) {
+	node := &snbtNode{kind: snbtString, start: r.pos}
+	quote := r.src[r.pos]
+	r.pos++
+
+	var sb strings.Builder
+	for r.pos < len(r.src) {
+		c := r.src[r.pos]
+		switch {
+		case c == '\\' && r.pos+1 < len(r.src):
+			r.pos++
+			switch r.src[r.pos] {
+			case 'n':
+				sb.WriteByte('\n')
+			case 't':
+				sb.WriteByte('\t')
+			default:
+				sb.WriteByte(r.src[r.pos])
+			}
+			r.pos++
+		case c == quote:
+			r.pos++
+			node.text = sb.String()
+			node.end = r.pos
+			return node, nil
+		default:
+			sb.WriteByte(c)
+			r.pos++
+		}
+	}
+
+	return nil, fmt.Errorf("unterminated string starting at offset %d", node.start)
+}
+
+func (r *snbtReader) scalar() (*snbtNode, error) {
+	node := &snbtNode{kind: snbtScalar, start: r.pos}
+	for r.pos < len(r.src) && !isSNBTDelimiter(r.src[r.pos]) {
+		r.pos++
+	}
+	if r.pos == node.start {
+		return nil, fmt.Errorf("unexpected character %q at offset %d", r.src[r.pos], r.pos)
+	}
+	node.end = r.pos
+	node.text = string(r.src[node.start:node.end])
+	return node, nil
+}
+
+// isSNBTDelimiter reports whether c ends an unquoted token.
+func isSNBTDelimiter(c byte) bool {
+	switch c {
+	case ' ', '\t', '\n', '\r', ',', '{', '}', '[', ']':
+		return true
+	}
+	return false
+}
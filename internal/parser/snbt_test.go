@@ -0,0 +1,337 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+)
+
+const ftbQuestsChapterFixture = `{
+	default_hide_dependency_lines: false
+	filename: "getting_started"
+	icon: "minecraft:oak_sapling"
+	id: "0A1B2C3D4E5F6A7B"
+	order_index: 0
+	quest_links: [ ]
+	quests: [
+		{
+			description: [
+				"Punch a tree to get \"logs\"."
+				""
+				"Logs are the first step."
+			]
+			id: "1234567890ABCDEF"
+			subtitle: "The first step"
+			tasks: [{
+				id: "7766554433221100"
+				item: "minecraft:oak_log"
+				title: "Any Log"
+				type: "item"
+			}]
+			title: "Getting Wood"
+			x: 0.0d
+			y: -1.5d
+		}
+		{
+			id: "FEDCBA0987654321"
+			rewards: [{ count: 4, item: "minecraft:torch", type: "item" }]
+			title: "{ftbquests.quest.FEDCBA0987654321.title}"
+			x: 1.0d
+			y: 0.0d
+		}
+	]
+	title: "Getting Started"
+}
+`
+
+func TestSNBTParser_Parse(t *testing.T) {
+	parser := NewSNBTParser()
+
+	entries, err := parser.Parse([]byte(ftbQuestsChapterFixture))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	wantTexts := map[string]string{
+		"ftbquests.chapter.0A1B2C3D4E5F6A7B.title":       "Getting Started",
+		"ftbquests.quest.1234567890ABCDEF.title":         "Getting Wood",
+		"ftbquests.quest.1234567890ABCDEF.subtitle":      "The first step",
+		"ftbquests.quest.1234567890ABCDEF.description.0": `Punch a tree to get "logs".`,
+		"ftbquests.quest.1234567890ABCDEF.description.2": "Logs are the first step.",
+		"ftbquests.task.7766554433221100.title":          "Any Log",
+	}
+
+	if len(entries) != len(wantTexts) {
+		t.Errorf("Parse() got %d entries, want %d", len(entries), len(wantTexts))
+	}
+
+	keyMap := make(map[string]string)
+	for _, entry := range entries {
+		keyMap[entry.Key] = entry.Text
+	}
+
+	for key, wantText := range wantTexts {
+		if gotText, exists := keyMap[key]; !exists {
+			t.Errorf("Parse() missing key %q", key)
+		} else if gotText != wantText {
+			t.Errorf("Parse() key %q = %q, want %q", key, gotText, wantText)
+		}
+	}
+
+	// Lang-key references are already localized and must not be extracted
+	if _, exists := keyMap["ftbquests.quest.FEDCBA0987654321.title"]; exists {
+		t.Error("Parse() should skip lang-key references")
+	}
+}
+
+func TestSNBTParser_Parse_LineNumbers(t *testing.T) {
+	parser := NewSNBTParser()
+
+	entries, err := parser.Parse([]byte(ftbQuestsChapterFixture))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	lines := strings.Split(ftbQuestsChapterFixture, "\n")
+	for _, entry := range entries {
+		if entry.LineNumber < 1 || entry.LineNumber > len(lines) {
+			t.Errorf("entry %q has out-of-range line %d", entry.Key, entry.LineNumber)
+			continue
+		}
+		if !strings.Contains(lines[entry.LineNumber-1], strings.ReplaceAll(entry.Text, `"`, `\"`)) {
+			t.Errorf("entry %q line %d = %q, does not contain text", entry.Key, entry.LineNumber, lines[entry.LineNumber-1])
+		}
+	}
+}
+
+func TestSNBTParser_Parse_Invalid(t *testing.T) {
+	parser := NewSNBTParser()
+
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{name: "unterminated_compound", content: `{ title: "x"`},
+		{name: "unterminated_string", content: `{ title: "x }`},
+		{name: "missing_colon", content: `{ title "x" }`},
+		{name: "list_root", content: `["a", "b"]`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := parser.Parse([]byte(tt.content)); err == nil {
+				t.Error("Parse() expected error for invalid content")
+			}
+		})
+	}
+}
+
+func TestSNBTParser_Parse_CommaSeparated(t *testing.T) {
+	parser := NewSNBTParser()
+
+	content := `{id:"AA",title:"Chapter",quests:[{id:"BB",title:'It\'s',data:[I;1,2,3]}]}`
+	entries, err := parser.Parse([]byte(content))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	if len(entries) != 2 {
+		t.Fatalf("Parse() got %d entries, want 2", len(entries))
+	}
+	if entries[0].Key != "ftbquests.chapter.AA.title" || entries[0].Text != "Chapter" {
+		t.Errorf("Parse() entries[0] = %+v", entries[0])
+	}
+	if entries[1].Key != "ftbquests.quest.BB.title" || entries[1].Text != "It's" {
+		t.Errorf("Parse() entries[1] = %+v", entries[1])
+	}
+}
+
+func TestSNBTParser_Apply(t *testing.T) {
+	parser := NewSNBTParser()
+
+	translations := map[string]string{
+		"ftbquests.quest.1234567890ABCDEF.title":         "木材の入手",
+		"ftbquests.quest.1234567890ABCDEF.description.0": `木を殴って"原木"を手に入れよう。`,
+	}
+
+	result, err := parser.Apply([]byte(ftbQuestsChapterFixture), translations)
+	if err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+
+	entries, err := parser.Parse(result)
+	if err != nil {
+		t.Fatalf("Parse result error = %v", err)
+	}
+
+	entryMap := make(map[string]string)
+	for _, entry := range entries {
+		entryMap[entry.Key] = entry.Text
+	}
+
+	for key, expected := range translations {
+		if got := entryMap[key]; got != expected {
+			t.Errorf("Apply() key %q = %q, want %q", key, got, expected)
+		}
+	}
+
+	// Only the translated literals change; everything else is byte-identical
+	want := strings.Replace(ftbQuestsChapterFixture, `title: "Getting Wood"`, `title: "木材の入手"`, 1)
+	want = strings.Replace(want, `"Punch a tree to get \"logs\"."`, `"木を殴って\"原木\"を手に入れよう。"`, 1)
+	if string(result) != want {
+		t.Errorf("Apply() changed untranslated content:\n%s", result)
+	}
+}
+
+func TestSNBTParser_Apply_LangKeys(t *testing.T) {
+	parser := NewSNBTParser()
+
+	entries, err := parser.Parse([]byte(ftbQuestsChapterFixture))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	langKeys := make(map[string]string)
+	for _, entry := range entries {
+		langKeys[entry.Key] = "{" + entry.Key + "}"
+	}
+
+	result, err := parser.Apply([]byte(ftbQuestsChapterFixture), langKeys)
+	if err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+
+	if !strings.Contains(string(result), `title: "{ftbquests.quest.1234567890ABCDEF.title}"`) {
+		t.Errorf("Apply() did not rewrite title to lang key:\n%s", result)
+	}
+
+	// Rewritten file has nothing left to translate
+	remaining, err := parser.Parse(result)
+	if err != nil {
+		t.Fatalf("Parse result error = %v", err)
+	}
+	if len(remaining) != 0 {
+		t.Errorf("Parse() after lang-key rewrite got %d entries, want 0", len(remaining))
+	}
+}
+
+func TestSNBTParser_TextComponents(t *testing.T) {
+	parser := NewSNBTParser()
+
+	content := `{
+	id: "AA"
+	title: "{\"text\":\"Chapter\",\"color\":\"gold\"}"
+	quests: [{
+		id: "BB"
+		description: ["[\"\",{\"text\":\"Bold intro\",\"bold\":true}]", "Plain line"]
+		rewards: [{
+			id: "CC"
+			type: "command"
+			command: "/tellraw @p {\"text\":\"Well done!\",\"color\":\"green\"}"
+		}, {
+			id: "DD"
+			type: "command"
+			command: "/give @p minecraft:diamond"
+		}]
+	}]
+}`
+
+	entries, err := parser.Parse([]byte(content))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	wantTexts := map[string]string{
+		"ftbquests.chapter.AA.title.text":          "Chapter",
+		"ftbquests.quest.BB.description.0[1].text": "Bold intro",
+		"ftbquests.quest.BB.description.1":         "Plain line",
+		"ftbquests.reward.CC.command.text":         "Well done!",
+	}
+
+	if len(entries) != len(wantTexts) {
+		t.Errorf("Parse() got %d entries, want %d: %+v", len(entries), len(wantTexts), entries)
+	}
+	for _, entry := range entries {
+		if want, ok := wantTexts[entry.Key]; !ok {
+			t.Errorf("Parse() unexpected key %q", entry.Key)
+		} else if entry.Text != want {
+			t.Errorf("Parse() key %q = %q, want %q", entry.Key, entry.Text, want)
+		}
+	}
+
+	result, err := parser.Apply([]byte(content), map[string]string{
+		"ftbquests.chapter.AA.title.text":  "第1章",
+		"ftbquests.reward.CC.command.text": "よくできました！",
+	})
+	if err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+
+	for _, want := range []string{
+		`title: "{\"color\":\"gold\",\"text\":\"第1章\"}"`,
+		`command: "/tellraw @p {\"color\":\"green\",\"text\":\"よくできました！\"}"`,
+		`"[\"\",{\"text\":\"Bold intro\",\"bold\":true}]"`,
+		`command: "/give @p minecraft:diamond"`,
+	} {
+		if !strings.Contains(string(result), want) {
+			t.Errorf("Apply() result missing %s:\n%s", want, result)
+		}
+	}
+}
+
+func TestSNBTParser_ApplyLangKeys_TextComponents(t *testing.T) {
+	parser := NewSNBTParser()
+
+	content := `{
+	id: "AA"
+	title: "{\"text\":\"Chapter\",\"color\":\"gold\"}"
+	subtitle: "{\"translate\":\"item.minecraft.diamond\",\"fallback\":\"Diamond\"}"
+	quests: [{
+		id: "BB"
+		description: ["[\"\",{\"text\":\"Bold intro\",\"bold\":true}]", "Plain line"]
+		rewards: [{
+			id: "CC"
+			type: "command"
+			command: "/tellraw @p {\"text\":\"Well done!\",\"color\":\"green\"}"
+		}]
+	}]
+}`
+
+	result, entries, err := parser.ApplyLangKeys([]byte(content))
+	if err != nil {
+		t.Fatalf("ApplyLangKeys() error = %v", err)
+	}
+
+	wantTexts := map[string]string{
+		"ftbquests.chapter.AA.title.text":          "Chapter",
+		"ftbquests.quest.BB.description.0[1].text": "Bold intro",
+		"ftbquests.quest.BB.description.1":         "Plain line",
+		"ftbquests.reward.CC.command.text":         "Well done!",
+	}
+	if len(entries) != len(wantTexts) {
+		t.Errorf("ApplyLangKeys() got %d entries, want %d: %+v", len(entries), len(wantTexts), entries)
+	}
+	for _, entry := range entries {
+		if want, ok := wantTexts[entry.Key]; !ok {
+			t.Errorf("ApplyLangKeys() unexpected key %q", entry.Key)
+		} else if entry.Text != want {
+			t.Errorf("ApplyLangKeys() key %q = %q, want %q", entry.Key, entry.Text, want)
+		}
+	}
+
+	for _, want := range []string{
+		`title: "{\"color\":\"gold\",\"fallback\":\"Chapter\",\"translate\":\"ftbquests.chapter.AA.title.text\"}"`,
+		`subtitle: "{\"translate\":\"item.minecraft.diamond\",\"fallback\":\"Diamond\"}"`,
+		`"[\"\",{\"bold\":true,\"fallback\":\"Bold intro\",\"translate\":\"ftbquests.quest.BB.description.0[1].text\"}]"`,
+		`"{ftbquests.quest.BB.description.1}"`,
+		`command: "/tellraw @p {\"color\":\"green\",\"fallback\":\"Well done!\",\"translate\":\"ftbquests.reward.CC.command.text\"}"`,
+	} {
+		if !strings.Contains(string(result), want) {
+			t.Errorf("ApplyLangKeys() result missing %s:\n%s", want, result)
+		}
+	}
+
+	// Component text lives only in the lang files
+	if strings.Contains(string(result), `\"text\":`) {
+		t.Errorf("ApplyLangKeys() left literal text in a component:\n%s", result)
+	}
+}
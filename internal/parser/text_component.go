@@ -0,0 +1,237 @@
+package parser
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Minecraft text components (JSON chat format) come in three shapes:
+//
+//	"plain text"
+//	{"text": "Hello", "color": "gold", "extra": [...]}
+//	{"translate": "some.key", "fallback": "Hello", "with": [...]}
+//	["", {"text": "a"}, "b"]  (the first element is the parent of the rest)
+//
+// Only the human-readable fields (text, fallback, with/extra children and show_text
+// hover contents) are extracted. Everything else - styling, click events, translate
+// keys, scores, selectors - is left untouched on Apply.
+
+// textComponentField is a readable string inside a text component.
+type textComponentField struct {
+	key       string // path below the component prefix, e.g. "title.extra[0].text"
+	text      string
+	component bool // false when the whole value was a plain string
+}
+
+// textComponentMarkers are keys that identify a JSON object as a text component.
+var textComponentMarkers = []string{"text", "translate", "extra", "keybind", "score", "selector"}
+
+// tellrawRegex splits a tellraw/title command into its head and JSON component argument.
+var tellrawRegex = regexp.MustCompile(`^(\s*/?(?:tellraw\s+\S+|title\s+\S+\s+(?:title|subtitle|actionbar))\s+)([\[{].*)$`)
+
+// extractTextComponent returns the readable fields of a decoded text component.
+func extractTextComponent(prefix string, v interface{}) []textComponentField {
+	var fields []textComponentField
+	walkTextComponent(prefix, v, false, &fields)
+	return fields
+}
+
+func walkTextComponent(key string, v interface{}, nested bool, fields *[]textComponentField) {
+	switch value := v.(type) {
+	case string:
+		if strings.TrimSpace(value) != "" {
+			*fields = append(*fields, textComponentField{key: key, text: value, component: nested})
+		}
+	case []interface{}:
+		for i, item := range value {
+			walkTextComponent(fmt.Sprintf("%s[%d]", key, i), item, true, fields)
+		}
+	case map[string]interface{}:
+		if text, ok := value["text"].(string); ok && strings.TrimSpace(text) != "" {
+			*fields = append(*fields, textComponentField{key: joinComponentKey(key, "text"), text: text, component: true})
+		}
+		if fallback, ok := value["fallback"].(string); ok && strings.TrimSpace(fallback) != "" {
+			*fields = append(*fields, textComponentField{key: joinComponentKey(key, "fallback"), text: fallback, component: true})
+		}
+		for _, child := range []string{"with", "extra"} {
+			if items, ok := value[child].([]interface{}); ok {
+				for i, item := range items {
+					walkTextComponent(fmt.Sprintf("%s[%d]", joinComponentKey(key, child), i), item, true, fields)
+				}
+			}
+		}
+		if hover, ok := value["hoverEvent"].(map[string]interface{}); ok && hover["action"] == "show_text" {
+			for _, child := range []string{"contents", "value"} {
+				if contents, ok := hover[child]; ok {
+					walkTextComponent(joinComponentKey(key, "hoverEvent."+child), contents, true, fields)
+				}
+			}
+		}
+	}
+}
+
+// applyTextComponent returns v with readable fields replaced from translations.
+// Keys not present in translations keep their original text.
+func applyTextComponent(prefix string, v interface{}, translations map[string]string) interface{} {
+	switch value := v.(type) {
+	case string:
+		if translation, ok := translations[prefix]; ok {
+			return translation
+		}
+		return value
+	case []interface{}:
+		for i, item := range value {
+			value[i] = applyTextComponent(fmt.Sprintf("%s[%d]", prefix, i), item, translations)
+		}
+		return value
+	case map[string]interface{}:
+		for _, field := range []string{"text", "fallback"} {
+			if _, ok := value[field].(string); ok {
+				if translation, ok := translations[joinComponentKey(prefix, field)]; ok {
+					value[field] = translation
+				}
+			}
+		}
+		for _, child := range []string{"with", "extra"} {
+			if items, ok := value[child].([]interface{}); ok {
+				for i, item := range items {
+					items[i] = applyTextComponent(fmt.Sprintf("%s[%d]", joinComponentKey(prefix, child), i), item, translations)
+				}
+			}
+		}
+		if hover, ok := value["hoverEvent"].(map[string]interface{}); ok && hover["action"] == "show_text" {
+			for _, child := range []string{"contents", "value"} {
+				if contents, ok := hover[child]; ok {
+					hover[child] = applyTextComponent(joinComponentKey(prefix, "hoverEvent."+child), contents, translations)
+				}
+			}
+		}
+		return value
+	}
+	return v
+}
+
+// localizeTextComponent returns v with readable text replaced by translate components
+// referencing the text's key, keeping the original text as fallback. The replaced
+// fields are appended to parts. Existing translate components are kept as they are,
+// apart from their with/extra children and hover text.
+func localizeTextComponent(prefix string, v interface{}, parts *[]textComponentField) interface{} {
+	switch value := v.(type) {
+	case string:
+		if strings.TrimSpace(value) == "" {
+			return value
+		}
+		*parts = append(*parts, textComponentField{key: prefix, text: value, component: true})
+		return map[string]interface{}{"translate": prefix, "fallback": value}
+	case []interface{}:
+		for i, item := range value {
+			value[i] = localizeTextComponent(fmt.Sprintf("%s[%d]", prefix, i), item, parts)
+		}
+		return value
+	case map[string]interface{}:
+		if _, translated := value["translate"]; !translated {
+			if text, ok := value["text"].(string); ok && strings.TrimSpace(text) != "" {
+				key := joinComponentKey(prefix, "text")
+				*parts = append(*parts, textComponentField{key: key, text: text, component: true})
+				delete(value, "text")
+				value["translate"] = key
+				value["fallback"] = text
+			}
+		}
+		for _, child := range []string{"with", "extra"} {
+			if items, ok := value[child].([]interface{}); ok {
+				for i, item := range items {
+					items[i] = localizeTextComponent(fmt.Sprintf("%s[%d]", joinComponentKey(prefix, child), i), item, parts)
+				}
+			}
+		}
+		if hover, ok := value["hoverEvent"].(map[string]interface{}); ok && hover["action"] == "show_text" {
+			for _, child := range []string{"contents", "value"} {
+				if contents, ok := hover[child]; ok {
+					hover[child] = localizeTextComponent(joinComponentKey(prefix, "hoverEvent."+child), contents, parts)
+				}
+			}
+		}
+		return value
+	}
+	return v
+}
+
+// decodeTextComponent decodes a string holding a JSON text component.
+// Returns false for plain text and for JSON that is not a text component.
+func decodeTextComponent(s string) (interface{}, bool) {
+	trimmed := strings.TrimSpace(s)
+	if trimmed == "" || (trimmed[0] != '{' && trimmed[0] != '[') {
+		return nil, false
+	}
+
+	decoder := json.NewDecoder(strings.NewReader(trimmed))
+	decoder.UseNumber()
+	var v interface{}
+	if err := decoder.Decode(&v); err != nil || decoder.More() {
+		return nil, false
+	}
+
+	if !isTextComponent(v) {
+		return nil, false
+	}
+	return v, true
+}
+
+// isTextComponent reports whether a decoded JSON value looks like a text component.
+func isTextComponent(v interface{}) bool {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		for _, marker := range textComponentMarkers {
+			if _, ok := value[marker]; ok {
+				return true
+			}
+		}
+	case []interface{}:
+		if len(value) == 0 {
+			return false
+		}
+		for _, item := range value {
+			if _, ok := item.(string); !ok && !isTextComponent(item) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// encodeTextComponent encodes a text component as compact JSON without HTML escaping.
+func encodeTextComponent(v interface{}) (string, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(v); err != nil {
+		return "", fmt.Errorf("failed to encode text component: %w", err)
+	}
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
+
+// splitTellrawCommand splits a tellraw or title command into its head
+// (e.g. "/tellraw @p ") and decoded JSON component.
+func splitTellrawCommand(command string) (string, interface{}, bool) {
+	m := tellrawRegex.FindStringSubmatch(command)
+	if m == nil {
+		return "", nil, false
+	}
+	v, ok := decodeTextComponent(m[2])
+	if !ok {
+		return "", nil, false
+	}
+	return m[1], v, true
+}
+
+func joinComponentKey(prefix, field string) string {
+	if prefix == "" {
+		return field
+	}
+	return prefix + "." + field
+}
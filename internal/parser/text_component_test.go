@@ -0,0 +1,126 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDecodeTextComponent(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  bool
+	}{
+		{name: "plain_text", input: "Hello", want: false},
+		{name: "text_object", input: `{"text":"Hello","color":"gold"}`, want: true},
+		{name: "translate_object", input: `{"translate":"item.x","fallback":"X"}`, want: true},
+		{name: "array", input: `["", {"text":"a"}, "b"]`, want: true},
+		{name: "non_component_object", input: `{"item":"minecraft:stone"}`, want: false},
+		{name: "lang_key_reference", input: `{ftbquests.quest.x.title}`, want: false},
+		{name: "trailing_data", input: `{"text":"a"} extra`, want: false},
+		{name: "empty_array", input: `[]`, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, got := decodeTextComponent(tt.input); got != tt.want {
+				t.Errorf("decodeTextComponent(%q) ok = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtractTextComponent(t *testing.T) {
+	component, ok := decodeTextComponent(`{
+		"text": "Welcome to ",
+		"color": "gray",
+		"extra": [
+			{"text": "the Pack", "bold": true, "hoverEvent": {"action": "show_text", "contents": "Click me"}},
+			{"translate": "item.minecraft.diamond", "fallback": "Diamond"},
+			{"translate": "chat.type.text", "with": ["Steve", {"text": "hi"}]},
+			{"keybind": "key.jump"}
+		]
+	}`)
+	if !ok {
+		t.Fatal("decodeTextComponent() failed")
+	}
+
+	fields := extractTextComponent("title", component)
+
+	want := []textComponentField{
+		{key: "title.text", text: "Welcome to ", component: true},
+		{key: "title.extra[0].text", text: "the Pack", component: true},
+		{key: "title.extra[0].hoverEvent.contents", text: "Click me", component: true},
+		{key: "title.extra[1].fallback", text: "Diamond", component: true},
+		{key: "title.extra[2].with[0]", text: "Steve", component: true},
+		{key: "title.extra[2].with[1].text", text: "hi", component: true},
+	}
+
+	if !reflect.DeepEqual(fields, want) {
+		t.Errorf("extractTextComponent() =\n%+v\nwant\n%+v", fields, want)
+	}
+
+	// A plain string is a single non-component field
+	plain := extractTextComponent("name", "Copper Gear")
+	if len(plain) != 1 || plain[0].key != "name" || plain[0].component {
+		t.Errorf("extractTextComponent(plain) = %+v", plain)
+	}
+}
+
+func TestApplyTextComponent_RoundTrip(t *testing.T) {
+	input := `{"bold":true,"color":"gold","extra":[{"clickEvent":{"action":"run_command","value":"/spawn"},"text":"here"}],"text":"Click "}`
+
+	component, ok := decodeTextComponent(input)
+	if !ok {
+		t.Fatal("decodeTextComponent() failed")
+	}
+
+	// No translations: output is identical
+	encoded, err := encodeTextComponent(applyTextComponent("", component, nil))
+	if err != nil {
+		t.Fatalf("encodeTextComponent() error = %v", err)
+	}
+	if encoded != input {
+		t.Errorf("round trip = %s, want %s", encoded, input)
+	}
+
+	// Translations replace only readable text; styling and click events are kept
+	component, _ = decodeTextComponent(input)
+	applied := applyTextComponent("", component, map[string]string{
+		"text":          "クリック: ",
+		"extra[0].text": "ここ",
+	})
+	encoded, err = encodeTextComponent(applied)
+	if err != nil {
+		t.Fatalf("encodeTextComponent() error = %v", err)
+	}
+	want := `{"bold":true,"color":"gold","extra":[{"clickEvent":{"action":"run_command","value":"/spawn"},"text":"ここ"}],"text":"クリック: "}`
+	if encoded != want {
+		t.Errorf("applyTextComponent() = %s, want %s", encoded, want)
+	}
+}
+
+func TestSplitTellrawCommand(t *testing.T) {
+	tests := []struct {
+		command  string
+		wantHead string
+		wantOK   bool
+	}{
+		{command: `/tellraw @p {"text":"Well done!","color":"green"}`, wantHead: "/tellraw @p ", wantOK: true},
+		{command: `tellraw @a[distance=..5] ["",{"text":"Hi"}]`, wantHead: "tellraw @a[distance=..5] ", wantOK: true},
+		{command: `/title @s subtitle {"text":"Chapter 2"}`, wantHead: "/title @s subtitle ", wantOK: true},
+		{command: `/give @p minecraft:diamond 1`, wantOK: false},
+		{command: `/tellraw @p plain`, wantOK: false},
+	}
+
+	for _, tt := range tests {
+		head, _, ok := splitTellrawCommand(tt.command)
+		if ok != tt.wantOK {
+			t.Errorf("splitTellrawCommand(%q) ok = %v, want %v", tt.command, ok, tt.wantOK)
+			continue
+		}
+		if ok && head != tt.wantHead {
+			t.Errorf("splitTellrawCommand(%q) head = %q, want %q", tt.command, head, tt.wantHead)
+		}
+	}
+}
@@ -3,6 +3,7 @@ package dictionary
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 
@@ -137,29 +138,157 @@ func (c *Client) ListTranslations(ctx context.Context, versionID int64, filter i
 	return c.repo.ListTranslations(ctx, versionID, filter)
 }
 
-// BulkGetTranslations retrieves multiple translations efficiently.
+// BulkGetTranslations retrieves the translations of multiple keys in a mod version.
+// Keys without a translation are left out of the result. With a SourceRepository the keys
+// are looked up in batched queries in the configured target language; otherwise each key
+// is queried separately.
 func (c *Client) BulkGetTranslations(ctx context.Context, versionID int64, keys []string) (map[string]*models.Translation, error) {
-	translations, err := c.repo.ListTranslations(ctx, versionID, interfaces.TranslationFilter{})
+	result := make(map[string]*models.Translation, len(keys))
+
+	if repo, err := c.sourceRepo(); err == nil {
+		translations, err := repo.ListTranslationsWithSourceByVersionKeys(ctx, versionID, keys, interfaces.TranslationFilter{
+			TargetLang: c.config.TargetLang,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range translations {
+			result[t.Key] = &t.Translation
+		}
+		return result, nil
+	}
+
+	for _, k := range keys {
+		if _, ok := result[k]; ok {
+			continue
+		}
+		t, err := c.repo.GetTranslation(ctx, versionID, k)
+		if errors.Is(err, ErrTranslationNotFound) {
+			// Keys without a translation are left out of the result
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		result[k] = t
+	}
+
+	return result, nil
+}
+
+// ==================== Mod Translation Operations ====================
+
+// sourceRepo returns the repository as a SourceRepository for mod-level queries.
+func (c *Client) sourceRepo() (interfaces.SourceRepository, error) {
+	repo, ok := c.repo.(interfaces.SourceRepository)
+	if !ok {
+		return nil, ErrSourceSchemaUnsupported
+	}
+	return repo, nil
+}
+
+// GetDefaultVersion retrieves the default version of a mod, or its newest version if
+// none is marked as default.
+func (c *Client) GetDefaultVersion(ctx context.Context, modID string) (*models.ModVersion, error) {
+	repo, err := c.sourceRepo()
 	if err != nil {
 		return nil, err
 	}
+	return repo.GetDefaultVersion(ctx, modID)
+}
 
-	// Build key set for filtering
-	keySet := make(map[string]struct{}, len(keys))
-	for _, k := range keys {
-		keySet[k] = struct{}{}
+// GetModTranslation retrieves the translation of a key in a mod's default version.
+// It returns ErrSourceNotFound if the mod has no such key and ErrTranslationNotFound if
+// the key has no translation into targetLang.
+func (c *Client) GetModTranslation(ctx context.Context, modID, key, targetLang string) (*models.TranslationWithSource, error) {
+	repo, err := c.sourceRepo()
+	if err != nil {
+		return nil, err
 	}
 
-	result := make(map[string]*models.Translation)
-	for _, t := range translations {
-		if _, ok := keySet[t.Key]; ok {
-			result[t.Key] = t
-		}
+	source, err := repo.GetSourceByModAndKey(ctx, modID, key)
+	if err != nil {
+		return nil, err
+	}
+	if source == nil {
+		return nil, ErrSourceNotFound
+	}
+
+	t, err := repo.GetTranslationBySource(ctx, source.ID, targetLang)
+	if err != nil {
+		return nil, err
+	}
+	return &models.TranslationWithSource{
+		Translation: *t,
+		Key:         source.Key,
+		SourceText:  source.SourceText,
+		SourceLang:  source.SourceLang,
+		IsCurrent:   source.IsCurrent,
+	}, nil
+}
+
+// ListModTranslations retrieves the translations of a mod's default version with their
+// source keys and texts.
+func (c *Client) ListModTranslations(ctx context.Context, modID string, filter interfaces.TranslationFilter) ([]*models.TranslationWithSource, error) {
+	repo, err := c.sourceRepo()
+	if err != nil {
+		return nil, err
+	}
+	return repo.ListTranslationsWithSourceByMod(ctx, modID, filter)
+}
+
+// ListVersionTranslations retrieves the translations of a specific mod version with
+// their source keys and texts.
+func (c *Client) ListVersionTranslations(ctx context.Context, versionID int64, filter interfaces.TranslationFilter) ([]*models.TranslationWithSource, error) {
+	repo, err := c.sourceRepo()
+	if err != nil {
+		return nil, err
+	}
+	return repo.ListTranslationsWithSourceByVersion(ctx, versionID, filter)
+}
+
+// BulkGetModTranslations retrieves the translations of multiple keys in a mod's default
+// version, keyed by translation key. Keys without a translation into targetLang are left out.
+func (c *Client) BulkGetModTranslations(ctx context.Context, modID string, keys []string, targetLang string) (map[string]*models.TranslationWithSource, error) {
+	repo, err := c.sourceRepo()
+	if err != nil {
+		return nil, err
 	}
 
+	translations, err := repo.ListTranslationsWithSourceByModKeys(ctx, modID, keys, interfaces.TranslationFilter{
+		TargetLang: targetLang,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]*models.TranslationWithSource, len(translations))
+	for _, t := range translations {
+		result[t.Key] = t
+	}
 	return result, nil
 }
 
+// StreamModTranslations calls fn for each translation of a mod's default version without
+// loading the whole mod into memory. An error from fn stops the iteration and is returned.
+func (c *Client) StreamModTranslations(ctx context.Context, modID string, filter interfaces.TranslationFilter, fn func(*models.TranslationWithSource) error) error {
+	repo, err := c.sourceRepo()
+	if err != nil {
+		return err
+	}
+	return repo.StreamTranslationsWithSourceByMod(ctx, modID, filter, fn)
+}
+
+// CountModTranslations returns the translation counts by status for a mod's default
+// version. Keys without a translation are counted as pending.
+func (c *Client) CountModTranslations(ctx context.Context, modID string) (map[string]int, error) {
+	repo, err := c.sourceRepo()
+	if err != nil {
+		return nil, err
+	}
+	return repo.CountTranslationsByMod(ctx, modID)
+}
+
 // ==================== Pattern Operations ====================
 
 // GetPatterns retrieves file patterns for a mod.
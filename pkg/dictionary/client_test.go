@@ -0,0 +1,235 @@
+package dictionary
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+
+	"github.com/iuif/minecraft-mod-dictionary/pkg/interfaces"
+	"github.com/iuif/minecraft-mod-dictionary/pkg/models"
+	"github.com/iuif/minecraft-mod-dictionary/pkg/repotest"
+)
+
+// seedClientRepository creates the mod "create" with an old version and a default version.
+// The default version has four keys: gear and shaft translated into ja_jp (shaft also into
+// ko_kr), belt pending and wrench without any translation. It returns the default version.
+func seedClientRepository(t *testing.T, repo *repotest.Repository) *models.ModVersion {
+	t.Helper()
+	ctx := context.Background()
+
+	if err := repo.SaveMod(ctx, &models.Mod{ID: "create", DisplayName: "Create"}); err != nil {
+		t.Fatalf("SaveMod() error = %v", err)
+	}
+	old := &models.ModVersion{ModID: "create", Version: "0.5.0", MCVersion: "1.20.1"}
+	current := &models.ModVersion{ModID: "create", Version: "0.5.1", MCVersion: "1.20.1"}
+	for _, v := range []*models.ModVersion{old, current} {
+		if err := repo.SaveVersion(ctx, v); err != nil {
+			t.Fatalf("SaveVersion() error = %v", err)
+		}
+	}
+	if err := repo.SetDefaultVersion(ctx, current.ID); err != nil {
+		t.Fatalf("SetDefaultVersion() error = %v", err)
+	}
+
+	addSource := func(version *models.ModVersion, key, text string, translations map[string]string) {
+		t.Helper()
+		source, _, err := repo.GetOrCreateSource(ctx, "create", key, text, "en_us")
+		if err != nil {
+			t.Fatalf("GetOrCreateSource() error = %v", err)
+		}
+		if err := repo.LinkSourceToVersion(ctx, source.ID, version.ID); err != nil {
+			t.Fatalf("LinkSourceToVersion() error = %v", err)
+		}
+		for lang, target := range translations {
+			trans := &models.Translation{SourceID: source.ID, TargetLang: lang, Status: models.StatusPending}
+			if target != "" {
+				target := target
+				trans.TargetText = &target
+				trans.Status = models.StatusTranslated
+			}
+			if err := repo.SaveTranslation(ctx, trans); err != nil {
+				t.Fatalf("SaveTranslation() error = %v", err)
+			}
+		}
+	}
+
+	addSource(old, "item.create.old", "Old", map[string]string{"ja_jp": "古い"})
+	addSource(current, "item.create.gear", "Gear", map[string]string{"ja_jp": "歯車"})
+	addSource(current, "item.create.shaft", "Shaft", map[string]string{"ja_jp": "シャフト", "ko_kr": "샤프트"})
+	addSource(current, "item.create.belt", "Belt", map[string]string{"ja_jp": ""})
+	addSource(current, "item.create.wrench", "Wrench", nil)
+	return current
+}
+
+func newTestClient(t *testing.T, repo interfaces.Repository, opts ...Option) *Client {
+	t.Helper()
+	client, err := New(repo, opts...)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	return client
+}
+
+func TestClient_GetModTranslation(t *testing.T) {
+	ctx := context.Background()
+	repo := repotest.NewRepository()
+	seedClientRepository(t, repo)
+	client := newTestClient(t, repo)
+
+	got, err := client.GetModTranslation(ctx, "create", "item.create.shaft", "ko_kr")
+	if err != nil {
+		t.Fatalf("GetModTranslation() error = %v", err)
+	}
+	if got.Key != "item.create.shaft" || got.SourceText != "Shaft" || got.TargetText == nil || *got.TargetText != "샤프트" {
+		t.Errorf("GetModTranslation() = %+v", got)
+	}
+
+	if _, err := client.GetModTranslation(ctx, "create", "item.create.missing", "ja_jp"); !errors.Is(err, ErrSourceNotFound) {
+		t.Errorf("GetModTranslation() of a missing key error = %v, want ErrSourceNotFound", err)
+	}
+	if _, err := client.GetModTranslation(ctx, "create", "item.create.wrench", "ja_jp"); !errors.Is(err, ErrTranslationNotFound) {
+		t.Errorf("GetModTranslation() of an untranslated key error = %v, want ErrTranslationNotFound", err)
+	}
+}
+
+func TestClient_BulkGetModTranslations(t *testing.T) {
+	ctx := context.Background()
+	repo := repotest.NewRepository()
+	seedClientRepository(t, repo)
+	client := newTestClient(t, repo)
+
+	keys := []string{"item.create.gear", "item.create.shaft", "item.create.wrench", "item.create.old", "item.create.missing"}
+	got, err := client.BulkGetModTranslations(ctx, "create", keys, "ja_jp")
+	if err != nil {
+		t.Fatalf("BulkGetModTranslations() error = %v", err)
+	}
+
+	want := map[string]string{"item.create.gear": "歯車", "item.create.shaft": "シャフト"}
+	if len(got) != len(want) {
+		t.Errorf("BulkGetModTranslations() returned %d translations, want %d: %v", len(got), len(want), got)
+	}
+	for key, text := range want {
+		if got[key] == nil || got[key].TargetText == nil || *got[key].TargetText != text {
+			t.Errorf("BulkGetModTranslations()[%s] = %+v, want %q", key, got[key], text)
+		}
+	}
+}
+
+func TestClient_StreamModTranslations(t *testing.T) {
+	ctx := context.Background()
+	repo := repotest.NewRepository()
+	seedClientRepository(t, repo)
+	client := newTestClient(t, repo)
+
+	var keys []string
+	err := client.StreamModTranslations(ctx, "create", interfaces.TranslationFilter{TargetLang: "ja_jp"}, func(t *models.TranslationWithSource) error {
+		keys = append(keys, t.Key)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("StreamModTranslations() error = %v", err)
+	}
+	sort.Strings(keys)
+	want := []string{"item.create.belt", "item.create.gear", "item.create.shaft"}
+	if len(keys) != len(want) {
+		t.Fatalf("StreamModTranslations() streamed %v, want %v", keys, want)
+	}
+	for i := range want {
+		if keys[i] != want[i] {
+			t.Errorf("StreamModTranslations() streamed %v, want %v", keys, want)
+			break
+		}
+	}
+
+	stop := errors.New("stop")
+	calls := 0
+	err = client.StreamModTranslations(ctx, "create", interfaces.TranslationFilter{}, func(t *models.TranslationWithSource) error {
+		calls++
+		return stop
+	})
+	if !errors.Is(err, stop) || calls != 1 {
+		t.Errorf("StreamModTranslations() with a failing callback = %v after %d calls, want stop after 1", err, calls)
+	}
+}
+
+func TestClient_CountModTranslations(t *testing.T) {
+	ctx := context.Background()
+	repo := repotest.NewRepository()
+	seedClientRepository(t, repo)
+	client := newTestClient(t, repo)
+
+	counts, err := client.CountModTranslations(ctx, "create")
+	if err != nil {
+		t.Fatalf("CountModTranslations() error = %v", err)
+	}
+	// Translations of all languages are counted; the wrench key has none and counts as pending
+	if counts[models.StatusTranslated] != 3 || counts[models.StatusPending] != 2 {
+		t.Errorf("CountModTranslations() = %v, want 3 translated and 2 pending", counts)
+	}
+}
+
+func TestClient_BulkGetTranslations(t *testing.T) {
+	ctx := context.Background()
+	repo := repotest.NewRepository()
+	version := seedClientRepository(t, repo)
+
+	keys := []string{"item.create.gear", "item.create.shaft", "item.create.wrench", "item.create.old"}
+
+	got, err := newTestClient(t, repo).BulkGetTranslations(ctx, version.ID, keys)
+	if err != nil {
+		t.Fatalf("BulkGetTranslations() error = %v", err)
+	}
+	if len(got) != 2 || got["item.create.gear"] == nil || *got["item.create.gear"].TargetText != "歯車" {
+		t.Errorf("BulkGetTranslations() = %v, want the ja_jp translations of gear and shaft", got)
+	}
+
+	// The batched lookup uses the configured target language
+	got, err = newTestClient(t, repo, WithTargetLang("ko_kr")).BulkGetTranslations(ctx, version.ID, keys)
+	if err != nil {
+		t.Fatalf("BulkGetTranslations() error = %v", err)
+	}
+	if len(got) != 1 || got["item.create.shaft"] == nil || *got["item.create.shaft"].TargetText != "샤프트" {
+		t.Errorf("BulkGetTranslations() with ko_kr = %v, want the shaft translation", got)
+	}
+}
+
+// plainRepository hides the SourceRepository methods of a repository, so that the client
+// uses its per-key fallback. GetTranslation fails for the key in fail.
+type plainRepository struct {
+	interfaces.Repository
+	fail string
+}
+
+var errLookupFailed = errors.New("lookup failed")
+
+func (r *plainRepository) GetTranslation(ctx context.Context, versionID int64, key string) (*models.Translation, error) {
+	if key == r.fail {
+		return nil, errLookupFailed
+	}
+	return r.Repository.GetTranslation(ctx, versionID, key)
+}
+
+func TestClient_BulkGetTranslations_Fallback(t *testing.T) {
+	ctx := context.Background()
+	repo := repotest.NewRepository()
+	version := seedClientRepository(t, repo)
+
+	client := newTestClient(t, &plainRepository{Repository: repo})
+	if _, err := client.GetModTranslation(ctx, "create", "item.create.gear", "ja_jp"); !errors.Is(err, ErrSourceSchemaUnsupported) {
+		t.Errorf("GetModTranslation() without SourceRepository error = %v, want ErrSourceSchemaUnsupported", err)
+	}
+
+	got, err := client.BulkGetTranslations(ctx, version.ID, []string{"item.create.gear", "item.create.wrench"})
+	if err != nil {
+		t.Fatalf("BulkGetTranslations() error = %v", err)
+	}
+	if len(got) != 1 || got["item.create.gear"] == nil {
+		t.Errorf("BulkGetTranslations() = %v, want only the gear translation", got)
+	}
+
+	client = newTestClient(t, &plainRepository{Repository: repo, fail: "item.create.shaft"})
+	if _, err := client.BulkGetTranslations(ctx, version.ID, []string{"item.create.gear", "item.create.shaft"}); !errors.Is(err, errLookupFailed) {
+		t.Errorf("BulkGetTranslations() with a failing lookup error = %v, want errLookupFailed", err)
+	}
+}
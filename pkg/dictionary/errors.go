@@ -1,16 +1,26 @@
 package dictionary
 
-import "errors"
+import (
+	"errors"
+
+	"github.com/iuif/minecraft-mod-dictionary/pkg/interfaces"
+)
 
 // Common errors.
 var (
-	ErrModNotFound         = errors.New("mod not found")
-	ErrVersionNotFound     = errors.New("version not found")
-	ErrTranslationNotFound = errors.New("translation not found")
-	ErrTermNotFound        = errors.New("term not found")
-	ErrPatternNotFound     = errors.New("pattern not found")
+	ErrModNotFound         = interfaces.ErrModNotFound
+	ErrVersionNotFound     = interfaces.ErrVersionNotFound
+	ErrTranslationNotFound = interfaces.ErrTranslationNotFound
+	ErrTermNotFound        = interfaces.ErrTermNotFound
+	ErrPatternNotFound     = interfaces.ErrPatternNotFound
+	ErrSourceNotFound      = interfaces.ErrSourceNotFound
+	ErrModArchived         = interfaces.ErrModArchived
 	ErrInvalidScope        = errors.New("invalid scope format")
 	ErrInvalidParser       = errors.New("unknown parser type")
 	ErrParseFailure        = errors.New("failed to parse file")
 	ErrDatabaseConnection  = errors.New("database connection failed")
+
+	// ErrSourceSchemaUnsupported is returned by mod-level queries when the repository
+	// does not implement interfaces.SourceRepository.
+	ErrSourceSchemaUnsupported = errors.New("repository does not support the source-based schema")
 )
@@ -3,10 +3,22 @@ package interfaces
 
 import (
 	"context"
+	"errors"
 
 	"github.com/iuif/minecraft-mod-dictionary/pkg/models"
 )
 
+// Errors returned by Repository implementations. Use errors.Is to check for them.
+var (
+	ErrModNotFound         = errors.New("mod not found")
+	ErrVersionNotFound     = errors.New("version not found")
+	ErrTermNotFound        = errors.New("term not found")
+	ErrTranslationNotFound = errors.New("translation not found")
+	ErrPatternNotFound     = errors.New("pattern not found")
+	ErrSourceNotFound      = errors.New("source not found")
+	ErrModArchived         = errors.New("mod is archived")
+)
+
 // Repository defines the data access interface.
 type Repository interface {
 	// Mod operations
@@ -52,19 +64,70 @@ type Repository interface {
 	Migrate() error
 }
 
+// SourceRepository extends Repository with the source-based schema, where a translation
+// belongs to a TranslationSource (mod + key + source text) that is shared by every mod
+// version containing the same text.
+type SourceRepository interface {
+	Repository
+
+	// Source operations
+	GetSource(ctx context.Context, id int64) (*models.TranslationSource, error)
+	GetSourceByKey(ctx context.Context, modID, key, sourceText string) (*models.TranslationSource, error)                      // nil if not found
+	GetSourceByModAndKey(ctx context.Context, modID, key string) (*models.TranslationSource, error)                            // nil if not found; prefers the default version
+	GetOrCreateSource(ctx context.Context, modID, key, sourceText, sourceLang string) (*models.TranslationSource, bool, error) // true if created
+	ListSourcesByMod(ctx context.Context, modID string, currentOnly bool) ([]*models.TranslationSource, error)
+	ListSourcesByVersion(ctx context.Context, versionID int64) ([]*models.TranslationSource, error)
+	LinkSourceToVersion(ctx context.Context, sourceID, versionID int64) error
+	GetSourceVersions(ctx context.Context, sourceID int64) ([]*models.SourceVersion, error)
+
+	// Default version operations
+	GetDefaultVersion(ctx context.Context, modID string) (*models.ModVersion, error)
+	SetDefaultVersion(ctx context.Context, versionID int64) error
+	GetOrCreateVersion(ctx context.Context, modID, version, loader string) (*models.ModVersion, bool, error) // true if created
+
+	// Archive operations; archived mods and versions are left out of default queries and
+	// exports but keep their sources and translations
+	ArchiveMod(ctx context.Context, modID string) error // also archives the mod's versions
+	UnarchiveMod(ctx context.Context, modID string) error
+	ArchiveVersion(ctx context.Context, id int64) error // promotes another version if it was the default
+	UnarchiveVersion(ctx context.Context, id int64) error
+
+	// Source-based translation operations
+	GetTranslationBySource(ctx context.Context, sourceID int64, targetLang string) (*models.Translation, error)
+	ListTranslationsByMod(ctx context.Context, modID string, filter TranslationFilter) ([]*models.Translation, error)
+	ListTranslationsWithSourceByMod(ctx context.Context, modID string, filter TranslationFilter) ([]*models.TranslationWithSource, error)
+	ListTranslationsWithSourceByVersion(ctx context.Context, versionID int64, filter TranslationFilter) ([]*models.TranslationWithSource, error)
+	CountTranslationsByMod(ctx context.Context, modID string) (map[string]int, error) // by status; sources without translation count as pending
+
+	// Key-set lookups; keys without a translation are left out, Limit and Offset are ignored
+	ListTranslationsWithSourceByModKeys(ctx context.Context, modID string, keys []string, filter TranslationFilter) ([]*models.TranslationWithSource, error)
+	ListTranslationsWithSourceByVersionKeys(ctx context.Context, versionID int64, keys []string, filter TranslationFilter) ([]*models.TranslationWithSource, error)
+
+	// StreamTranslationsWithSourceByMod calls fn for each translation of the mod's default
+	// version in ID order without loading them all; an error from fn stops the iteration.
+	StreamTranslationsWithSourceByMod(ctx context.Context, modID string, filter TranslationFilter, fn func(*models.TranslationWithSource) error) error
+
+	// IterateTranslations calls fn for each translation matching the filter in ID order,
+	// reading one page at a time; fn may use the repository. An error from fn stops the
+	// iteration and is returned.
+	IterateTranslations(ctx context.Context, filter TranslationIterFilter, fn func(*models.TranslationWithSource) error) error
+}
+
 // ModFilter defines filter options for mod queries.
 type ModFilter struct {
-	Tags   []string
-	Limit  int
-	Offset int
+	Tags            []string
+	IncludeArchived bool
+	Limit           int
+	Offset          int
 }
 
 // VersionFilter defines filter options for version queries.
 type VersionFilter struct {
-	MCVersion string
-	Loader    string
-	Limit     int
-	Offset    int
+	MCVersion       string
+	Loader          string
+	IncludeArchived bool
+	Limit           int
+	Offset          int
 }
 
 // TermFilter defines filter options for term queries.
@@ -85,3 +148,14 @@ type TranslationFilter struct {
 	Limit      int
 	Offset     int
 }
+
+// TranslationIterFilter defines the translations visited by IterateTranslations.
+type TranslationIterFilter struct {
+	ModID           string // Empty for all mods
+	VersionID       int64  // Sources of this version; 0 for the default version of each mod
+	TargetLang      string
+	Statuses        []string // Any of these statuses; empty for all
+	TranslatedOnly  bool     // Skip rows without target text
+	IncludeArchived bool     // Also visit the default versions of archived mods and versions
+	BatchSize       int      // Rows per page; 0 for the implementation default
+}
@@ -0,0 +1,23 @@
+package models
+
+import "time"
+
+// MemoryEntry is a translation memory entry imported from another translation group (TMX).
+// Memory entries are low-priority suggestions; they never overwrite translations.
+type MemoryEntry struct {
+	ID         int64     `json:"id" gorm:"primaryKey;autoIncrement"`
+	SourceText string    `json:"source_text" gorm:"index;not null"`
+	SourceLang string    `json:"source_lang" gorm:"default:en_us"`
+	TargetText string    `json:"target_text" gorm:"not null"`
+	TargetLang string    `json:"target_lang" gorm:"default:ja_jp;index"`
+	Origin     string    `json:"origin" gorm:"index"`        // TMX file or group the entry came from
+	ModID      string    `json:"mod_id,omitempty"`           // mod of the original unit, if known
+	Key        string    `json:"key,omitempty"`              // lang key of the original unit, if known
+	Priority   int       `json:"priority" gorm:"default:10"` // higher wins among suggestions
+	CreatedAt  time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// TableName returns the table name for GORM.
+func (MemoryEntry) TableName() string {
+	return "translation_memory"
+}
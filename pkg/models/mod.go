@@ -11,10 +11,16 @@ type Mod struct {
 	Description string            `json:"description,omitempty" yaml:"description,omitempty"`
 	Tags        []string          `json:"tags,omitempty" yaml:"tags,omitempty" gorm:"serializer:json"`
 	Metadata    map[string]string `json:"metadata,omitempty" yaml:"metadata,omitempty" gorm:"serializer:json"`
+	ArchivedAt  *time.Time        `json:"archived_at,omitempty" yaml:"archived_at,omitempty" gorm:"index"` // Hidden from default queries when set
 	CreatedAt   time.Time         `json:"created_at" gorm:"autoCreateTime"`
 	UpdatedAt   time.Time         `json:"updated_at" gorm:"autoUpdateTime"`
 }
 
+// IsArchived reports whether the mod has been archived.
+func (m *Mod) IsArchived() bool {
+	return m.ArchivedAt != nil
+}
+
 // TableName returns the table name for GORM.
 func (Mod) TableName() string {
 	return "mods"
@@ -28,9 +28,11 @@ const (
 
 // Parser type constants.
 const (
-	ParserJSONLang    = "json_lang"
-	ParserJSONGeneric = "json_generic"
-	ParserPatchouli   = "patchouli"
-	ParserSNBT        = "snbt"
-	ParserLegacyLang  = "lang_legacy"
+	ParserJSONLang       = "json_lang"
+	ParserJSONGeneric    = "json_generic"
+	ParserPatchouli      = "patchouli"
+	ParserSNBT           = "snbt"
+	ParserLegacyLang     = "lang_legacy"
+	ParserBetterQuesting = "betterquesting"
+	ParserKubeJSScript   = "kubejs_script"
 )
@@ -6,9 +6,9 @@ import "time"
 // Multiple versions can share the same source if the text hasn't changed.
 type TranslationSource struct {
 	ID         int64     `json:"id" gorm:"primaryKey;autoIncrement"`
-	ModID      string    `json:"mod_id" gorm:"index;not null"`
-	Key        string    `json:"key" gorm:"not null"`
-	SourceText string    `json:"source_text" gorm:"not null"`
+	ModID      string    `json:"mod_id" gorm:"index;not null;uniqueIndex:idx_translation_sources_unique,priority:1"`
+	Key        string    `json:"key" gorm:"not null;uniqueIndex:idx_translation_sources_unique,priority:2"`
+	SourceText string    `json:"source_text" gorm:"not null;uniqueIndex:idx_translation_sources_unique,priority:3"`
 	SourceLang string    `json:"source_lang" gorm:"default:en_us"`
 	IsCurrent  bool      `json:"is_current" gorm:"default:true;index"` // Current/default source for this key
 	CreatedAt  time.Time `json:"created_at" gorm:"autoCreateTime"`
@@ -24,8 +24,8 @@ func (TranslationSource) TableName() string {
 // This tracks which source text was used in which version.
 type SourceVersion struct {
 	ID           int64     `json:"id" gorm:"primaryKey;autoIncrement"`
-	SourceID     int64     `json:"source_id" gorm:"index;not null"`
-	ModVersionID int64     `json:"mod_version_id" gorm:"index;not null"`
+	SourceID     int64     `json:"source_id" gorm:"index;not null;uniqueIndex:idx_source_versions_unique,priority:1"`
+	ModVersionID int64     `json:"mod_version_id" gorm:"index;not null;uniqueIndex:idx_source_versions_unique,priority:2"`
 	CreatedAt    time.Time `json:"created_at" gorm:"autoCreateTime"`
 }
 
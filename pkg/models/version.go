@@ -13,6 +13,7 @@ type ModVersion struct {
 	ParentVersionID *int64            `json:"parent_version_id,omitempty"`
 	Stats           VersionStats      `json:"stats" gorm:"embedded"`
 	Metadata        map[string]string `json:"metadata,omitempty" gorm:"serializer:json"`
+	ArchivedAt      *time.Time        `json:"archived_at,omitempty" gorm:"index"` // Hidden from default queries when set
 	CreatedAt       time.Time         `json:"created_at" gorm:"autoCreateTime"`
 	UpdatedAt       time.Time         `json:"updated_at" gorm:"autoUpdateTime"`
 }
@@ -28,3 +29,8 @@ type VersionStats struct {
 func (ModVersion) TableName() string {
 	return "mod_versions"
 }
+
+// IsArchived reports whether the version has been archived.
+func (v *ModVersion) IsArchived() bool {
+	return v.ArchivedAt != nil
+}
@@ -0,0 +1,1059 @@
+// Package repotest provides an in-memory implementation of interfaces.SourceRepository
+// for tests of code built on the dictionary, without a database.
+package repotest
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/iuif/minecraft-mod-dictionary/pkg/interfaces"
+	"github.com/iuif/minecraft-mod-dictionary/pkg/models"
+)
+
+// Repository is an in-memory interfaces.SourceRepository. It follows the behavior of the
+// database implementation: IDs are assigned on create, translations are upserted on
+// source + target language, deleting a mod or version cascades to its sources, links and
+// translations, and rows referring to a missing mod, version or source are rejected.
+//
+// Results are ordered by ID unless the database orders them otherwise (terms and
+// patterns by priority). Returned values are copies; change them through Save methods.
+// A Repository is safe for concurrent use.
+type Repository struct {
+	mu sync.Mutex
+
+	mods           map[string]*models.Mod
+	versions       map[int64]*models.ModVersion
+	terms          map[int64]*models.Term
+	patterns       map[int64]*models.FilePattern
+	diffs          map[int64]*models.VersionDiff
+	sources        map[int64]*models.TranslationSource
+	sourceVersions map[int64]*models.SourceVersion
+	translations   map[int64]*models.Translation
+
+	lastID int64
+}
+
+// Compile-time check that Repository implements interfaces.SourceRepository.
+var _ interfaces.SourceRepository = (*Repository)(nil)
+
+// NewRepository creates an empty in-memory repository.
+func NewRepository() *Repository {
+	return &Repository{
+		mods:           make(map[string]*models.Mod),
+		versions:       make(map[int64]*models.ModVersion),
+		terms:          make(map[int64]*models.Term),
+		patterns:       make(map[int64]*models.FilePattern),
+		diffs:          make(map[int64]*models.VersionDiff),
+		sources:        make(map[int64]*models.TranslationSource),
+		sourceVersions: make(map[int64]*models.SourceVersion),
+		translations:   make(map[int64]*models.Translation),
+	}
+}
+
+// Close is a no-op.
+func (r *Repository) Close() error {
+	return nil
+}
+
+// Migrate is a no-op.
+func (r *Repository) Migrate() error {
+	return nil
+}
+
+// nextID returns a new ID. IDs are unique across tables, which catches tests that mix up
+// e.g. source and translation IDs.
+func (r *Repository) nextID() int64 {
+	r.lastID++
+	return r.lastID
+}
+
+func clone[T any](v *T) *T {
+	c := *v
+	return &c
+}
+
+// sortedByID returns copies of the map values in ID order.
+func sortedByID[T any](m map[int64]*T, id func(*T) int64, keep func(*T) bool) []*T {
+	result := make([]*T, 0, len(m))
+	for _, v := range m {
+		if keep == nil || keep(v) {
+			result = append(result, clone(v))
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return id(result[i]) < id(result[j]) })
+	return result
+}
+
+// paginate applies limit and offset the way the database does.
+func paginate[T any](items []*T, limit, offset int) []*T {
+	if offset > 0 {
+		if offset >= len(items) {
+			return []*T{}
+		}
+		items = items[offset:]
+	}
+	if limit > 0 && limit < len(items) {
+		items = items[:limit]
+	}
+	return items
+}
+
+func versionID(v *models.ModVersion) int64           { return v.ID }
+func termID(t *models.Term) int64                    { return t.ID }
+func patternID(p *models.FilePattern) int64          { return p.ID }
+func diffID(d *models.VersionDiff) int64             { return d.ID }
+func sourceID(s *models.TranslationSource) int64     { return s.ID }
+func sourceVersionID(sv *models.SourceVersion) int64 { return sv.ID }
+func translationID(t *models.Translation) int64      { return t.ID }
+
+// Mod operations
+
+// GetMod retrieves a mod by ID.
+func (r *Repository) GetMod(ctx context.Context, modID string) (*models.Mod, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	mod, ok := r.mods[modID]
+	if !ok {
+		return nil, interfaces.ErrModNotFound
+	}
+	return clone(mod), nil
+}
+
+// ListMods retrieves mods ordered by ID. Like the database, filter.Tags is not applied.
+func (r *Repository) ListMods(ctx context.Context, filter interfaces.ModFilter) ([]*models.Mod, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	mods := make([]*models.Mod, 0, len(r.mods))
+	for _, mod := range r.mods {
+		if filter.IncludeArchived || !mod.IsArchived() {
+			mods = append(mods, clone(mod))
+		}
+	}
+	sort.Slice(mods, func(i, j int) bool { return mods[i].ID < mods[j].ID })
+	return paginate(mods, filter.Limit, filter.Offset), nil
+}
+
+// SaveMod creates or updates a mod.
+func (r *Repository) SaveMod(ctx context.Context, mod *models.Mod) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	if existing, ok := r.mods[mod.ID]; ok && mod.CreatedAt.IsZero() {
+		mod.CreatedAt = existing.CreatedAt
+	}
+	if mod.CreatedAt.IsZero() {
+		mod.CreatedAt = now
+	}
+	mod.UpdatedAt = now
+	r.mods[mod.ID] = clone(mod)
+	return nil
+}
+
+// DeleteMod deletes a mod with its versions, sources and translations.
+func (r *Repository) DeleteMod(ctx context.Context, modID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.mods, modID)
+	for id, v := range r.versions {
+		if v.ModID == modID {
+			r.deleteVersion(id)
+		}
+	}
+	for id, s := range r.sources {
+		if s.ModID == modID {
+			r.deleteSource(id)
+		}
+	}
+	return nil
+}
+
+// Version operations
+
+// GetVersion retrieves a version by ID.
+func (r *Repository) GetVersion(ctx context.Context, id int64) (*models.ModVersion, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	v, ok := r.versions[id]
+	if !ok {
+		return nil, interfaces.ErrVersionNotFound
+	}
+	return clone(v), nil
+}
+
+// GetVersionBySpec retrieves a version by mod ID, version, and MC version.
+func (r *Repository) GetVersionBySpec(ctx context.Context, modID, version, mcVersion string) (*models.ModVersion, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	versions := sortedByID(r.versions, versionID, func(v *models.ModVersion) bool {
+		return v.ModID == modID && v.Version == version && v.MCVersion == mcVersion
+	})
+	if len(versions) == 0 {
+		return nil, interfaces.ErrVersionNotFound
+	}
+	return versions[0], nil
+}
+
+// ListVersions retrieves versions for a mod matching the filter.
+func (r *Repository) ListVersions(ctx context.Context, modID string, filter interfaces.VersionFilter) ([]*models.ModVersion, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	versions := sortedByID(r.versions, versionID, func(v *models.ModVersion) bool {
+		return v.ModID == modID &&
+			(filter.MCVersion == "" || v.MCVersion == filter.MCVersion) &&
+			(filter.Loader == "" || v.Loader == filter.Loader) &&
+			(filter.IncludeArchived || !v.IsArchived())
+	})
+	return paginate(versions, filter.Limit, filter.Offset), nil
+}
+
+// SaveVersion creates or updates a version. The mod must exist.
+func (r *Repository) SaveVersion(ctx context.Context, version *models.ModVersion) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.saveVersion(version)
+}
+
+func (r *Repository) saveVersion(version *models.ModVersion) error {
+	if _, ok := r.mods[version.ModID]; !ok {
+		return fmt.Errorf("failed to save version: %w: %s", interfaces.ErrModNotFound, version.ModID)
+	}
+
+	now := time.Now()
+	if version.ID == 0 {
+		version.ID = r.nextID()
+	} else if existing, ok := r.versions[version.ID]; ok && version.CreatedAt.IsZero() {
+		version.CreatedAt = existing.CreatedAt
+	}
+	if version.CreatedAt.IsZero() {
+		version.CreatedAt = now
+	}
+	version.UpdatedAt = now
+	r.versions[version.ID] = clone(version)
+	return nil
+}
+
+// DeleteVersion deletes a version and its source links. Sources left without any version
+// are deleted with their translations.
+func (r *Repository) DeleteVersion(ctx context.Context, id int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	sourceIDs := r.linkedSources(func(v *models.ModVersion) bool { return v.ID == id })
+	r.deleteVersion(id)
+	stillLinked := r.linkedSources(func(*models.ModVersion) bool { return true })
+	for sid := range sourceIDs {
+		if !stillLinked[sid] {
+			r.deleteSource(sid)
+		}
+	}
+	return nil
+}
+
+func (r *Repository) deleteVersion(id int64) {
+	delete(r.versions, id)
+	for svID, sv := range r.sourceVersions {
+		if sv.ModVersionID == id {
+			delete(r.sourceVersions, svID)
+		}
+	}
+}
+
+// Term operations
+
+// GetTerm retrieves a term by ID.
+func (r *Repository) GetTerm(ctx context.Context, id int64) (*models.Term, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	term, ok := r.terms[id]
+	if !ok {
+		return nil, interfaces.ErrTermNotFound
+	}
+	return clone(term), nil
+}
+
+// ListTerms retrieves terms matching the filter, higher priority first. Like the
+// database, filter.Tags is not applied.
+func (r *Repository) ListTerms(ctx context.Context, filter interfaces.TermFilter) ([]*models.Term, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	scopes := make(map[string]bool, len(filter.Scopes))
+	for _, s := range filter.Scopes {
+		scopes[s] = true
+	}
+
+	terms := sortedByID(r.terms, termID, func(t *models.Term) bool {
+		return (filter.Scope == "" || t.Scope == filter.Scope) &&
+			(len(scopes) == 0 || scopes[t.Scope]) &&
+			(filter.TargetLang == "" || t.TargetLang == filter.TargetLang)
+	})
+	sort.SliceStable(terms, func(i, j int) bool { return terms[i].Priority > terms[j].Priority })
+	return paginate(terms, filter.Limit, filter.Offset), nil
+}
+
+// SaveTerm creates or updates a term.
+func (r *Repository) SaveTerm(ctx context.Context, term *models.Term) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.saveTerm(term)
+	return nil
+}
+
+func (r *Repository) saveTerm(term *models.Term) {
+	now := time.Now()
+	if term.ID == 0 {
+		term.ID = r.nextID()
+	} else if existing, ok := r.terms[term.ID]; ok && term.CreatedAt.IsZero() {
+		term.CreatedAt = existing.CreatedAt
+	}
+	if term.CreatedAt.IsZero() {
+		term.CreatedAt = now
+	}
+	term.UpdatedAt = now
+	r.terms[term.ID] = clone(term)
+}
+
+// DeleteTerm deletes a term by ID.
+func (r *Repository) DeleteTerm(ctx context.Context, id int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.terms, id)
+	return nil
+}
+
+// BulkSaveTerms creates or updates multiple terms.
+func (r *Repository) BulkSaveTerms(ctx context.Context, terms []*models.Term) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, term := range terms {
+		r.saveTerm(term)
+	}
+	return nil
+}
+
+// Translation operations
+
+// GetTranslation retrieves a translation by version ID and key.
+func (r *Repository) GetTranslation(ctx context.Context, versionID int64, key string) (*models.Translation, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, t := range r.translationsByVersion(versionID) {
+		if r.sources[t.SourceID].Key == key {
+			return t, nil
+		}
+	}
+	return nil, interfaces.ErrTranslationNotFound
+}
+
+// ListTranslations retrieves translations for a version matching the filter.
+func (r *Repository) ListTranslations(ctx context.Context, versionID int64, filter interfaces.TranslationFilter) ([]*models.Translation, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var translations []*models.Translation
+	for _, t := range r.translationsByVersion(versionID) {
+		if matchesTranslation(t, filter) {
+			translations = append(translations, t)
+		}
+	}
+	return paginate(translations, filter.Limit, filter.Offset), nil
+}
+
+// translationsByVersion returns the translations of the sources linked to a version.
+func (r *Repository) translationsByVersion(versionID int64) []*models.Translation {
+	linked := r.linkedSources(func(v *models.ModVersion) bool { return v.ID == versionID })
+	return sortedByID(r.translations, translationID, func(t *models.Translation) bool {
+		return linked[t.SourceID]
+	})
+}
+
+// linkedSources returns the IDs of the sources linked to a version matching keep.
+func (r *Repository) linkedSources(keep func(*models.ModVersion) bool) map[int64]bool {
+	linked := make(map[int64]bool)
+	for _, sv := range r.sourceVersions {
+		if v, ok := r.versions[sv.ModVersionID]; ok && keep(v) {
+			linked[sv.SourceID] = true
+		}
+	}
+	return linked
+}
+
+func matchesTranslation(t *models.Translation, filter interfaces.TranslationFilter) bool {
+	return (filter.TargetLang == "" || t.TargetLang == filter.TargetLang) &&
+		(filter.Status == "" || t.Status == filter.Status)
+}
+
+// SaveTranslation creates or updates a translation. A new translation for a source and
+// language that already has one updates the existing row and takes its ID.
+func (r *Repository) SaveTranslation(ctx context.Context, translation *models.Translation) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.saveTranslation(translation)
+}
+
+// BulkSaveTranslations creates or updates multiple translations. Nothing is saved if any
+// translation refers to a missing source.
+func (r *Repository) BulkSaveTranslations(ctx context.Context, translations []*models.Translation) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, t := range translations {
+		if _, ok := r.sources[t.SourceID]; !ok {
+			return fmt.Errorf("failed to save translation: %w: %d", interfaces.ErrSourceNotFound, t.SourceID)
+		}
+	}
+	for _, t := range translations {
+		if err := r.saveTranslation(t); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *Repository) saveTranslation(translation *models.Translation) error {
+	if _, ok := r.sources[translation.SourceID]; !ok {
+		return fmt.Errorf("failed to save translation: %w: %d", interfaces.ErrSourceNotFound, translation.SourceID)
+	}
+	if translation.TargetLang == "" {
+		translation.TargetLang = "ja_jp"
+	}
+	if translation.Status == "" {
+		translation.Status = models.StatusPending
+	}
+
+	now := time.Now()
+	if translation.ID == 0 {
+		if existing := r.findTranslation(translation.SourceID, translation.TargetLang); existing != nil {
+			translation.ID = existing.ID
+			translation.CreatedAt = existing.CreatedAt
+		} else {
+			translation.ID = r.nextID()
+		}
+	} else if existing, ok := r.translations[translation.ID]; ok && translation.CreatedAt.IsZero() {
+		translation.CreatedAt = existing.CreatedAt
+	}
+	if translation.CreatedAt.IsZero() {
+		translation.CreatedAt = now
+	}
+	translation.UpdatedAt = now
+	r.translations[translation.ID] = clone(translation)
+	return nil
+}
+
+func (r *Repository) findTranslation(sourceID int64, targetLang string) *models.Translation {
+	for _, t := range r.translations {
+		if t.SourceID == sourceID && t.TargetLang == targetLang {
+			return t
+		}
+	}
+	return nil
+}
+
+// DeleteTranslation deletes a translation by ID.
+func (r *Repository) DeleteTranslation(ctx context.Context, id int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.translations, id)
+	return nil
+}
+
+// Pattern operations
+
+// GetPattern retrieves a pattern by ID.
+func (r *Repository) GetPattern(ctx context.Context, id int64) (*models.FilePattern, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	pattern, ok := r.patterns[id]
+	if !ok {
+		return nil, interfaces.ErrPatternNotFound
+	}
+	return clone(pattern), nil
+}
+
+// ListPatterns retrieves patterns for a scope (all patterns if empty), higher priority first.
+func (r *Repository) ListPatterns(ctx context.Context, scope string) ([]*models.FilePattern, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	patterns := sortedByID(r.patterns, patternID, func(p *models.FilePattern) bool {
+		return scope == "" || p.Scope == scope
+	})
+	sort.SliceStable(patterns, func(i, j int) bool { return patterns[i].Priority > patterns[j].Priority })
+	return patterns, nil
+}
+
+// SavePattern creates or updates a pattern.
+func (r *Repository) SavePattern(ctx context.Context, pattern *models.FilePattern) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if pattern.ID == 0 {
+		pattern.ID = r.nextID()
+	}
+	r.patterns[pattern.ID] = clone(pattern)
+	return nil
+}
+
+// DeletePattern deletes a pattern by ID.
+func (r *Repository) DeletePattern(ctx context.Context, id int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.patterns, id)
+	return nil
+}
+
+// Diff operations
+
+// ListDiffs retrieves diffs between two versions.
+func (r *Repository) ListDiffs(ctx context.Context, fromVersionID, toVersionID int64) ([]*models.VersionDiff, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return sortedByID(r.diffs, diffID, func(d *models.VersionDiff) bool {
+		return d.FromVersionID == fromVersionID && d.ToVersionID == toVersionID
+	}), nil
+}
+
+// SaveDiff creates or updates a diff.
+func (r *Repository) SaveDiff(ctx context.Context, diff *models.VersionDiff) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.saveDiff(diff)
+	return nil
+}
+
+// BulkSaveDiffs creates or updates multiple diffs.
+func (r *Repository) BulkSaveDiffs(ctx context.Context, diffs []*models.VersionDiff) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, diff := range diffs {
+		r.saveDiff(diff)
+	}
+	return nil
+}
+
+func (r *Repository) saveDiff(diff *models.VersionDiff) {
+	if diff.ID == 0 {
+		diff.ID = r.nextID()
+	}
+	if diff.CreatedAt.IsZero() {
+		diff.CreatedAt = time.Now()
+	}
+	r.diffs[diff.ID] = clone(diff)
+}
+
+// TranslationSource operations
+
+// GetSource retrieves a translation source by ID.
+func (r *Repository) GetSource(ctx context.Context, id int64) (*models.TranslationSource, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	source, ok := r.sources[id]
+	if !ok {
+		return nil, interfaces.ErrSourceNotFound
+	}
+	return clone(source), nil
+}
+
+// GetSourceByKey retrieves a source by mod ID, key, and source text. It returns nil
+// without an error if there is none.
+func (r *Repository) GetSourceByKey(ctx context.Context, modID, key, sourceText string) (*models.TranslationSource, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if source := r.findSource(modID, key, sourceText); source != nil {
+		return clone(source), nil
+	}
+	return nil, nil
+}
+
+func (r *Repository) findSource(modID, key, sourceText string) *models.TranslationSource {
+	for _, s := range r.sources {
+		if s.ModID == modID && s.Key == key && s.SourceText == sourceText {
+			return s
+		}
+	}
+	return nil
+}
+
+// GetSourceByModAndKey retrieves a source by mod ID and key, preferring sources linked to
+// the default version and then the newest. It returns nil without an error if there is none.
+func (r *Repository) GetSourceByModAndKey(ctx context.Context, modID, key string) (*models.TranslationSource, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	sources := sortedByID(r.sources, sourceID, func(s *models.TranslationSource) bool {
+		return s.ModID == modID && s.Key == key
+	})
+	if len(sources) == 0 {
+		return nil, nil
+	}
+
+	onDefault := r.linkedSources(isActiveDefault)
+	best := sources[len(sources)-1]
+	for i := len(sources) - 1; i >= 0; i-- {
+		if onDefault[sources[i].ID] {
+			best = sources[i]
+			break
+		}
+	}
+	return best, nil
+}
+
+// GetOrCreateSource finds an existing source or creates a new one. The mod must exist.
+// Returns (source, created, error) where created is true if a new source was created.
+func (r *Repository) GetOrCreateSource(ctx context.Context, modID, key, sourceText, sourceLang string) (*models.TranslationSource, bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if existing := r.findSource(modID, key, sourceText); existing != nil {
+		return clone(existing), false, nil
+	}
+	if _, ok := r.mods[modID]; !ok {
+		return nil, false, fmt.Errorf("failed to create source: %w: %s", interfaces.ErrModNotFound, modID)
+	}
+
+	now := time.Now()
+	source := &models.TranslationSource{
+		ID:         r.nextID(),
+		ModID:      modID,
+		Key:        key,
+		SourceText: sourceText,
+		SourceLang: sourceLang,
+		IsCurrent:  true,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+	r.sources[source.ID] = source
+	return clone(source), true, nil
+}
+
+// ListSourcesByMod retrieves the sources of a mod.
+func (r *Repository) ListSourcesByMod(ctx context.Context, modID string, currentOnly bool) ([]*models.TranslationSource, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return sortedByID(r.sources, sourceID, func(s *models.TranslationSource) bool {
+		return s.ModID == modID && (!currentOnly || s.IsCurrent)
+	}), nil
+}
+
+// ListSourcesByVersion retrieves all sources linked to a version.
+func (r *Repository) ListSourcesByVersion(ctx context.Context, versionID int64) ([]*models.TranslationSource, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	linked := r.linkedSources(func(v *models.ModVersion) bool { return v.ID == versionID })
+	return sortedByID(r.sources, sourceID, func(s *models.TranslationSource) bool {
+		return linked[s.ID]
+	}), nil
+}
+
+// LinkSourceToVersion creates a link between a source and a version. Linking twice is a no-op.
+func (r *Repository) LinkSourceToVersion(ctx context.Context, sourceID, versionID int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.sources[sourceID]; !ok {
+		return fmt.Errorf("failed to link source %d to version %d: %w", sourceID, versionID, interfaces.ErrSourceNotFound)
+	}
+	if _, ok := r.versions[versionID]; !ok {
+		return fmt.Errorf("failed to link source %d to version %d: %w", sourceID, versionID, interfaces.ErrVersionNotFound)
+	}
+	for _, sv := range r.sourceVersions {
+		if sv.SourceID == sourceID && sv.ModVersionID == versionID {
+			return nil
+		}
+	}
+
+	sv := &models.SourceVersion{
+		ID:           r.nextID(),
+		SourceID:     sourceID,
+		ModVersionID: versionID,
+		CreatedAt:    time.Now(),
+	}
+	r.sourceVersions[sv.ID] = sv
+	return nil
+}
+
+// GetSourceVersions retrieves all version links for a source.
+func (r *Repository) GetSourceVersions(ctx context.Context, sourceID int64) ([]*models.SourceVersion, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return sortedByID(r.sourceVersions, sourceVersionID, func(sv *models.SourceVersion) bool {
+		return sv.SourceID == sourceID
+	}), nil
+}
+
+func (r *Repository) deleteSource(id int64) {
+	delete(r.sources, id)
+	for svID, sv := range r.sourceVersions {
+		if sv.SourceID == id {
+			delete(r.sourceVersions, svID)
+		}
+	}
+	for tID, t := range r.translations {
+		if t.SourceID == id {
+			delete(r.translations, tID)
+		}
+	}
+}
+
+// Default version operations
+
+// GetDefaultVersion returns the default version for a mod, or its newest version if none
+// is marked as default. Archived versions are skipped.
+func (r *Repository) GetDefaultVersion(ctx context.Context, modID string) (*models.ModVersion, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	versions := sortedByID(r.versions, versionID, func(v *models.ModVersion) bool {
+		return v.ModID == modID && !v.IsArchived()
+	})
+	if len(versions) == 0 {
+		return nil, interfaces.ErrVersionNotFound
+	}
+	for _, v := range versions {
+		if v.IsDefault {
+			return v, nil
+		}
+	}
+	return versions[len(versions)-1], nil
+}
+
+// SetDefaultVersion sets a version as the default for its mod.
+func (r *Repository) SetDefaultVersion(ctx context.Context, versionID int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	version, ok := r.versions[versionID]
+	if !ok {
+		return fmt.Errorf("failed to get version: %w", interfaces.ErrVersionNotFound)
+	}
+	for _, v := range r.versions {
+		if v.ModID == version.ModID {
+			v.IsDefault = v.ID == versionID
+		}
+	}
+	return nil
+}
+
+// GetOrCreateVersion finds a version by mod ID and version or creates a new one.
+// Returns (version, created, error) where created is true if a new version was created.
+func (r *Repository) GetOrCreateVersion(ctx context.Context, modID, version, loader string) (*models.ModVersion, bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing := sortedByID(r.versions, versionID, func(v *models.ModVersion) bool {
+		return v.ModID == modID && v.Version == version
+	})
+	if len(existing) > 0 {
+		return existing[0], false, nil
+	}
+
+	newVersion := &models.ModVersion{
+		ModID:   modID,
+		Version: version,
+		Loader:  loader,
+	}
+	if err := r.saveVersion(newVersion); err != nil {
+		return nil, false, err
+	}
+	return newVersion, true, nil
+}
+
+// isActiveDefault reports whether v is the default version of its mod and not archived.
+func isActiveDefault(v *models.ModVersion) bool {
+	return v.IsDefault && !v.IsArchived()
+}
+
+// Archive operations
+
+// ArchiveMod archives a mod and its active versions with the same timestamp.
+func (r *Repository) ArchiveMod(ctx context.Context, modID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	mod, ok := r.mods[modID]
+	if !ok {
+		return interfaces.ErrModNotFound
+	}
+	if mod.IsArchived() {
+		return nil
+	}
+	now := time.Now()
+	mod.ArchivedAt = &now
+	for _, v := range r.versions {
+		if v.ModID == modID && !v.IsArchived() {
+			v.ArchivedAt = &now
+		}
+	}
+	return nil
+}
+
+// UnarchiveMod restores an archived mod together with the versions archived with it.
+func (r *Repository) UnarchiveMod(ctx context.Context, modID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	mod, ok := r.mods[modID]
+	if !ok {
+		return interfaces.ErrModNotFound
+	}
+	if !mod.IsArchived() {
+		return nil
+	}
+	for _, v := range r.versions {
+		if v.ModID == modID && v.IsArchived() && !v.ArchivedAt.Before(*mod.ArchivedAt) {
+			v.ArchivedAt = nil
+		}
+	}
+	mod.ArchivedAt = nil
+	return nil
+}
+
+// ArchiveVersion archives a version. If it was the default version, the newest active
+// version of the mod becomes the default.
+func (r *Repository) ArchiveVersion(ctx context.Context, id int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	version, ok := r.versions[id]
+	if !ok {
+		return interfaces.ErrVersionNotFound
+	}
+	if version.IsArchived() {
+		return nil
+	}
+	now := time.Now()
+	version.ArchivedAt = &now
+	if !version.IsDefault {
+		return nil
+	}
+
+	active := sortedByID(r.versions, versionID, func(v *models.ModVersion) bool {
+		return v.ModID == version.ModID && !v.IsArchived()
+	})
+	if len(active) > 0 {
+		version.IsDefault = false
+		r.versions[active[len(active)-1].ID].IsDefault = true
+	}
+	return nil
+}
+
+// UnarchiveVersion restores an archived version. Versions of an archived mod return
+// ErrModArchived.
+func (r *Repository) UnarchiveVersion(ctx context.Context, id int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	version, ok := r.versions[id]
+	if !ok {
+		return interfaces.ErrVersionNotFound
+	}
+	if r.mods[version.ModID].IsArchived() {
+		return interfaces.ErrModArchived
+	}
+	version.ArchivedAt = nil
+	return nil
+}
+
+// Source-based translation operations
+
+// GetTranslationBySource retrieves a translation by source ID and target language.
+func (r *Repository) GetTranslationBySource(ctx context.Context, sourceID int64, targetLang string) (*models.Translation, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if t := r.findTranslation(sourceID, targetLang); t != nil {
+		return clone(t), nil
+	}
+	return nil, interfaces.ErrTranslationNotFound
+}
+
+// ListTranslationsByMod retrieves translations for a mod (current sources only).
+func (r *Repository) ListTranslationsByMod(ctx context.Context, modID string, filter interfaces.TranslationFilter) ([]*models.Translation, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	translations := sortedByID(r.translations, translationID, func(t *models.Translation) bool {
+		s := r.sources[t.SourceID]
+		return s.ModID == modID && s.IsCurrent && matchesTranslation(t, filter)
+	})
+	return paginate(translations, filter.Limit, filter.Offset), nil
+}
+
+// ListTranslationsWithSourceByMod retrieves translations with source info for a mod's
+// default version.
+func (r *Repository) ListTranslationsWithSourceByMod(ctx context.Context, modID string, filter interfaces.TranslationFilter) ([]*models.TranslationWithSource, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	linked := r.linkedSources(func(v *models.ModVersion) bool { return v.ModID == modID && isActiveDefault(v) })
+	return r.translationsWithSource(linked, filter), nil
+}
+
+// ListTranslationsWithSourceByVersion retrieves translations with source info for the
+// sources linked to a specific mod version, regardless of the default version.
+func (r *Repository) ListTranslationsWithSourceByVersion(ctx context.Context, versionID int64, filter interfaces.TranslationFilter) ([]*models.TranslationWithSource, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	linked := r.linkedSources(func(v *models.ModVersion) bool { return v.ID == versionID })
+	return r.translationsWithSource(linked, filter), nil
+}
+
+// ListTranslationsWithSourceByModKeys retrieves the translations of the given keys in a
+// mod's default version. filter.Limit and filter.Offset are ignored.
+func (r *Repository) ListTranslationsWithSourceByModKeys(ctx context.Context, modID string, keys []string, filter interfaces.TranslationFilter) ([]*models.TranslationWithSource, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	linked := r.linkedSources(func(v *models.ModVersion) bool { return v.ModID == modID && isActiveDefault(v) })
+	return r.translationsWithSource(r.withKeys(linked, keys), withoutPaging(filter)), nil
+}
+
+// ListTranslationsWithSourceByVersionKeys retrieves the translations of the given keys in
+// a mod version. filter.Limit and filter.Offset are ignored.
+func (r *Repository) ListTranslationsWithSourceByVersionKeys(ctx context.Context, versionID int64, keys []string, filter interfaces.TranslationFilter) ([]*models.TranslationWithSource, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	linked := r.linkedSources(func(v *models.ModVersion) bool { return v.ID == versionID })
+	return r.translationsWithSource(r.withKeys(linked, keys), withoutPaging(filter)), nil
+}
+
+// withKeys narrows a set of source IDs to the sources with one of the keys.
+func (r *Repository) withKeys(sourceIDs map[int64]bool, keys []string) map[int64]bool {
+	wanted := make(map[string]bool, len(keys))
+	for _, k := range keys {
+		wanted[k] = true
+	}
+	result := make(map[int64]bool)
+	for id := range sourceIDs {
+		if wanted[r.sources[id].Key] {
+			result[id] = true
+		}
+	}
+	return result
+}
+
+func withoutPaging(filter interfaces.TranslationFilter) interfaces.TranslationFilter {
+	filter.Limit, filter.Offset = 0, 0
+	return filter
+}
+
+// StreamTranslationsWithSourceByMod calls fn for each translation of a mod's default
+// version in ID order. The translations are collected first, so fn may use the repository.
+func (r *Repository) StreamTranslationsWithSourceByMod(ctx context.Context, modID string, filter interfaces.TranslationFilter, fn func(*models.TranslationWithSource) error) error {
+	translations, err := r.ListTranslationsWithSourceByMod(ctx, modID, filter)
+	if err != nil {
+		return err
+	}
+	for _, t := range translations {
+		if err := fn(t); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// IterateTranslations calls fn for each translation matching the filter in ID order. The
+// matching translations are collected first, so fn may use the repository.
+func (r *Repository) IterateTranslations(ctx context.Context, filter interfaces.TranslationIterFilter, fn func(*models.TranslationWithSource) error) error {
+	r.mu.Lock()
+	linked := r.linkedSources(func(v *models.ModVersion) bool {
+		if filter.VersionID != 0 {
+			return v.ID == filter.VersionID
+		}
+		return v.IsDefault && (filter.IncludeArchived || !v.IsArchived()) &&
+			(filter.ModID == "" || v.ModID == filter.ModID)
+	})
+	statuses := make(map[string]bool, len(filter.Statuses))
+	for _, s := range filter.Statuses {
+		statuses[s] = true
+	}
+	var translations []*models.TranslationWithSource
+	for _, t := range r.translationsWithSource(linked, interfaces.TranslationFilter{TargetLang: filter.TargetLang}) {
+		if (filter.ModID == "" || t.ModID == filter.ModID) &&
+			(len(statuses) == 0 || statuses[t.Status]) &&
+			(!filter.TranslatedOnly || t.TargetText != nil && *t.TargetText != "") {
+			translations = append(translations, t)
+		}
+	}
+	r.mu.Unlock()
+
+	for _, t := range translations {
+		if err := fn(t); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *Repository) translationsWithSource(linked map[int64]bool, filter interfaces.TranslationFilter) []*models.TranslationWithSource {
+	translations := sortedByID(r.translations, translationID, func(t *models.Translation) bool {
+		return linked[t.SourceID] && matchesTranslation(t, filter)
+	})
+	translations = paginate(translations, filter.Limit, filter.Offset)
+
+	results := make([]*models.TranslationWithSource, 0, len(translations))
+	for _, t := range translations {
+		s := r.sources[t.SourceID]
+		results = append(results, &models.TranslationWithSource{
+			Translation: *t,
+			ModID:       s.ModID,
+			Key:         s.Key,
+			SourceText:  s.SourceText,
+			SourceLang:  s.SourceLang,
+			IsCurrent:   s.IsCurrent,
+		})
+	}
+	return results
+}
+
+// CountTranslationsByMod returns translation counts by status for a mod's default version.
+// Sources without a ja_jp translation are counted as "pending".
+func (r *Repository) CountTranslationsByMod(ctx context.Context, modID string) (map[string]int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	linked := r.linkedSources(func(v *models.ModVersion) bool { return v.ModID == modID && isActiveDefault(v) })
+
+	result := make(map[string]int)
+	for _, t := range r.translations {
+		if linked[t.SourceID] {
+			result[t.Status]++
+		}
+	}
+	for id := range linked {
+		if r.findTranslation(id, "ja_jp") == nil {
+			result[models.StatusPending]++
+		}
+	}
+	return result, nil
+}